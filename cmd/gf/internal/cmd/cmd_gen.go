@@ -23,13 +23,15 @@ type cGen struct {
 	cGenPb
 	cGenPbEntity
 	cGenService
+	cGenOpenapi
 }
 
 const (
-	cGenBrief = `automatically generate go files for dao/do/entity/pb/pbentity`
+	cGenBrief = `automatically generate go files for dao/do/entity/pb/pbentity/api`
 	cGenDc    = `
 The "gen" command is designed for multiple generating purposes. 
-It's currently supporting generating go files for ORM models, protobuf and protobuf entity files.
+It's currently supporting generating go files for ORM models, protobuf and protobuf entity files,
+and api definition files from OpenAPI documents.
 Please use "gf gen dao -h" for specified type help.
 `
 )
@@ -406,3 +406,41 @@ func Test_Gen_Ctrl_UseMerge_Issue3460(t *testing.T) {
 		}
 	})
 }
+
+func Test_Gen_Ctrl_TypeScript(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path      = gfile.Temp(guid.S())
+			tsPath    = filepath.Join(path, "ts")
+			apiFolder = gtest.DataPath("genctrl", "typescript", "api")
+			in        = genctrl.CGenCtrlInput{
+				SrcFolder:     apiFolder,
+				DstFolder:     filepath.Join(path, "controller"),
+				TsPath:        tsPath,
+				SdkStdVersion: true,
+			}
+		)
+		err := gutil.FillStructWithDefault(&in)
+		t.AssertNil(err)
+
+		err = gfile.Mkdir(path)
+		t.AssertNil(err)
+		defer gfile.RemoveAll(path)
+		defer gfile.RemoveAll(filepath.Join(apiFolder, "user", "user.go"))
+
+		_, err = genctrl.CGenCtrl{}.Ctrl(ctx, in)
+		t.AssertNil(err)
+
+		files, err := gfile.ScanDir(tsPath, "*.ts", true)
+		t.AssertNil(err)
+		t.Assert(files, []string{
+			filepath.Join(tsPath, "client.ts"),
+			filepath.Join(tsPath, "user_v1.ts"),
+		})
+
+		expectPath := gtest.DataPath("genctrl", "typescript", "ts")
+		for _, file := range files {
+			t.Assert(gfile.GetContents(file), gfile.GetContents(filepath.Join(expectPath, gfile.Basename(file))))
+		}
+	})
+}
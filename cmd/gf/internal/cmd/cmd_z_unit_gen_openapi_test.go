@@ -0,0 +1,92 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/gogf/gf/v2/util/gutil"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/cmd/genopenapi"
+)
+
+func Test_Gen_Openapi(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path = gfile.Temp(guid.S())
+			in   = genopenapi.CGenOpenapiInput{
+				Path:      gtest.DataPath("genopenapi", "openapi.yaml"),
+				DstFolder: path,
+			}
+		)
+		err := gutil.FillStructWithDefault(&in)
+		t.AssertNil(err)
+		defer gfile.RemoveAll(path)
+
+		_, err = genopenapi.CGenOpenapi{}.ApiFromOpenapi(ctx, in)
+		t.AssertNil(err)
+
+		files, err := gfile.ScanDir(path, "*.go", true)
+		t.AssertNil(err)
+		t.Assert(files, []string{
+			path + filepath.FromSlash("/file/v1/file.go"),
+			path + filepath.FromSlash("/user/v1/user.go"),
+		})
+
+		testPath := gtest.DataPath("genopenapi", "api")
+		for _, file := range []string{"/file/v1/file.go", "/user/v1/user.go"} {
+			t.Assert(
+				gfile.GetContents(path+filepath.FromSlash(file)),
+				gfile.GetContents(testPath+filepath.FromSlash(file)),
+			)
+		}
+
+		// Existing files are not overwritten in default.
+		err = gfile.PutContents(path+filepath.FromSlash("/user/v1/user.go"), "package v1")
+		t.AssertNil(err)
+		_, err = genopenapi.CGenOpenapi{}.ApiFromOpenapi(ctx, in)
+		t.AssertNil(err)
+		t.Assert(gfile.GetContents(path+filepath.FromSlash("/user/v1/user.go")), "package v1")
+
+		in.Overwrite = true
+		_, err = genopenapi.CGenOpenapi{}.ApiFromOpenapi(ctx, in)
+		t.AssertNil(err)
+		t.Assert(
+			gfile.GetContents(path+filepath.FromSlash("/user/v1/user.go")),
+			gfile.GetContents(testPath+filepath.FromSlash("/user/v1/user.go")),
+		)
+	})
+}
+
+func Test_Gen_Openapi_V31(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path = gfile.Temp(guid.S())
+			in   = genopenapi.CGenOpenapiInput{
+				Path:      gtest.DataPath("genopenapi", "openapi31.json"),
+				DstFolder: path,
+				Version:   "v2",
+			}
+		)
+		err := gutil.FillStructWithDefault(&in)
+		t.AssertNil(err)
+		defer gfile.RemoveAll(path)
+
+		_, err = genopenapi.CGenOpenapi{}.ApiFromOpenapi(ctx, in)
+		t.AssertNil(err)
+
+		var (
+			genFile    = path + filepath.FromSlash("/orders/v2/orders.go")
+			expectFile = gtest.DataPath("genopenapi", "api", "orders", "v2", "orders.go")
+		)
+		t.Assert(gfile.GetContents(genFile), gfile.GetContents(expectFile))
+	})
+}
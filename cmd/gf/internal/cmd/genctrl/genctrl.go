@@ -32,6 +32,7 @@ gf gen ctrl
 	CGenCtrlBriefSdkPath       = `also generate SDK go files for api definitions to specified directory`
 	CGenCtrlBriefSdkStdVersion = `use standard version prefix for generated sdk request path`
 	CGenCtrlBriefSdkNoV1       = `do not add version suffix for interface module name if version is v1`
+	CGenCtrlBriefTsPath        = `also generate TypeScript client files for api definitions to specified directory`
 	CGenCtrlBriefClear         = `auto delete generated and unimplemented controller go files if api definitions are missing`
 	CGenCtrlControllerMerge    = `generate all controller files into one go file by name of api definition source go file`
 )
@@ -56,6 +57,7 @@ func init() {
 		`CGenCtrlBriefSdkPath`:       CGenCtrlBriefSdkPath,
 		`CGenCtrlBriefSdkStdVersion`: CGenCtrlBriefSdkStdVersion,
 		`CGenCtrlBriefSdkNoV1`:       CGenCtrlBriefSdkNoV1,
+		`CGenCtrlBriefTsPath`:        CGenCtrlBriefTsPath,
 		`CGenCtrlBriefClear`:         CGenCtrlBriefClear,
 		`CGenCtrlControllerMerge`:    CGenCtrlControllerMerge,
 	})
@@ -71,6 +73,7 @@ type (
 		SdkPath       string `short:"k" name:"sdkPath"       brief:"{CGenCtrlBriefSdkPath}"`
 		SdkStdVersion bool   `short:"v" name:"sdkStdVersion" brief:"{CGenCtrlBriefSdkStdVersion}" orphan:"true"`
 		SdkNoV1       bool   `short:"n" name:"sdkNoV1"       brief:"{CGenCtrlBriefSdkNoV1}" orphan:"true"`
+		TsPath        string `short:"t" name:"tsPath"        brief:"{CGenCtrlBriefTsPath}"`
 		Clear         bool   `short:"c" name:"clear"         brief:"{CGenCtrlBriefClear}" orphan:"true"`
 		Merge         bool   `short:"m" name:"merge"         brief:"{CGenCtrlControllerMerge}" orphan:"true"`
 	}
@@ -80,7 +83,7 @@ type (
 func (c CGenCtrl) Ctrl(ctx context.Context, in CGenCtrlInput) (out *CGenCtrlOutput, err error) {
 	if in.WatchFile != "" {
 		err = c.generateByWatchFile(
-			in.WatchFile, in.SdkPath, in.SdkStdVersion, in.SdkNoV1, in.Clear, in.Merge,
+			in.WatchFile, in.SdkPath, in.TsPath, in.SdkStdVersion, in.SdkNoV1, in.Clear, in.Merge,
 		)
 		mlog.Print(`done!`)
 		return
@@ -99,7 +102,9 @@ func (c CGenCtrl) Ctrl(ctx context.Context, in CGenCtrlInput) (out *CGenCtrlOutp
 	return
 }
 
-func (c CGenCtrl) generateByWatchFile(watchFile, sdkPath string, sdkStdVersion, sdkNoV1, clear, merge bool) (err error) {
+func (c CGenCtrl) generateByWatchFile(
+	watchFile, sdkPath, tsPath string, sdkStdVersion, sdkNoV1, clear, merge bool,
+) (err error) {
 	// File lock to avoid multiple processes.
 	var (
 		flockFilePath = gfile.Temp("gf.cli.gen.service.lock")
@@ -142,7 +147,7 @@ func (c CGenCtrl) generateByWatchFile(watchFile, sdkPath string, sdkStdVersion,
 		dstModuleFolderPath = gfile.Join(projectRootPath, "internal", "controller", module)
 	)
 	return c.generateByModule(
-		apiModuleFolderPath, dstModuleFolderPath, sdkPath, sdkStdVersion, sdkNoV1, clear, merge,
+		apiModuleFolderPath, dstModuleFolderPath, sdkPath, tsPath, sdkStdVersion, sdkNoV1, clear, merge,
 	)
 }
 
@@ -186,7 +191,7 @@ func (c CGenCtrl) generateByModules(in CGenCtrlInput) (err error) {
 			dstModuleFolderPath = gfile.Join(in.DstFolder, module)
 		)
 		err = c.generateByModule(
-			moduleFolder, dstModuleFolderPath, in.SdkPath,
+			moduleFolder, dstModuleFolderPath, in.SdkPath, in.TsPath,
 			in.SdkStdVersion, in.SdkNoV1, in.Clear, in.Merge,
 		)
 		if err != nil {
@@ -198,7 +203,7 @@ func (c CGenCtrl) generateByModules(in CGenCtrlInput) (err error) {
 
 // parseApiModule parses certain api and generate associated go files by certain module, not all api modules.
 func (c CGenCtrl) generateByModule(
-	apiModuleFolderPath, dstModuleFolderPath, sdkPath string,
+	apiModuleFolderPath, dstModuleFolderPath, sdkPath, tsPath string,
 	sdkStdVersion, sdkNoV1, clear, merge bool,
 ) (err error) {
 	// parse src and dst folder go files.
@@ -267,5 +272,13 @@ func (c CGenCtrl) generateByModule(
 			return
 		}
 	}
+
+	// generate typescript client files.
+	if tsPath != "" {
+		err = newApiTsGenerator().Generate(apiModuleFolderPath, apiItemsInSrc, tsPath, sdkStdVersion, sdkNoV1)
+		if err != nil {
+			return
+		}
+	}
 	return
 }
@@ -0,0 +1,448 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genctrl
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/consts"
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+const tsClientFileName = "client.ts"
+
+// tsSelectorTypes maps the types from other packages to typescript types.
+var tsSelectorTypes = map[string]string{
+	"g.Map":             "Record<string, any>",
+	"g.MapAnyAny":       "Record<string, any>",
+	"g.MapStrAny":       "Record<string, any>",
+	"g.MapStrStr":       "Record<string, string>",
+	"g.MapStrInt":       "Record<string, number>",
+	"g.MapIntAny":       "Record<number, any>",
+	"g.List":            "Record<string, any>[]",
+	"g.ListStrAny":      "Record<string, any>[]",
+	"g.Slice":           "any[]",
+	"g.SliceAny":        "any[]",
+	"g.Array":           "any[]",
+	"g.SliceStr":        "string[]",
+	"g.ArrayStr":        "string[]",
+	"g.SliceInt":        "number[]",
+	"g.ArrayInt":        "number[]",
+	"gtime.Time":        "string",
+	"time.Time":         "string",
+	"time.Duration":     "number",
+	"gjson.Json":        "any",
+	"json.RawMessage":   "any",
+	"ghttp.UploadFile":  "Blob",
+	"ghttp.UploadFiles": "Blob[]",
+}
+
+type apiTsGenerator struct{}
+
+func newApiTsGenerator() *apiTsGenerator {
+	return &apiTsGenerator{}
+}
+
+// tsDefinition is a type definition in typescript file.
+type tsDefinition struct {
+	Name    string
+	Comment string
+	Extends []string   // Interfaces extended from embedded structs.
+	Fields  []*tsField // Fields of interface, for struct type only.
+	Type    string     // Aliased type for non-struct type.
+	Path    string     // Request path from g.Meta.
+	Method  string     // Request method from g.Meta.
+	IsAlias bool       // Whether it is a type alias definition.
+}
+
+// tsField is a field of typescript interface.
+type tsField struct {
+	Name     string
+	Type     string
+	Comment  string
+	Optional bool
+}
+
+func (c *apiTsGenerator) Generate(
+	apiModuleFolderPath string, apiModuleApiItems []apiItem, tsFolderPath string, sdkStdVersion, sdkNoV1 bool,
+) (err error) {
+	if err = c.doGenerateTsClientFile(tsFolderPath); err != nil {
+		return
+	}
+	var doneVersionSet = gset.NewStrSet()
+	for _, item := range apiModuleApiItems {
+		if doneVersionSet.Contains(item.Version) {
+			continue
+		}
+		doneVersionSet.Add(item.Version)
+		var subItems []apiItem
+		for _, subItem := range apiModuleApiItems {
+			if subItem.Module == item.Module && subItem.Version == item.Version {
+				subItems = append(subItems, subItem)
+			}
+		}
+		if err = c.doGenerateTsModuleFile(
+			subItems, gfile.Join(apiModuleFolderPath, item.Version), tsFolderPath,
+			item.Module, item.Version, sdkStdVersion, sdkNoV1,
+		); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c *apiTsGenerator) doGenerateTsClientFile(tsFolderPath string) (err error) {
+	var clientFilePath = filepath.FromSlash(gfile.Join(tsFolderPath, tsClientFileName))
+	if gfile.Exists(clientFilePath) {
+		return nil
+	}
+	if err = gfile.PutContents(clientFilePath, gstr.TrimLeft(consts.TemplateGenCtrlTsClient)); err != nil {
+		return
+	}
+	mlog.Printf(`generated: %s`, gfile.RealPath(clientFilePath))
+	return
+}
+
+func (c *apiTsGenerator) doGenerateTsModuleFile(
+	items []apiItem, apiVersionFolderPath, tsFolderPath, module, version string, sdkStdVersion, sdkNoV1 bool,
+) (err error) {
+	definitions, err := c.parseDefinitions(apiVersionFolderPath)
+	if err != nil {
+		return err
+	}
+	var (
+		className      = gstr.CaseCamel(module) + gstr.UcFirst(version)
+		versionPrefix  = ""
+		definitionMap  = make(map[string]*tsDefinition)
+		definitionsBuf = bytes.NewBuffer(nil)
+		methodsBuf     = bytes.NewBuffer(nil)
+		moduleFilePath = filepath.FromSlash(gfile.Join(tsFolderPath, fmt.Sprintf(
+			`%s_%s.ts`, gstr.CaseSnake(module), version,
+		)))
+	)
+	if sdkNoV1 && version == "v1" {
+		className = gstr.CaseCamel(module)
+	}
+	if sdkStdVersion {
+		versionPrefix = fmt.Sprintf(`/api/%s`, version)
+	}
+	for _, definition := range definitions {
+		definitionMap[definition.Name] = definition
+		definitionsBuf.WriteString(definition.Content())
+		definitionsBuf.WriteString("\n")
+	}
+	for _, item := range items {
+		var (
+			method = "POST"
+			path   string
+		)
+		if definition, ok := definitionMap[item.MethodName+"Req"]; ok {
+			path = definition.Path
+			if definition.Method != "" {
+				method = gstr.ToUpper(gstr.SplitAndTrim(definition.Method, ",")[0])
+			}
+		}
+		var methodComment string
+		if item.Comment != "" {
+			methodComment = fmt.Sprintf("\n  /** %s %s */", item.MethodName, item.Comment)
+		}
+		methodsBuf.WriteString(gstr.ReplaceByMap(consts.TemplateGenCtrlTsModuleMethod, g.MapStrStr{
+			"{MethodComment}":   methodComment,
+			"{MethodName}":      item.MethodName,
+			"{MethodNameLower}": gstr.CaseCamelLower(item.MethodName),
+			"{Method}":          method,
+			"{Path}":            versionPrefix + path,
+		}))
+	}
+	moduleFileContent := gstr.TrimLeft(gstr.ReplaceByMap(consts.TemplateGenCtrlTsModule, g.MapStrStr{
+		"{Definitions}": definitionsBuf.String(),
+		"{ClassName}":   className,
+		"{Methods}":     methodsBuf.String(),
+	}))
+	if err = gfile.PutContents(moduleFilePath, moduleFileContent); err != nil {
+		return
+	}
+	mlog.Printf(`generated: %s`, gfile.RealPath(moduleFilePath))
+	return
+}
+
+// parseDefinitions parses all type definitions of the api version folder in source order.
+func (c *apiTsGenerator) parseDefinitions(apiVersionFolderPath string) (definitions []*tsDefinition, err error) {
+	filePaths, err := gfile.ScanDir(apiVersionFolderPath, "*.go", false)
+	if err != nil {
+		return nil, err
+	}
+	var fileSet = token.NewFileSet()
+	for _, filePath := range filePaths {
+		if gstr.HasSuffix(filePath, "_test.go") {
+			continue
+		}
+		node, err := parser.ParseFile(fileSet, filePath, gfile.GetContents(filePath), parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range node.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if !typeSpec.Name.IsExported() || typeSpec.TypeParams != nil {
+					continue
+				}
+				var doc = typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				definitions = append(definitions, c.parseDefinition(typeSpec, doc))
+			}
+		}
+	}
+	return
+}
+
+func (c *apiTsGenerator) parseDefinition(typeSpec *ast.TypeSpec, doc *ast.CommentGroup) *tsDefinition {
+	var definition = &tsDefinition{
+		Name:    typeSpec.Name.Name,
+		Comment: tsComment(doc),
+	}
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		definition.IsAlias = true
+		definition.Type = c.tsType(typeSpec.Type)
+		return definition
+	}
+	var isRequest = gstr.HasSuffix(definition.Name, "Req")
+	for _, field := range structType.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		}
+		// Embedded field.
+		if len(field.Names) == 0 {
+			switch c.exprString(field.Type) {
+			case "g.Meta", "gmeta.Meta":
+				definition.Path = tag.Get("path")
+				definition.Method = tag.Get("method")
+			default:
+				if ident, ok := field.Type.(*ast.Ident); ok {
+					definition.Extends = append(definition.Extends, ident.Name)
+				} else if star, ok := field.Type.(*ast.StarExpr); ok {
+					if ident, ok = star.X.(*ast.Ident); ok {
+						definition.Extends = append(definition.Extends, ident.Name)
+					}
+				}
+			}
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			tsField := c.parseField(name.Name, field, tag, isRequest)
+			if tsField != nil {
+				definition.Fields = append(definition.Fields, tsField)
+			}
+		}
+	}
+	return definition
+}
+
+func (c *apiTsGenerator) parseField(name string, field *ast.Field, tag reflect.StructTag, isRequest bool) *tsField {
+	var (
+		jsonName, jsonOptions, _ = strings.Cut(tag.Get("json"), ",")
+		_, isPointer             = field.Type.(*ast.StarExpr)
+		tsField                  = &tsField{
+			Name: name,
+			Type: c.tsType(field.Type),
+		}
+	)
+	if jsonName == "-" && jsonOptions == "" {
+		return nil
+	}
+	if jsonName != "" {
+		tsField.Name = jsonName
+	}
+	tsField.Optional = isPointer || gstr.Contains(jsonOptions, "omitempty")
+	if isRequest {
+		// Request fields are optional unless they are required in validation,
+		// as server fills the missing fields with default values.
+		tsField.Optional = !gstr.InArray(gstr.SplitAndTrim(tag.Get("v"), "|"), "required")
+	}
+	for _, key := range []string{"dc", "description", "des"} {
+		if tsField.Comment = tag.Get(key); tsField.Comment != "" {
+			break
+		}
+	}
+	if tsField.Comment == "" {
+		tsField.Comment = tsComment(field.Doc)
+		if tsField.Comment == "" {
+			tsField.Comment = tsComment(field.Comment)
+		}
+	}
+	return tsField
+}
+
+// tsType converts the go type expression to typescript type.
+func (c *apiTsGenerator) tsType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return "string"
+		case "bool":
+			return "boolean"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"float32", "float64", "byte", "rune":
+			return "number"
+		case "any", "error":
+			return "any"
+		default:
+			return t.Name
+		}
+
+	case *ast.StarExpr:
+		return c.tsType(t.X)
+
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" && t.Len == nil {
+			// Bytes are encoded as base64 string in json.
+			return "string"
+		}
+		elemType := c.tsType(t.Elt)
+		if gstr.Contains(elemType, "|") {
+			elemType = "(" + elemType + ")"
+		}
+		return elemType + "[]"
+
+	case *ast.MapType:
+		keyType := c.tsType(t.Key)
+		if keyType != "number" {
+			keyType = "string"
+		}
+		return fmt.Sprintf("Record<%s, %s>", keyType, c.tsType(t.Value))
+
+	case *ast.SelectorExpr:
+		if tsType, ok := tsSelectorTypes[c.exprString(t)]; ok {
+			return tsType
+		}
+		return "any"
+
+	case *ast.StructType:
+		var items = make([]string, 0)
+		for _, field := range t.Fields.List {
+			var tag reflect.StructTag
+			if field.Tag != nil {
+				tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+			}
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					continue
+				}
+				if tsField := c.parseField(name.Name, field, tag, false); tsField != nil {
+					items = append(items, tsField.declaration())
+				}
+			}
+		}
+		if len(items) == 0 {
+			return "Record<string, never>"
+		}
+		return "{ " + strings.Join(items, "; ") + " }"
+
+	default:
+		return "any"
+	}
+}
+
+func (c *apiTsGenerator) exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return c.exprString(t.X)
+	case *ast.SelectorExpr:
+		return c.exprString(t.X) + "." + t.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// Content returns the typescript declaration of the definition.
+func (d *tsDefinition) Content() string {
+	var buffer = bytes.NewBuffer(nil)
+	if d.Comment != "" {
+		buffer.WriteString(fmt.Sprintf("/** %s */\n", d.Comment))
+	}
+	if d.IsAlias {
+		buffer.WriteString(fmt.Sprintf("export type %s = %s;\n", d.Name, d.Type))
+		return buffer.String()
+	}
+	buffer.WriteString("export interface " + d.Name)
+	if len(d.Extends) > 0 {
+		buffer.WriteString(" extends " + strings.Join(d.Extends, ", "))
+	}
+	if len(d.Fields) == 0 {
+		buffer.WriteString(" {}\n")
+		return buffer.String()
+	}
+	buffer.WriteString(" {\n")
+	for _, field := range d.Fields {
+		if field.Comment != "" {
+			buffer.WriteString(fmt.Sprintf("  /** %s */\n", field.Comment))
+		}
+		buffer.WriteString(fmt.Sprintf("  %s;\n", field.declaration()))
+	}
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+func (f *tsField) declaration() string {
+	var name = f.Name
+	if !isTsIdentifier(name) {
+		name = fmt.Sprintf("'%s'", name)
+	}
+	if f.Optional {
+		return fmt.Sprintf("%s?: %s", name, f.Type)
+	}
+	return fmt.Sprintf("%s: %s", name, f.Type)
+}
+
+// tsComment returns the comment text in single line.
+func tsComment(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	comment := strings.Join(strings.Fields(doc.Text()), " ")
+	return gstr.Replace(comment, "*/", "* /")
+}
+
+// isTsIdentifier checks whether `name` can be used as identifier without quotes.
+func isTsIdentifier(name string) bool {
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
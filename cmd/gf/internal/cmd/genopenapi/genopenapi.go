@@ -0,0 +1,106 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genopenapi
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gtag"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/utils"
+)
+
+const (
+	CGenOpenapiConfig = `gfcli.gen.openapi`
+	CGenOpenapiUsage  = `gf gen api-from-openapi [OPTION]`
+	CGenOpenapiBrief  = `parse OpenAPI document to generate api definition go files`
+	CGenOpenapiEg     = `
+gf gen api-from-openapi -p openapi.yaml
+gf gen api-from-openapi -p openapi.json -d api -v v2
+gf gen api-from-openapi -p https://example.com/api.json -m user
+`
+	CGenOpenapiAd = `
+The generated api definitions are organized as "{dstFolder}/{module}/{version}/{module}.go",
+which is the layout that "gf gen ctrl" expects, so the controllers can be generated after that.
+The module of an operation is its first tag in default, or the first segment of its path if it has no tag.
+Request and response structs are named by the operationId of the operations.
+Schema constraints are converted to validation rules in "v" tag, parameter locations to "in" tag
+and descriptions to "dc" tag of the struct fields.
+`
+	CGenOpenapiBriefPath      = `file path or url of the OpenAPI 3.0/3.1 document in json or yaml format`
+	CGenOpenapiBriefDstFolder = `destination folder path storing automatically generated api go files. default: api`
+	CGenOpenapiBriefModule    = `module name for all the operations, which is the first tag of each operation in default`
+	CGenOpenapiBriefVersion   = `version name of the generated api definitions. default: v1`
+	CGenOpenapiBriefOverwrite = `overwrite the existing api go files`
+)
+
+func init() {
+	gtag.Sets(g.MapStrStr{
+		`CGenOpenapiConfig`:         CGenOpenapiConfig,
+		`CGenOpenapiUsage`:          CGenOpenapiUsage,
+		`CGenOpenapiBrief`:          CGenOpenapiBrief,
+		`CGenOpenapiEg`:             CGenOpenapiEg,
+		`CGenOpenapiAd`:             CGenOpenapiAd,
+		`CGenOpenapiBriefPath`:      CGenOpenapiBriefPath,
+		`CGenOpenapiBriefDstFolder`: CGenOpenapiBriefDstFolder,
+		`CGenOpenapiBriefModule`:    CGenOpenapiBriefModule,
+		`CGenOpenapiBriefVersion`:   CGenOpenapiBriefVersion,
+		`CGenOpenapiBriefOverwrite`: CGenOpenapiBriefOverwrite,
+	})
+}
+
+type (
+	CGenOpenapi      struct{}
+	CGenOpenapiInput struct {
+		g.Meta    `name:"api-from-openapi" config:"{CGenOpenapiConfig}" usage:"{CGenOpenapiUsage}" brief:"{CGenOpenapiBrief}" eg:"{CGenOpenapiEg}" ad:"{CGenOpenapiAd}"`
+		Path      string `short:"p" name:"path"      brief:"{CGenOpenapiBriefPath}"`
+		DstFolder string `short:"d" name:"dstFolder" brief:"{CGenOpenapiBriefDstFolder}" d:"api"`
+		Module    string `short:"m" name:"module"    brief:"{CGenOpenapiBriefModule}"`
+		Version   string `short:"v" name:"version"   brief:"{CGenOpenapiBriefVersion}" d:"v1"`
+		Overwrite bool   `short:"o" name:"overwrite" brief:"{CGenOpenapiBriefOverwrite}" orphan:"true"`
+	}
+	CGenOpenapiOutput struct{}
+)
+
+func (c CGenOpenapi) ApiFromOpenapi(ctx context.Context, in CGenOpenapiInput) (out *CGenOpenapiOutput, err error) {
+	if in.Path == "" {
+		mlog.Fatal(`OpenAPI document path should not be empty, use "-p" to specify it`)
+	}
+	if in.Version == "" {
+		in.Version = "v1"
+	}
+	document, err := loadDocument(ctx, in.Path)
+	if err != nil {
+		return nil, err
+	}
+	files, err := newApiParser(document, in.Module, in.Version).Parse()
+	if err != nil {
+		return nil, err
+	}
+	var sourceName = gfile.Basename(in.Path)
+	if gstr.HasPrefix(in.Path, "http://") || gstr.HasPrefix(in.Path, "https://") {
+		sourceName = in.Path
+	}
+	for _, file := range files {
+		var filePath = gfile.Join(in.DstFolder, file.Module, file.Version, file.Module+".go")
+		if !in.Overwrite && gfile.Exists(filePath) {
+			mlog.Printf(`api file "%s" exists, skip generating, use "-o" to overwrite it`, filePath)
+			continue
+		}
+		if err = gfile.PutContents(filePath, file.Content(sourceName)); err != nil {
+			return nil, err
+		}
+		utils.GoFmt(filePath)
+		mlog.Printf(`generated: %s`, filePath)
+	}
+	mlog.Print(`done!`)
+	return
+}
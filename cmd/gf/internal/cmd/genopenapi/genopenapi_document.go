@@ -0,0 +1,243 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genopenapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// document is a node of the parsed OpenAPI document,
+// which keeps the key order of the document for stable generating result.
+type document = *gmap.ListMap
+
+// loadDocument loads and parses the OpenAPI document from local file or remote url.
+func loadDocument(ctx context.Context, path string) (document, error) {
+	var content []byte
+	if gstr.HasPrefix(path, "http://") || gstr.HasPrefix(path, "https://") {
+		response, err := g.Client().Get(ctx, path)
+		if err != nil {
+			return nil, gerror.Wrapf(err, `fetch OpenAPI document "%s" failed`, path)
+		}
+		defer response.Close()
+		content = response.ReadAll()
+	} else {
+		if !gfile.Exists(path) {
+			return nil, gerror.Newf(`OpenAPI document "%s" does not exist`, path)
+		}
+		content = gfile.GetBytes(path)
+	}
+	root, err := parseDocument(content)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `parse OpenAPI document "%s" failed`, path)
+	}
+	version := getString(root, "openapi")
+	if !gstr.HasPrefix(version, "3.") {
+		if getString(root, "swagger") != "" {
+			return nil, gerror.Newf(`Swagger %s document is not supported, please convert it to OpenAPI 3.x`, getString(root, "swagger"))
+		}
+		return nil, gerror.Newf(`unsupported OpenAPI version "%s", only 3.0 and 3.1 are supported`, version)
+	}
+	return root, nil
+}
+
+// parseDocument parses json or yaml content into ordered document.
+func parseDocument(content []byte) (document, error) {
+	var (
+		value any
+		err   error
+	)
+	content = bytes.TrimSpace(content)
+	if len(content) > 0 && content[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		value, err = decodeJsonValue(decoder)
+	} else {
+		var node yaml.Node
+		if err = yaml.Unmarshal(content, &node); err != nil {
+			return nil, err
+		}
+		value, err = decodeYamlNode(&node)
+	}
+	if err != nil {
+		return nil, err
+	}
+	root, ok := value.(document)
+	if !ok {
+		return nil, gerror.New(`the root of OpenAPI document should be an object`)
+	}
+	return root, nil
+}
+
+// decodeJsonValue decodes the next json value from decoder with object key order kept.
+func decodeJsonValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := token.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			object := gmap.NewListMap()
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeJsonValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object.Set(gconv.String(keyToken), value)
+			}
+			// Consumes the closing delimiter.
+			if _, err = decoder.Token(); err != nil {
+				return nil, err
+			}
+			return object, nil
+
+		case '[':
+			array := make([]any, 0)
+			for decoder.More() {
+				value, err := decodeJsonValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			}
+			if _, err = decoder.Token(); err != nil {
+				return nil, err
+			}
+			return array, nil
+
+		default:
+			return nil, gerror.Newf(`unexpected json delimiter "%s"`, v)
+		}
+
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+		return v.Float64()
+
+	default:
+		return v, nil
+	}
+}
+
+// decodeYamlNode decodes yaml node into value with mapping key order kept.
+func decodeYamlNode(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, io.EOF
+		}
+		return decodeYamlNode(node.Content[0])
+
+	case yaml.MappingNode:
+		object := gmap.NewListMap()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := decodeYamlNode(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			object.Set(node.Content[i].Value, value)
+		}
+		return object, nil
+
+	case yaml.SequenceNode:
+		array := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			value, err := decodeYamlNode(item)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		return array, nil
+
+	case yaml.AliasNode:
+		return decodeYamlNode(node.Alias)
+
+	default:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// getDocument returns the child object of `object` by `key`, or nil if it's not an object.
+func getDocument(object document, key string) document {
+	if object == nil {
+		return nil
+	}
+	child, _ := object.Get(key).(document)
+	return child
+}
+
+// getArray returns the child array of `object` by `key`.
+func getArray(object document, key string) []any {
+	if object == nil {
+		return nil
+	}
+	array, _ := object.Get(key).([]any)
+	return array
+}
+
+// getString returns the child value of `object` by `key` as string.
+func getString(object document, key string) string {
+	if object == nil {
+		return ""
+	}
+	if value := object.Get(key); value != nil {
+		return gconv.String(value)
+	}
+	return ""
+}
+
+// resolvePointer resolves the local json pointer like "#/components/schemas/User" in `root`.
+// It returns nil if the pointer cannot be resolved.
+func resolvePointer(root document, pointer string) any {
+	if !gstr.HasPrefix(pointer, "#/") {
+		return nil
+	}
+	var current any = root
+	for _, token := range strings.Split(pointer[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := current.(type) {
+		case document:
+			if !v.Contains(token) {
+				return nil
+			}
+			current = v.Get(token)
+		case []any:
+			index := gconv.Int(token)
+			if index < 0 || index >= len(v) {
+				return nil
+			}
+			current = v[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
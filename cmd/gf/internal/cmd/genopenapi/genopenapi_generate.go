@@ -0,0 +1,147 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genopenapi
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/text/gstr"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/consts"
+)
+
+// apiFile is the api definition file of a module.
+type apiFile struct {
+	Module      string
+	Version     string
+	definitions []*apiDefinition  // Request and response definitions in operation order.
+	models      []*apiDefinition  // Struct definitions converted from schemas.
+	typeNames   *gset.StrSet      // All type names in the file, to avoid name conflicts.
+	components  map[string]string // Component schema name => type name.
+	imports     *gset.StrSet      // Import packages.
+}
+
+// apiDefinition is a type definition in api file.
+type apiDefinition struct {
+	Name    string      // Type name.
+	Comment string      // Comment of the type.
+	Meta    string      // Tag of g.Meta field, for struct only.
+	Type    string      // Underlying type for non-struct type, eg: []User.
+	Fields  []*apiField // Struct fields.
+}
+
+// apiField is a struct field of api definition.
+type apiField struct {
+	Name string // Field name, empty for embedded field.
+	Type string // Field type.
+	Tag  string // Field tag.
+}
+
+func newApiFile(module, version string) *apiFile {
+	return &apiFile{
+		Module:     module,
+		Version:    version,
+		typeNames:  gset.NewStrSet(),
+		components: make(map[string]string),
+		imports:    gset.NewStrSet(),
+	}
+}
+
+// newOperationName returns a unique operation name in the file,
+// the request and response names are reserved by the way.
+func (f *apiFile) newOperationName(name string) string {
+	var uniqueName = name
+	for i := 2; f.typeNames.Contains(uniqueName+"Req") || f.typeNames.Contains(uniqueName+"Res"); i++ {
+		uniqueName = fmt.Sprintf(`%s%d`, name, i)
+	}
+	f.typeNames.Add(uniqueName+"Req", uniqueName+"Res")
+	return uniqueName
+}
+
+// newTypeName returns a unique type name in the file.
+func (f *apiFile) newTypeName(name string) string {
+	var uniqueName = name
+	for i := 2; f.typeNames.Contains(uniqueName); i++ {
+		uniqueName = fmt.Sprintf(`%s%d`, name, i)
+	}
+	f.typeNames.Add(uniqueName)
+	return uniqueName
+}
+
+// Content generates and returns the go source content of the api file.
+func (f *apiFile) Content(sourceName string) string {
+	var (
+		buffer      = bytes.NewBuffer(nil)
+		definitions = append(append([]*apiDefinition{}, f.definitions...), f.models...)
+		imports     = gset.NewStrSetFrom(f.imports.Slice())
+	)
+	for _, definition := range definitions {
+		if definition.Type != "" {
+			buffer.WriteString(definition.typeContent())
+		} else {
+			buffer.WriteString(definition.structContent())
+		}
+		buffer.WriteString("\n")
+		if definition.Meta != "" {
+			imports.Add("github.com/gogf/gf/v2/frame/g")
+		}
+	}
+	var importContent string
+	if imports.Size() > 0 {
+		importContent = "import (\n"
+		for _, item := range garray.NewSortedStrArrayFrom(imports.Slice()).Slice() {
+			importContent += fmt.Sprintf("\t\"%s\"\n", item)
+		}
+		importContent += ")"
+	}
+	content := gstr.ReplaceByMap(consts.TemplateGenOpenapiApi, g.MapStrStr{
+		"{SourceName}":  sourceName,
+		"{PackageName}": f.Version,
+		"{Imports}":     importContent,
+		"{Definitions}": buffer.String(),
+	})
+	return gstr.Trim(content) + "\n"
+}
+
+func (d *apiDefinition) typeContent() string {
+	return d.commentContent() + fmt.Sprintf("type %s %s\n", d.Name, d.Type)
+}
+
+func (d *apiDefinition) structContent() string {
+	if d.Meta == "" && len(d.Fields) == 0 {
+		return d.commentContent() + fmt.Sprintf("type %s struct{}\n", d.Name)
+	}
+	var buffer = bytes.NewBuffer(nil)
+	buffer.WriteString(d.commentContent())
+	buffer.WriteString(fmt.Sprintf("type %s struct {\n", d.Name))
+	if d.Meta != "" {
+		buffer.WriteString(fmt.Sprintf("\tg.Meta `%s`\n", d.Meta))
+	}
+	for _, field := range d.Fields {
+		switch {
+		case field.Name == "":
+			buffer.WriteString(fmt.Sprintf("\t%s\n", field.Type))
+		case field.Tag == "":
+			buffer.WriteString(fmt.Sprintf("\t%s %s\n", field.Name, field.Type))
+		default:
+			buffer.WriteString(fmt.Sprintf("\t%s %s `%s`\n", field.Name, field.Type, field.Tag))
+		}
+	}
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+func (d *apiDefinition) commentContent() string {
+	if d.Comment == "" {
+		return ""
+	}
+	return fmt.Sprintf("// %s\n", gstr.Trim(tagValueReplacer.Replace(d.Comment)))
+}
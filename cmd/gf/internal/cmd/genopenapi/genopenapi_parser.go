@@ -0,0 +1,678 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genopenapi
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+const (
+	componentSchemaRefPrefix = `#/components/schemas/`
+	maxRefResolvingDepth     = 32
+)
+
+var (
+	// operationMethods is the supported method names of path item.
+	operationMethods = gset.NewStrSetFrom([]string{
+		"get", "put", "post", "delete", "options", "head", "patch", "trace",
+	})
+	// formatValidationRules maps the string formats to validation rules.
+	formatValidationRules = map[string]string{
+		"email": "email",
+		"uri":   "url",
+		"url":   "url",
+		"ipv4":  "ipv4",
+		"ipv6":  "ipv6",
+		"date":  "date",
+	}
+	// tagValueReplacer replaces the line breaks in struct tag value.
+	tagValueReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+)
+
+// apiParser parses the OpenAPI document into api definitions grouped by modules.
+type apiParser struct {
+	root      document
+	module    string                            // Module name for all operations, optional.
+	version   string                            // Version name for all operations.
+	files     *gmap.ListKVMap[string, *apiFile] // Module name => api file.
+	resolving *gset.StrSet                      // Non-struct schema references being resolved, to avoid infinite recursion.
+}
+
+func newApiParser(root document, module, version string) *apiParser {
+	if module != "" {
+		module = toModuleName(module)
+	}
+	return &apiParser{
+		root:      root,
+		module:    module,
+		version:   version,
+		files:     gmap.NewListKVMap[string, *apiFile](),
+		resolving: gset.NewStrSet(),
+	}
+}
+
+// Parse parses all operations of the document and returns the api files.
+func (p *apiParser) Parse() ([]*apiFile, error) {
+	paths := getDocument(p.root, "paths")
+	if paths == nil || paths.Size() == 0 {
+		return nil, gerror.New(`no paths defined in OpenAPI document`)
+	}
+	paths.Iterator(func(k, v any) bool {
+		var (
+			path           = gconv.String(k)
+			pathItem       = p.resolveDocument(v)
+			pathParameters = getArray(pathItem, "parameters")
+		)
+		if pathItem == nil {
+			return true
+		}
+		pathItem.Iterator(func(k, v any) bool {
+			method := strings.ToLower(gconv.String(k))
+			if !operationMethods.Contains(method) {
+				return true
+			}
+			if operation, ok := v.(document); ok {
+				p.parseOperation(path, method, operation, pathParameters)
+			}
+			return true
+		})
+		return true
+	})
+	if p.files.Size() == 0 {
+		return nil, gerror.New(`no operations defined in OpenAPI document`)
+	}
+	return p.files.Values(), nil
+}
+
+// parseOperation parses one operation into request and response definitions.
+func (p *apiParser) parseOperation(path, method string, operation document, pathParameters []any) {
+	var (
+		file    = p.getFile(p.operationModule(path, operation))
+		name    = file.newOperationName(p.operationName(path, method, operation))
+		summary = getString(operation, "summary")
+		req     = &apiDefinition{Name: name + "Req"}
+		res     = &apiDefinition{Name: name + "Res"}
+		metas   = []string{
+			tagPair("path", path),
+			tagPair("method", method),
+		}
+	)
+	if summary != "" {
+		req.Comment = fmt.Sprintf(`%s %s`, req.Name, summary)
+	}
+	file.definitions = append(file.definitions, req, res)
+
+	// Request meta.
+	if tags := getArray(operation, "tags"); len(tags) > 0 {
+		metas = append(metas, tagPair("tags", gstr.JoinAny(tags, ",")))
+	}
+	if summary != "" {
+		metas = append(metas, tagPair("summary", summary))
+	}
+	if description := getString(operation, "description"); description != "" {
+		metas = append(metas, tagPair("dc", description))
+	}
+	if gconv.Bool(getString(operation, "deprecated")) {
+		metas = append(metas, tagPair("deprecated", "true"))
+	}
+
+	// Request parameters, the operation parameters overwrite the path parameters.
+	var (
+		fieldNames     = gset.NewStrSet()
+		parameters     = gmap.NewListKVMap[string, document]()
+		parameterItems = make([]any, 0)
+	)
+	parameterItems = append(parameterItems, pathParameters...)
+	parameterItems = append(parameterItems, getArray(operation, "parameters")...)
+	for _, item := range parameterItems {
+		if parameter := p.resolveDocument(item); parameter != nil {
+			parameters.Set(getString(parameter, "in")+":"+getString(parameter, "name"), parameter)
+		}
+	}
+	parameters.Iterator(func(_ string, parameter document) bool {
+		var (
+			in          = getString(parameter, "in")
+			paramName   = getString(parameter, "name")
+			fieldName   = newFieldName(fieldNames, paramName)
+			schema      = getDocument(parameter, "schema")
+			description = getString(parameter, "description")
+			required    = in == "path" || gconv.Bool(getString(parameter, "required"))
+		)
+		if schema == nil {
+			_, media := selectMedia(getDocument(parameter, "content"))
+			schema = getDocument(media, "schema")
+		}
+		req.Fields = append(req.Fields, &apiField{
+			Name: fieldName,
+			Type: p.schemaType(file, schema, req.Name+fieldName, true),
+			Tag:  p.fieldTag(paramName, in, description, schema, required, true),
+		})
+		return true
+	})
+
+	// Request body.
+	if requestBody := p.resolveDocument(operation.Get("requestBody")); requestBody != nil {
+		mime, media := selectMedia(getDocument(requestBody, "content"))
+		if mime != "" && !isJsonMime(mime) {
+			metas = append(metas, tagPair("mime", mime))
+		}
+		if fields, ok := p.structFields(file, getDocument(media, "schema"), req.Name, true, fieldNames); ok {
+			req.Fields = append(req.Fields, fields...)
+		} else if media != nil {
+			mlog.Printf(`request body of operation "%s %s" is not an object, it is ignored`, strings.ToUpper(method), path)
+		}
+	}
+	req.Meta = strings.Join(metas, " ")
+
+	// Response, which uses the first successful response of the operation.
+	var (
+		response  document
+		status    string
+		responses = getDocument(operation, "responses")
+	)
+	if responses != nil {
+		responses.Iterator(func(k, v any) bool {
+			if code := gconv.String(k); gstr.HasPrefix(code, "2") {
+				response, status = p.resolveDocument(v), code
+				return false
+			}
+			return true
+		})
+	}
+	if response == nil {
+		return
+	}
+	var (
+		resMetas      []string
+		mime, media   = selectMedia(getDocument(response, "content"))
+		schema        = getDocument(media, "schema")
+		target, _     = p.resolveSchema(schema)
+		resFieldNames = gset.NewStrSet()
+	)
+	if gstr.IsNumeric(status) && status != "200" {
+		resMetas = append(resMetas, tagPair("status", status))
+	}
+	if mime != "" && !isJsonMime(mime) {
+		resMetas = append(resMetas, tagPair("mime", mime))
+	}
+	if schema != nil && !isStructSchema(target) && schemaKind(target) != "" {
+		res.Type = strings.TrimPrefix(p.schemaType(file, schema, res.Name, false), "*")
+		return
+	}
+	res.Meta = strings.Join(resMetas, " ")
+	res.Fields, _ = p.structFields(file, schema, res.Name, false, resFieldNames)
+}
+
+// structFields converts the object schema into struct fields.
+// The referenced component schema is converted to an embedded field,
+// and the inline schemas of `allOf` are merged into the fields.
+// It returns false if the schema is not an object.
+func (p *apiParser) structFields(
+	file *apiFile, schema document, parentName string, forRequest bool, fieldNames *gset.StrSet,
+) ([]*apiField, bool) {
+	target, refName := p.resolveSchema(schema)
+	if target == nil || !isStructSchema(target) {
+		return nil, false
+	}
+	if refName != "" {
+		var typeName = p.componentType(file, refName, target)
+		fieldNames.Add(typeName)
+		return []*apiField{{Type: typeName}}, true
+	}
+	var fields = make([]*apiField, 0)
+	for _, item := range getArray(target, "allOf") {
+		if itemSchema, ok := item.(document); ok {
+			itemFields, _ := p.structFields(file, itemSchema, parentName, forRequest, fieldNames)
+			fields = append(fields, itemFields...)
+		}
+	}
+	var (
+		properties = getDocument(target, "properties")
+		required   = gset.NewStrSetFrom(gconv.Strings(getArray(target, "required")))
+	)
+	if properties == nil {
+		return fields, true
+	}
+	properties.Iterator(func(k, v any) bool {
+		var (
+			propertyName      = gconv.String(k)
+			propertySchema, _ = v.(document)
+			fieldName         = newFieldName(fieldNames, propertyName)
+		)
+		fields = append(fields, &apiField{
+			Name: fieldName,
+			Type: p.schemaType(file, propertySchema, parentName+fieldName, forRequest),
+			Tag:  p.fieldTag(propertyName, "", "", propertySchema, required.Contains(propertyName), forRequest),
+		})
+		return true
+	})
+	return fields, true
+}
+
+// schemaType returns the go type of the schema, the inline object schemas are generated as new struct
+// types named `name`, and the referenced component object schemas are generated as struct types named
+// by the component names.
+func (p *apiParser) schemaType(file *apiFile, schema document, name string, forRequest bool) string {
+	if schema == nil {
+		return "any"
+	}
+	if ref := getString(schema, "$ref"); ref != "" {
+		target, refName := p.resolveSchema(schema)
+		if target == nil {
+			mlog.Printf(`unresolvable schema reference "%s", it is generated as any`, ref)
+			return "any"
+		}
+		if refName != "" && isStructSchema(target) {
+			return "*" + p.componentType(file, refName, target)
+		}
+		// The non-object schema is inlined.
+		if p.resolving.Contains(ref) {
+			return "any"
+		}
+		p.resolving.Add(ref)
+		defer p.resolving.Remove(ref)
+		return p.schemaType(file, target, name, forRequest)
+	}
+	if isStructSchema(schema) {
+		allOf := getArray(schema, "allOf")
+		if len(allOf) == 1 && getDocument(schema, "properties") == nil {
+			if itemSchema, ok := allOf[0].(document); ok {
+				return p.schemaType(file, itemSchema, name, forRequest)
+			}
+		}
+		return "*" + p.inlineStructType(file, schema, name, forRequest)
+	}
+	if variants := nonNullSchemas(schema); variants != nil {
+		if len(variants) == 1 {
+			return p.schemaType(file, variants[0], name, forRequest)
+		}
+		return "any"
+	}
+	switch schemaKind(schema) {
+	case "object":
+		if valueSchema, ok := schema.Get("additionalProperties").(document); ok {
+			return "map[string]" + strings.TrimPrefix(p.schemaType(file, valueSchema, name+"Value", forRequest), "*")
+		}
+		return "map[string]any"
+
+	case "array":
+		itemSchema, _ := schema.Get("items").(document)
+		itemType := p.schemaType(file, itemSchema, name+"Item", forRequest)
+		return "[]" + strings.TrimPrefix(itemType, "*")
+
+	case "string":
+		switch getString(schema, "format") {
+		case "date", "date-time":
+			file.imports.Add("github.com/gogf/gf/v2/os/gtime")
+			return "*gtime.Time"
+		case "binary":
+			if forRequest {
+				file.imports.Add("github.com/gogf/gf/v2/net/ghttp")
+				return "*ghttp.UploadFile"
+			}
+			return "[]byte"
+		}
+		return "string"
+
+	case "integer":
+		if getString(schema, "format") == "int64" {
+			return "int64"
+		}
+		return "int"
+
+	case "number":
+		if getString(schema, "format") == "float" {
+			return "float32"
+		}
+		return "float64"
+
+	case "boolean":
+		return "bool"
+
+	default:
+		return "any"
+	}
+}
+
+// inlineStructType generates struct type for inline object schema and returns its type name.
+func (p *apiParser) inlineStructType(file *apiFile, schema document, name string, forRequest bool) string {
+	definition := &apiDefinition{
+		Name: file.newTypeName(name),
+	}
+	if description := getString(schema, "description"); description != "" {
+		definition.Comment = fmt.Sprintf(`%s %s`, definition.Name, description)
+	}
+	file.models = append(file.models, definition)
+	definition.Fields, _ = p.structFields(file, schema, definition.Name, forRequest, gset.NewStrSet())
+	return definition.Name
+}
+
+// componentType generates struct type for component schema and returns its type name.
+// Each component schema is generated only once in each file.
+func (p *apiParser) componentType(file *apiFile, refName string, schema document) string {
+	if typeName, ok := file.components[refName]; ok {
+		return typeName
+	}
+	definition := &apiDefinition{
+		Name: file.newTypeName(toIdentifier(refName)),
+	}
+	if description := getString(schema, "description"); description != "" {
+		definition.Comment = fmt.Sprintf(`%s %s`, definition.Name, description)
+	}
+	file.components[refName] = definition.Name
+	file.models = append(file.models, definition)
+	definition.Fields, _ = p.structFields(file, schema, definition.Name, false, gset.NewStrSet())
+	return definition.Name
+}
+
+// fieldTag generates the struct tag of field for parameter or property.
+func (p *apiParser) fieldTag(name, in, description string, schema document, required, forRequest bool) string {
+	var (
+		target, _ = p.resolveSchema(schema)
+		tags      = []string{tagPair("json", name)}
+	)
+	if in != "" {
+		tags = append(tags, tagPair("in", in))
+	}
+	if rules := validationRules(target, required); rules != "" {
+		tags = append(tags, tagPair("v", rules))
+	}
+	if forRequest && target != nil {
+		switch value := target.Get("default").(type) {
+		case nil, document, []any:
+		default:
+			tags = append(tags, tagPair("d", gconv.String(value)))
+		}
+	}
+	if description == "" {
+		description = getString(schema, "description")
+	}
+	if description == "" {
+		description = getString(target, "description")
+	}
+	if description != "" {
+		tags = append(tags, tagPair("dc", description))
+	}
+	return strings.Join(tags, " ")
+}
+
+// operationModule returns the module name of the operation.
+func (p *apiParser) operationModule(path string, operation document) string {
+	if p.module != "" {
+		return p.module
+	}
+	if tags := getArray(operation, "tags"); len(tags) > 0 {
+		if module := toModuleName(gconv.String(tags[0])); module != "" {
+			return module
+		}
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "api" || gstr.HasPrefix(segment, "{") || isVersionName(segment) {
+			continue
+		}
+		if module := toModuleName(segment); module != "" {
+			return module
+		}
+	}
+	return "default"
+}
+
+// operationName returns the name of the operation, which is used as the prefix of request
+// and response struct names. It uses operationId of the operation, or else method and path.
+func (p *apiParser) operationName(path, method string, operation document) string {
+	if name := toIdentifier(getString(operation, "operationId")); name != "" {
+		return name
+	}
+	var words = []string{method}
+	for _, segment := range strings.Split(path, "/") {
+		if gstr.HasPrefix(segment, "{") {
+			words = append(words, "by", strings.Trim(segment, "{}"))
+		} else if segment != "" {
+			words = append(words, segment)
+		}
+	}
+	return toIdentifier(strings.Join(words, "_"))
+}
+
+// getFile returns the api file of the module, it creates one if not exists.
+func (p *apiParser) getFile(module string) *apiFile {
+	return p.files.GetOrSetFunc(module, func() *apiFile {
+		return newApiFile(module, p.version)
+	})
+}
+
+// resolveDocument resolves the document if it's a reference object.
+func (p *apiParser) resolveDocument(value any) document {
+	object, _ := value.(document)
+	for i := 0; object != nil && i < maxRefResolvingDepth; i++ {
+		ref := getString(object, "$ref")
+		if ref == "" {
+			return object
+		}
+		object, _ = resolvePointer(p.root, ref).(document)
+	}
+	return object
+}
+
+// resolveSchema resolves the schema reference and returns the target schema.
+// It also returns the component name if the last reference is a component schema.
+func (p *apiParser) resolveSchema(schema document) (target document, refName string) {
+	target = schema
+	for i := 0; target != nil && i < maxRefResolvingDepth; i++ {
+		ref := getString(target, "$ref")
+		if ref == "" {
+			return target, refName
+		}
+		refName = ""
+		if gstr.HasPrefix(ref, componentSchemaRefPrefix) {
+			refName = strings.ReplaceAll(
+				strings.ReplaceAll(ref[len(componentSchemaRefPrefix):], "~1", "/"), "~0", "~",
+			)
+		}
+		target, _ = resolvePointer(p.root, ref).(document)
+	}
+	return target, refName
+}
+
+// validationRules converts the schema constraints into validation rules.
+func validationRules(schema document, required bool) string {
+	var rules = garray.NewStrArray()
+	if required {
+		rules.Append("required")
+	}
+	if schema == nil {
+		return rules.Join("|")
+	}
+	switch schemaKind(schema) {
+	case "string":
+		var (
+			minLength = getString(schema, "minLength")
+			maxLength = getString(schema, "maxLength")
+		)
+		switch {
+		case minLength != "" && maxLength != "":
+			rules.Append(fmt.Sprintf("length:%s,%s", minLength, maxLength))
+		case minLength != "":
+			rules.Append("min-length:" + minLength)
+		case maxLength != "":
+			rules.Append("max-length:" + maxLength)
+		}
+		if rule, ok := formatValidationRules[getString(schema, "format")]; ok {
+			rules.Append(rule)
+		}
+
+	case "integer", "number":
+		var (
+			minimum = getString(schema, "minimum")
+			maximum = getString(schema, "maximum")
+		)
+		switch {
+		case minimum != "" && maximum != "":
+			rules.Append(fmt.Sprintf("between:%s,%s", minimum, maximum))
+		case minimum != "":
+			rules.Append("min:" + minimum)
+		case maximum != "":
+			rules.Append("max:" + maximum)
+		}
+	}
+	var enums = gconv.Strings(getArray(schema, "enum"))
+	if schema.Contains("const") {
+		enums = []string{getString(schema, "const")}
+	}
+	if len(enums) > 0 {
+		var values = strings.Join(enums, ",")
+		// Enum values containing rule separators cannot be converted.
+		if len(strings.Split(values, ",")) == len(enums) && !strings.Contains(values, "|") {
+			rules.Append("in:" + values)
+		}
+	}
+	// The regex rule should be the last one as its pattern might contain rule separator.
+	if pattern := getString(schema, "pattern"); pattern != "" {
+		rules.Append("regex:" + pattern)
+	}
+	return rules.Join("|")
+}
+
+// schemaKind returns the data type of the schema, which supports type array of OpenAPI 3.1.
+func schemaKind(schema document) string {
+	if schema == nil {
+		return ""
+	}
+	switch v := schema.Get("type").(type) {
+	case string:
+		return v
+	case []any:
+		for _, item := range v {
+			if kind := gconv.String(item); kind != "null" {
+				return kind
+			}
+		}
+	}
+	switch {
+	case schema.Contains("properties") || schema.Contains("additionalProperties"):
+		return "object"
+	case schema.Contains("items"):
+		return "array"
+	}
+	return ""
+}
+
+// isStructSchema checks whether the schema should be generated as struct.
+func isStructSchema(schema document) bool {
+	if schema == nil {
+		return false
+	}
+	if properties := getDocument(schema, "properties"); properties != nil && properties.Size() > 0 {
+		return true
+	}
+	return len(getArray(schema, "allOf")) > 0
+}
+
+// nonNullSchemas returns the variants of `oneOf` or `anyOf` excluding the null type schemas,
+// which is usually used for nullable types in OpenAPI 3.1.
+// It returns nil if the schema has no variants.
+func nonNullSchemas(schema document) []document {
+	var variants = getArray(schema, "oneOf")
+	if len(variants) == 0 {
+		variants = getArray(schema, "anyOf")
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+	var schemas = make([]document, 0, len(variants))
+	for _, item := range variants {
+		if variant, ok := item.(document); ok && getString(variant, "type") != "null" {
+			schemas = append(schemas, variant)
+		}
+	}
+	return schemas
+}
+
+// selectMedia selects the media type of content, json media type is preferred.
+func selectMedia(content document) (mime string, media document) {
+	if content == nil {
+		return "", nil
+	}
+	content.Iterator(func(k, v any) bool {
+		if key := gconv.String(k); mime == "" || isJsonMime(key) {
+			mime = key
+			media, _ = v.(document)
+			return !isJsonMime(key)
+		}
+		return true
+	})
+	return
+}
+
+func isJsonMime(mime string) bool {
+	return gstr.Contains(strings.ToLower(mime), "json") || mime == "*/*"
+}
+
+// isVersionName checks whether the path segment is version name like "v1".
+func isVersionName(segment string) bool {
+	return len(segment) > 1 && (segment[0] == 'v' || segment[0] == 'V') && gstr.IsNumeric(segment[1:])
+}
+
+// toIdentifier converts the name into exported go identifier.
+func toIdentifier(name string) string {
+	var builder strings.Builder
+	for _, r := range gstr.CaseCamel(name) {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	identifier := builder.String()
+	if identifier != "" && !unicode.IsUpper([]rune(identifier)[0]) {
+		identifier = "X" + identifier
+	}
+	return identifier
+}
+
+// toModuleName converts the name into go package name used as module name.
+func toModuleName(name string) string {
+	var builder strings.Builder
+	for _, r := range gstr.CaseSnake(name) {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(builder.String(), "_0123456789")
+}
+
+// newFieldName returns a unique field name for `name` in struct.
+func newFieldName(fieldNames *gset.StrSet, name string) string {
+	var fieldName = toIdentifier(name)
+	if fieldName == "" {
+		fieldName = "Field"
+	}
+	var uniqueName = fieldName
+	for i := 2; fieldNames.Contains(uniqueName); i++ {
+		uniqueName = fmt.Sprintf(`%s%d`, fieldName, i)
+	}
+	fieldNames.Add(uniqueName)
+	return uniqueName
+}
+
+// tagPair returns the struct tag key-value pair, the value is escaped to keep the struct tag valid.
+func tagPair(key, value string) string {
+	value = strings.TrimSpace(tagValueReplacer.Replace(value))
+	value = strings.ReplaceAll(value, "`", "'")
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return fmt.Sprintf(`%s:"%s"`, key, value)
+}
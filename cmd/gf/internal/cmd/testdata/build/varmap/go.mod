@@ -9,6 +9,7 @@ require github.com/gogf/gf/v2 v2.10.2
 require (
 	go.opentelemetry.io/otel v1.38.0 // indirect
 	go.opentelemetry.io/otel/trace v1.38.0 // indirect
+	golang.org/x/crypto v0.38.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 )
 
@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// Status is the status of user.
+type Status int
+
+// User is the user model.
+type User struct {
+	Id        uint64      `json:"id"`
+	Name      string      `json:"name"      dc:"user name"`
+	Status    Status      `json:"status"`
+	Tags      []string    `json:"tags,omitempty"`
+	Extra     g.Map       `json:"extra,omitempty"`
+	Avatar    *string     `json:"avatar"`
+	CreatedAt *gtime.Time `json:"created_at"`
+	password  string
+}
+
+// Page is the pagination parameters.
+type Page struct {
+	Page int `json:"page" d:"1"`
+	Size int `json:"size" d:"10"`
+}
+
+type GetListReq struct {
+	g.Meta `path:"/user" method:"get" tags:"User" summary:"Get users"`
+	Page
+	Keyword string `json:"keyword"`
+}
+
+type GetListRes struct {
+	List  []*User `json:"list"`
+	Total int     `json:"total"`
+}
+
+type (
+	// GetOneReq get one user.
+	GetOneReq struct {
+		g.Meta `path:"/user/{id}" method:"get"`
+		Id     uint64 `json:"id" v:"required" in:"path"`
+	}
+
+	GetOneRes struct {
+		*User
+	}
+)
+
+type CreateReq struct {
+	g.Meta `path:"/user" method:"post"`
+	Name   string            `json:"name" v:"required|length:2,32"`
+	Avatar *ghttp.UploadFile `json:"avatar" type:"file"`
+	Meta   map[string]int    `json:"meta"`
+	Secret string            `json:"-"`
+}
+
+type CreateRes struct {
+	Id uint64 `json:"id"`
+}
+
+type DeleteReq struct {
+	g.Meta `path:"/user/{id}" method:"delete,post"`
+	Id     uint64 `json:"id" v:"required"`
+}
+
+type DeleteRes struct{}
@@ -0,0 +1,101 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+export interface ClientConfig {
+  // Service address, eg: http://127.0.0.1:8000
+  baseURL: string;
+  // Headers sent along with all requests, eg: Authorization.
+  headers?: Record<string, string>;
+  // Custom fetch implementation, it uses the global fetch in default.
+  fetch?: typeof fetch;
+}
+
+export interface RequestOptions {
+  headers?: Record<string, string>;
+  signal?: AbortSignal;
+}
+
+export class ApiError extends Error {
+  constructor(
+    public readonly code: number,
+    message: string,
+    public readonly status: number,
+  ) {
+    super(message);
+    this.name = 'ApiError';
+  }
+}
+
+export class Client {
+  constructor(private readonly config: ClientConfig) {}
+
+  async request<T>(method: string, path: string, req: object, options?: RequestOptions): Promise<T> {
+    const params: Record<string, any> = { ...req };
+    path = path.replace(/\{(\w+)\}|:(\w+)/g, (match: string, brace?: string, colon?: string) => {
+      const name = (brace || colon) as string;
+      if (params[name] === undefined) {
+        return match;
+      }
+      const value = params[name];
+      delete params[name];
+      return encodeURIComponent(String(value));
+    });
+
+    const headers: Record<string, string> = { ...this.config.headers, ...options?.headers };
+    let url = this.config.baseURL.replace(/\/+$/, '') + path;
+    let body: BodyInit | undefined;
+    if (method === 'GET' || method === 'DELETE' || method === 'HEAD') {
+      const query = new URLSearchParams();
+      for (const [key, value] of Object.entries(params)) {
+        for (const item of Array.isArray(value) ? value : [value]) {
+          if (item !== undefined && item !== null) {
+            query.append(key, typeof item === 'object' ? JSON.stringify(item) : String(item));
+          }
+        }
+      }
+      const queryString = query.toString();
+      if (queryString) {
+        url += (url.includes('?') ? '&' : '?') + queryString;
+      }
+    } else if (Object.values(params).some(isBlobValue)) {
+      const form = new FormData();
+      for (const [key, value] of Object.entries(params)) {
+        for (const item of isBlobValue(value) && Array.isArray(value) ? value : [value]) {
+          if (item instanceof Blob) {
+            form.append(key, item, item instanceof File ? item.name : key);
+          } else if (item !== undefined && item !== null) {
+            form.append(key, typeof item === 'object' ? JSON.stringify(item) : String(item));
+          }
+        }
+      }
+      body = form;
+    } else {
+      headers['Content-Type'] = 'application/json';
+      body = JSON.stringify(params);
+    }
+
+    const response = await (this.config.fetch ?? fetch)(url, { method, headers, body, signal: options?.signal });
+    const text = await response.text();
+    let result: any;
+    try {
+      result = text ? JSON.parse(text) : undefined;
+    } catch {
+      throw new ApiError(-1, text || response.statusText, response.status);
+    }
+    if (result !== null && typeof result === 'object' && typeof result.code === 'number') {
+      if (result.code !== 0) {
+        throw new ApiError(result.code, result.message, response.status);
+      }
+      return result.data as T;
+    }
+    if (!response.ok) {
+      throw new ApiError(-1, response.statusText, response.status);
+    }
+    return result as T;
+  }
+}
+
+function isBlobValue(value: any): boolean {
+  return value instanceof Blob || (Array.isArray(value) && value.some((item) => item instanceof Blob));
+}
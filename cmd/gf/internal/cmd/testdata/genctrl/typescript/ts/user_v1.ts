@@ -0,0 +1,79 @@
+// =================================================================================
+// Code generated and maintained by GoFrame CLI tool. DO NOT EDIT.
+// =================================================================================
+
+import { Client, RequestOptions } from './client';
+
+/** Status is the status of user. */
+export type Status = number;
+
+/** User is the user model. */
+export interface User {
+  id: number;
+  /** user name */
+  name: string;
+  status: Status;
+  tags?: string[];
+  extra?: Record<string, any>;
+  avatar?: string;
+  created_at?: string;
+}
+
+/** Page is the pagination parameters. */
+export interface Page {
+  page: number;
+  size: number;
+}
+
+export interface GetListReq extends Page {
+  keyword?: string;
+}
+
+export interface GetListRes {
+  list: User[];
+  total: number;
+}
+
+/** GetOneReq get one user. */
+export interface GetOneReq {
+  id: number;
+}
+
+export interface GetOneRes extends User {}
+
+export interface CreateReq {
+  name: string;
+  avatar?: Blob;
+  meta?: Record<string, number>;
+}
+
+export interface CreateRes {
+  id: number;
+}
+
+export interface DeleteReq {
+  id: number;
+}
+
+export interface DeleteRes {}
+
+export class UserV1 {
+  constructor(private readonly client: Client) {}
+
+  getList(req: GetListReq, options?: RequestOptions): Promise<GetListRes> {
+    return this.client.request<GetListRes>('GET', '/api/v1/user', req, options);
+  }
+
+  /** GetOne get one user. */
+  getOne(req: GetOneReq, options?: RequestOptions): Promise<GetOneRes> {
+    return this.client.request<GetOneRes>('GET', '/api/v1/user/{id}', req, options);
+  }
+
+  create(req: CreateReq, options?: RequestOptions): Promise<CreateRes> {
+    return this.client.request<CreateRes>('POST', '/api/v1/user', req, options);
+  }
+
+  delete(req: DeleteReq, options?: RequestOptions): Promise<DeleteRes> {
+    return this.client.request<DeleteRes>('DELETE', '/api/v1/user/{id}', req, options);
+  }
+}
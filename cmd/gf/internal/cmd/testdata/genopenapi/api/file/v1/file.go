@@ -0,0 +1,18 @@
+// =================================================================================
+// This file is generated by GoFrame CLI tool from OpenAPI document "openapi.yaml".
+// You may edit it as needed, it is not overwritten unless the overwrite option is enabled.
+// =================================================================================
+
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+type UploadFileReq struct {
+	g.Meta `path:"/files/upload" method:"post" tags:"File" mime:"multipart/form-data"`
+	File   *ghttp.UploadFile `json:"file" dc:"File to upload"`
+}
+
+type UploadFileRes []string
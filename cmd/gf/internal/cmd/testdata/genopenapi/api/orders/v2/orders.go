@@ -0,0 +1,27 @@
+// =================================================================================
+// This file is generated by GoFrame CLI tool from OpenAPI document "openapi31.json".
+// You may edit it as needed, it is not overwritten unless the overwrite option is enabled.
+// =================================================================================
+
+package v2
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type UpdateOrderReq struct {
+	g.Meta  `path:"/v1/orders/{orderId}" method:"put"`
+	OrderId string `json:"orderId" in:"path" v:"required"`
+	OrderBase
+	Remark string `json:"remark" v:"max-length:200"`
+}
+
+type UpdateOrderRes struct {
+	Order *OrderBase         `json:"order"`
+	Extra map[string]float64 `json:"extra"`
+}
+
+type OrderBase struct {
+	Amount   float64 `json:"amount" v:"required"`
+	Currency string  `json:"currency" v:"in:CNY"`
+}
@@ -0,0 +1,72 @@
+// =================================================================================
+// This file is generated by GoFrame CLI tool from OpenAPI document "openapi.yaml".
+// You may edit it as needed, it is not overwritten unless the overwrite option is enabled.
+// =================================================================================
+
+package v1
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ListUsersReq List users
+type ListUsersReq struct {
+	g.Meta   `path:"/users" method:"get" tags:"User" summary:"List users"`
+	Page     int    `json:"page" in:"query" v:"min:1" d:"1" dc:"Page number"`
+	Size     int    `json:"size" in:"query" v:"between:1,100" d:"20"`
+	XTraceId string `json:"X-Trace-Id" in:"header"`
+}
+
+type ListUsersRes struct {
+	List  []User `json:"list"`
+	Total int    `json:"total"`
+}
+
+// CreateUserReq Create user
+type CreateUserReq struct {
+	g.Meta  `path:"/users" method:"post" tags:"User" summary:"Create user"`
+	Name    string                `json:"name" v:"required|length:2,32" dc:"User name"`
+	Email   string                `json:"email" v:"required|email"`
+	Status  string                `json:"status" v:"in:active,disabled" dc:"User status"`
+	Address *CreateUserReqAddress `json:"address"`
+}
+
+type CreateUserRes struct {
+	g.Meta `status:"201"`
+	User
+}
+
+// GetUserReq Get user
+type GetUserReq struct {
+	g.Meta `path:"/users/{id}" method:"get" tags:"User" summary:"Get user"`
+	Id     int64 `json:"id" in:"path" v:"required"`
+}
+
+type GetUserRes struct {
+	User
+}
+
+// DeleteUsersByIdReq Delete user
+type DeleteUsersByIdReq struct {
+	g.Meta `path:"/users/{id}" method:"delete" tags:"User" summary:"Delete user" deprecated:"true"`
+	Id     int64 `json:"id" in:"path" v:"required"`
+}
+
+type DeleteUsersByIdRes struct {
+	g.Meta `status:"204"`
+}
+
+// User User information.
+type User struct {
+	Id        int64       `json:"id" v:"required"`
+	Name      string      `json:"name"`
+	Status    string      `json:"status" v:"in:active,disabled" dc:"User status"`
+	Tags      []string    `json:"tags"`
+	CreatedAt *gtime.Time `json:"createdAt"`
+}
+
+type CreateUserReqAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip" v:"regex:^\\d{6}$"`
+}
@@ -0,0 +1,20 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package consts
+
+const TemplateGenOpenapiApi = `
+// =================================================================================
+// This file is generated by GoFrame CLI tool from OpenAPI document "{SourceName}".
+// You may edit it as needed, it is not overwritten unless the overwrite option is enabled.
+// =================================================================================
+
+package {PackageName}
+
+{Imports}
+
+{Definitions}
+`
@@ -15,6 +15,10 @@
 // 3. Support dynamic queue size(unlimited queue size);
 //
 // 4. Blocking when reading data from queue;
+//
+// 5. Generic priority queue(TPriorityQueue) with comparator;
+//
+// 6. Generic delay queue(TDelayQueue) whose items are poppable after their due time;
 package gqueue
 
 // Queue is a concurrent-safe queue built on doubly linked list and channel.
@@ -0,0 +1,78 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue
+
+import (
+	"context"
+	"time"
+)
+
+// TDelayQueue is a concurrent-safe delay queue built on binary heap,
+// in which the items are poppable only after their due time.
+type TDelayQueue[T any] struct {
+	heap *blockingHeap[T]
+}
+
+// NewTDelayQueue returns an empty delay queue object.
+// The items are popped in order of their due time, the items of the same due time are popped in FIFO way.
+func NewTDelayQueue[T any]() *TDelayQueue[T] {
+	return &TDelayQueue[T]{
+		heap: newBlockingHeap[T](true, func(a, b *heapItem[T]) bool {
+			return a.at.Before(b.at)
+		}),
+	}
+}
+
+// Push pushes the data `v` into the queue, which becomes poppable at time `at`.
+// Note that it is ignored if Push is called after the queue is closed.
+func (q *TDelayQueue[T]) Push(v T, at time.Time) {
+	q.heap.push(v, at)
+}
+
+// PushDelay pushes the data `v` into the queue, which becomes poppable after `delay` from now.
+func (q *TDelayQueue[T]) PushDelay(v T, delay time.Duration) {
+	q.heap.push(v, time.Now().Add(delay))
+}
+
+// Pop pops the earliest due item from the queue, it blocks until the item is due.
+// Note that it returns the due items without blocking after the queue is closed,
+// and returns zero value if there's no due item.
+func (q *TDelayQueue[T]) Pop() T {
+	v, _ := q.PopTimeout(context.Background())
+	return v
+}
+
+// TryPop pops the earliest due item from the queue without blocking.
+// It returns false if there's no due item.
+func (q *TDelayQueue[T]) TryPop() (v T, ok bool) {
+	return firstOf(q.heap.pop(context.Background(), 1, false))
+}
+
+// PopTimeout pops the earliest due item from the queue, it blocks until
+// any item is due, the queue is closed or `ctx` is done.
+// It returns false if no item is popped.
+func (q *TDelayQueue[T]) PopTimeout(ctx context.Context) (v T, ok bool) {
+	return firstOf(q.heap.pop(ctx, 1, true))
+}
+
+// PopN pops at most `n` due items from the queue in order of due time, it blocks until
+// any item is due, the queue is closed or `ctx` is done.
+func (q *TDelayQueue[T]) PopN(ctx context.Context, n int) []T {
+	return q.heap.pop(ctx, n, true)
+}
+
+// Close closes the queue.
+// Notice: It would notify all goroutines return immediately,
+// which are being blocked reading using Pop method.
+func (q *TDelayQueue[T]) Close() {
+	q.heap.close()
+}
+
+// Len returns the length of the queue, including the items that are not due.
+func (q *TDelayQueue[T]) Len() int64 {
+	return q.heap.len()
+}
@@ -0,0 +1,182 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// blockingHeap is the concurrent-safe binary heap with blocking popping,
+// which is the underlying structure of priority queue and delay queue.
+type blockingHeap[T any] struct {
+	mu      sync.Mutex
+	items   *heapItems[T]
+	seq     uint64        // Sequence for items, which keeps FIFO order of equal items.
+	closed  bool          // Whether the heap is closed.
+	delayed bool          // Whether the top item is popped only after its due time.
+	notify  chan struct{} // Closed and renewed when the top item changes, which wakes up the blocking popping.
+}
+
+// zeroTime is the due time for items of non-delayed heap.
+var zeroTime time.Time
+
+// heapItem is the item in heap.
+type heapItem[T any] struct {
+	value T
+	at    time.Time // Due time of item, only for delayed heap.
+	seq   uint64
+}
+
+// heapItems implements heap.Interface.
+type heapItems[T any] struct {
+	items []*heapItem[T]
+	less  func(a, b *heapItem[T]) bool
+}
+
+func newBlockingHeap[T any](delayed bool, less func(a, b *heapItem[T]) bool) *blockingHeap[T] {
+	return &blockingHeap[T]{
+		items: &heapItems[T]{
+			less: func(a, b *heapItem[T]) bool {
+				if less(a, b) {
+					return true
+				}
+				if less(b, a) {
+					return false
+				}
+				return a.seq < b.seq
+			},
+		},
+		delayed: delayed,
+		notify:  make(chan struct{}),
+	}
+}
+
+// push pushes `value` due at `at` into heap, it returns false if the heap is closed.
+func (h *blockingHeap[T]) push(value T, at time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return false
+	}
+	h.seq++
+	item := &heapItem[T]{
+		value: value,
+		at:    at,
+		seq:   h.seq,
+	}
+	heap.Push(h.items, item)
+	// Wakes up the blocking popping only if the top item changes.
+	if h.items.items[0] == item {
+		close(h.notify)
+		h.notify = make(chan struct{})
+	}
+	return true
+}
+
+// pop pops at most `n` items which are ready from heap.
+// If `block` is true, it blocks until any item is ready, the heap is closed or `ctx` is done.
+func (h *blockingHeap[T]) pop(ctx context.Context, n int, block bool) []T {
+	if n <= 0 {
+		return nil
+	}
+	for {
+		var (
+			values []T
+			wait   time.Duration = -1 // Duration until the top item is due, it is -1 if no item.
+			now    time.Time
+		)
+		h.mu.Lock()
+		if h.delayed {
+			now = time.Now()
+		}
+		for len(values) < n && h.items.Len() > 0 {
+			top := h.items.items[0]
+			if h.delayed {
+				if wait = top.at.Sub(now); wait > 0 {
+					break
+				}
+			}
+			heap.Pop(h.items)
+			values = append(values, top.value)
+		}
+		if len(values) > 0 || !block || h.closed {
+			h.mu.Unlock()
+			return values
+		}
+		notify := h.notify
+		h.mu.Unlock()
+
+		var (
+			timer  *time.Timer
+			timerC <-chan time.Time
+		)
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			timerC = timer.C
+		}
+		var done bool
+		select {
+		case <-notify:
+		case <-timerC:
+		case <-ctx.Done():
+			done = true
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// len returns the item count in heap.
+func (h *blockingHeap[T]) len() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return int64(h.items.Len())
+}
+
+// close closes the heap and wakes up all the blocking popping.
+func (h *blockingHeap[T]) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	close(h.notify)
+}
+
+func (h *heapItems[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *heapItems[T]) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h *heapItems[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *heapItems[T]) Push(x any) {
+	h.items = append(h.items, x.(*heapItem[T]))
+}
+
+func (h *heapItems[T]) Pop() any {
+	var (
+		n    = len(h.items)
+		item = h.items[n-1]
+	)
+	h.items[n-1] = nil
+	h.items = h.items[:n-1]
+	return item
+}
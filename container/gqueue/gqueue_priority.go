@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue
+
+import (
+	"context"
+)
+
+// TPriorityQueue is a concurrent-safe priority queue built on binary heap,
+// which pops the item of the highest priority first.
+type TPriorityQueue[T any] struct {
+	heap *blockingHeap[T]
+}
+
+// NewTPriorityQueue returns an empty priority queue object.
+// The parameter `less` is the comparator which returns true if `a` should be popped before `b`,
+// the items of equal priority are popped in FIFO way.
+func NewTPriorityQueue[T any](less func(a, b T) bool) *TPriorityQueue[T] {
+	return &TPriorityQueue[T]{
+		heap: newBlockingHeap[T](false, func(a, b *heapItem[T]) bool {
+			return less(a.value, b.value)
+		}),
+	}
+}
+
+// Push pushes the data `v` into the queue.
+// Note that it is ignored if Push is called after the queue is closed.
+func (q *TPriorityQueue[T]) Push(v T) {
+	q.heap.push(v, zeroTime)
+}
+
+// Pop pops the item of the highest priority from the queue, it blocks if the queue is empty.
+// Note that it returns the remaining items without blocking after the queue is closed,
+// and returns zero value if there's no more item.
+func (q *TPriorityQueue[T]) Pop() T {
+	v, _ := q.PopTimeout(context.Background())
+	return v
+}
+
+// TryPop pops the item of the highest priority from the queue without blocking.
+// It returns false if the queue is empty.
+func (q *TPriorityQueue[T]) TryPop() (v T, ok bool) {
+	return firstOf(q.heap.pop(context.Background(), 1, false))
+}
+
+// PopTimeout pops the item of the highest priority from the queue, it blocks until
+// any item is available, the queue is closed or `ctx` is done.
+// It returns false if no item is popped.
+func (q *TPriorityQueue[T]) PopTimeout(ctx context.Context) (v T, ok bool) {
+	return firstOf(q.heap.pop(ctx, 1, true))
+}
+
+// PopN pops at most `n` items of the highest priority from the queue in order, it blocks until
+// any item is available, the queue is closed or `ctx` is done.
+func (q *TPriorityQueue[T]) PopN(ctx context.Context, n int) []T {
+	return q.heap.pop(ctx, n, true)
+}
+
+// Close closes the queue.
+// Notice: It would notify all goroutines return immediately,
+// which are being blocked reading using Pop method.
+func (q *TPriorityQueue[T]) Close() {
+	q.heap.close()
+}
+
+// Len returns the length of the queue.
+func (q *TPriorityQueue[T]) Len() int64 {
+	return q.heap.len()
+}
+
+// firstOf returns the first item of `values`, it returns false if `values` is empty.
+func firstOf[T any](values []T) (v T, ok bool) {
+	if len(values) == 0 {
+		return
+	}
+	return values[0], true
+}
@@ -6,6 +6,7 @@
 package gqueue
 
 import (
+	"context"
 	"math"
 
 	"github.com/gogf/gf/v2/container/glist"
@@ -59,6 +60,43 @@ func (q *TQueue[T]) Pop() T {
 	return <-q.C
 }
 
+// PopTimeout pops an item from the queue in FIFO way, it blocks until any item is available,
+// the queue is closed or `ctx` is done.
+// It returns false if no item is popped.
+func (q *TQueue[T]) PopTimeout(ctx context.Context) (v T, ok bool) {
+	select {
+	case v, ok = <-q.C:
+		return
+	case <-ctx.Done():
+		return
+	}
+}
+
+// PopN pops at most `n` items from the queue in FIFO way, it blocks until any item is available,
+// the queue is closed or `ctx` is done.
+func (q *TQueue[T]) PopN(ctx context.Context, n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	v, ok := q.PopTimeout(ctx)
+	if !ok {
+		return nil
+	}
+	values := []T{v}
+	for len(values) < n {
+		select {
+		case v, ok = <-q.C:
+			if !ok {
+				return values
+			}
+			values = append(values, v)
+		default:
+			return values
+		}
+	}
+	return values
+}
+
 // Close closes the queue.
 // Notice: It would notify all goroutines return immediately,
 // which are being blocked reading using Pop method.
@@ -0,0 +1,183 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gqueue"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type priorityItem struct {
+	Name     string
+	Priority int
+}
+
+func TestTPriorityQueue_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		q := gqueue.NewTPriorityQueue(func(a, b priorityItem) bool {
+			return a.Priority > b.Priority
+		})
+		defer q.Close()
+		q.Push(priorityItem{"a", 1})
+		q.Push(priorityItem{"b", 3})
+		q.Push(priorityItem{"c", 2})
+		q.Push(priorityItem{"d", 3})
+		t.Assert(q.Len(), 4)
+		t.Assert(q.Pop().Name, "b")
+		t.Assert(q.Pop().Name, "d")
+		t.Assert(q.Pop().Name, "c")
+		v, ok := q.TryPop()
+		t.Assert(ok, true)
+		t.Assert(v.Name, "a")
+		_, ok = q.TryPop()
+		t.Assert(ok, false)
+		t.Assert(q.Len(), 0)
+	})
+}
+
+func TestTPriorityQueue_Blocking(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		q := gqueue.NewTPriorityQueue(func(a, b int) bool {
+			return a < b
+		})
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			q.Push(1)
+		}()
+		t.Assert(q.Pop(), 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, ok := q.PopTimeout(ctx)
+		t.Assert(ok, false)
+		t.AssertGE(time.Since(start), 100*time.Millisecond)
+	})
+	// Close wakes up the blocking popping, and the remaining items are still poppable.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg sync.WaitGroup
+			q  = gqueue.NewTPriorityQueue(func(a, b int) bool {
+				return a < b
+			})
+		)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok := q.PopTimeout(context.Background())
+			t.Assert(ok, false)
+		}()
+		time.Sleep(100 * time.Millisecond)
+		q.Close()
+		wg.Wait()
+		q.Push(1)
+		t.Assert(q.Len(), 0)
+	})
+}
+
+func TestTPriorityQueue_PopN(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		q := gqueue.NewTPriorityQueue(func(a, b int) bool {
+			return a < b
+		})
+		defer q.Close()
+		for _, v := range []int{5, 3, 4, 1, 2} {
+			q.Push(v)
+		}
+		t.Assert(q.PopN(context.Background(), 3), []int{1, 2, 3})
+		t.Assert(q.PopN(context.Background(), 3), []int{4, 5})
+		t.Assert(q.PopN(context.Background(), 0), nil)
+	})
+}
+
+func TestTDelayQueue_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			q   = gqueue.NewTDelayQueue[string]()
+			now = time.Now()
+		)
+		defer q.Close()
+		q.Push("c", now.Add(300*time.Millisecond))
+		q.PushDelay("b", 100*time.Millisecond)
+		q.Push("a", now.Add(-time.Second))
+		t.Assert(q.Len(), 3)
+
+		v, ok := q.TryPop()
+		t.Assert(ok, true)
+		t.Assert(v, "a")
+		_, ok = q.TryPop()
+		t.Assert(ok, false)
+
+		t.Assert(q.Pop(), "b")
+		t.AssertGE(time.Since(now), 100*time.Millisecond)
+		t.Assert(q.Pop(), "c")
+		t.AssertGE(time.Since(now), 300*time.Millisecond)
+	})
+}
+
+func TestTDelayQueue_Earlier(t *testing.T) {
+	// An item pushed earlier than the waiting one wakes up the blocking popping.
+	gtest.C(t, func(t *gtest.T) {
+		q := gqueue.NewTDelayQueue[int]()
+		defer q.Close()
+		q.PushDelay(2, time.Second)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			q.PushDelay(1, 50*time.Millisecond)
+		}()
+		start := time.Now()
+		t.Assert(q.Pop(), 1)
+		t.AssertLT(time.Since(start), 500*time.Millisecond)
+	})
+}
+
+func TestTDelayQueue_PopN(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			q   = gqueue.NewTDelayQueue[int]()
+			now = time.Now()
+		)
+		defer q.Close()
+		q.Push(1, now)
+		q.Push(2, now)
+		q.Push(3, now.Add(time.Hour))
+		t.Assert(q.PopN(context.Background(), 10), []int{1, 2})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		t.Assert(q.PopN(ctx, 10), nil)
+		_, ok := q.PopTimeout(ctx)
+		t.Assert(ok, false)
+	})
+}
+
+func TestTQueue_PopTimeout(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		q := gqueue.NewTQueue[int]()
+		defer q.Close()
+		for i := 1; i <= 5; i++ {
+			q.Push(i)
+		}
+		v, ok := q.PopTimeout(context.Background())
+		t.Assert(ok, true)
+		t.Assert(v, 1)
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(q.PopN(context.Background(), 3), []int{2, 3, 4})
+		t.Assert(q.PopN(context.Background(), 3), []int{5})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, ok = q.PopTimeout(ctx)
+		t.Assert(ok, false)
+		t.Assert(q.PopN(ctx, 3), nil)
+	})
+}
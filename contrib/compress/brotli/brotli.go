@@ -0,0 +1,75 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package brotli implements brotli compression, and registers content encoding "br"
+// for the compression middleware of ghttp.
+//
+// Usage:
+//
+//	import _ "github.com/gogf/gf/contrib/compress/brotli/v2"
+//
+//	s := g.Server()
+//	s.Use(ghttp.MiddlewareCompress)
+package brotli
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+func init() {
+	ghttp.RegisterCompressEncoder(ghttp.EncodingBrotli, func(writer io.Writer) (ghttp.CompressEncoder, error) {
+		return brotli.NewWriter(writer), nil
+	})
+}
+
+// Compress compresses `data` using brotli algorithm.
+// The optional parameter `level` specifies the compression level from
+// 0 to 11 which means from the fastest to the best compression.
+// The default level is 6.
+//
+// Note that it returns error if given `level` is invalid.
+func Compress(data []byte, level ...int) ([]byte, error) {
+	var (
+		buf           bytes.Buffer
+		compressLevel = brotli.DefaultCompression
+	)
+	if len(level) > 0 {
+		if level[0] < brotli.BestSpeed || level[0] > brotli.BestCompression {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid brotli level "%d"`, level[0])
+		}
+		compressLevel = level[0]
+	}
+	writer := brotli.NewWriterLevel(&buf, compressLevel)
+	if _, err := writer.Write(data); err != nil {
+		err = gerror.Wrap(err, `writer.Write failed`)
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		err = gerror.Wrap(err, `writer.Close failed`)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses `data` with brotli algorithm.
+func Decompress(data []byte) ([]byte, error) {
+	var (
+		buf    bytes.Buffer
+		reader = brotli.NewReader(bytes.NewReader(data))
+	)
+	if _, err := io.Copy(&buf, reader); err != nil {
+		err = gerror.Wrap(err, `io.Copy failed`)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
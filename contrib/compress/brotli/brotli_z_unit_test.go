@@ -0,0 +1,78 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package brotli_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/contrib/compress/brotli/v2"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Compress_Decompress(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		src := []byte(strings.Repeat("Hello World!!", 100))
+		data, err := brotli.Compress(src)
+		t.AssertNil(err)
+		t.Assert(len(data) < len(src), true)
+
+		data, err = brotli.Decompress(data)
+		t.AssertNil(err)
+		t.Assert(data, src)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		src := []byte("Hello World!!")
+		data, err := brotli.Compress(src, 11)
+		t.AssertNil(err)
+
+		data, err = brotli.Decompress(data)
+		t.AssertNil(err)
+		t.Assert(data, src)
+
+		_, err = brotli.Compress(src, 12)
+		t.AssertNE(err, nil)
+
+		_, err = brotli.Decompress([]byte("invalid"))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_MiddlewareCompress(t *testing.T) {
+	var (
+		s        = g.Server(guid.S())
+		expected = strings.Repeat("Hello World! ", 1000)
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompress)
+		group.ALL("/", func(r *ghttp.Request) {
+			r.Response.Write(expected)
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		resp, err := client.Header(g.MapStrStr{"Accept-Encoding": "gzip;q=0.5, br"}).Get(context.Background(), "/")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Encoding"), "br")
+		content, err := brotli.Decompress(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(string(content), expected)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package zstd implements zstd compression, and registers content encoding "zstd"
+// for the compression middleware of ghttp.
+//
+// Usage:
+//
+//	import _ "github.com/gogf/gf/contrib/compress/zstd/v2"
+//
+//	s := g.Server()
+//	s.Use(ghttp.MiddlewareCompress)
+package zstd
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+func init() {
+	ghttp.RegisterCompressEncoder(ghttp.EncodingZstd, func(writer io.Writer) (ghttp.CompressEncoder, error) {
+		return zstd.NewWriter(writer, zstd.WithEncoderConcurrency(1))
+	})
+}
+
+// Compress compresses `data` using zstd algorithm.
+// The optional parameter `level` specifies the compression level from
+// 1 to 22 following the zstd command line convention, which is mapped to the
+// nearest level that the underlying encoder supports. The default level is 3.
+//
+// Note that it returns error if given `level` is invalid.
+func Compress(data []byte, level ...int) ([]byte, error) {
+	var (
+		buf     bytes.Buffer
+		options []zstd.EOption
+	)
+	if len(level) > 0 {
+		if level[0] < 1 || level[0] > 22 {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid zstd level "%d"`, level[0])
+		}
+		options = append(options, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level[0])))
+	}
+	writer, err := zstd.NewWriter(&buf, options...)
+	if err != nil {
+		err = gerror.Wrap(err, `zstd.NewWriter failed`)
+		return nil, err
+	}
+	if _, err = writer.Write(data); err != nil {
+		err = gerror.Wrap(err, `writer.Write failed`)
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		err = gerror.Wrap(err, `writer.Close failed`)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses `data` with zstd algorithm.
+func Decompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	reader, err := zstd.NewReader(bytes.NewReader(data))
+	if err != nil {
+		err = gerror.Wrap(err, `zstd.NewReader failed`)
+		return nil, err
+	}
+	defer reader.Close()
+
+	if _, err = io.Copy(&buf, reader); err != nil {
+		err = gerror.Wrap(err, `io.Copy failed`)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
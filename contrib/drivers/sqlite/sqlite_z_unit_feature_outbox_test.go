@@ -0,0 +1,427 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/database/goutbox"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// outboxPublisher records the published messages, and fails the messages in `failures`.
+type outboxPublisher struct {
+	mu        sync.Mutex
+	published []string
+	calls     int
+	failures  map[string]int // Failure times of messages by payload, -1 means always failing.
+	onPublish func(message *goutbox.Message)
+}
+
+func (p *outboxPublisher) Publish(ctx context.Context, message *goutbox.Message) error {
+	if p.onPublish != nil {
+		p.onPublish(message)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if n := p.failures[message.Payload]; n != 0 {
+		if n > 0 {
+			p.failures[message.Payload] = n - 1
+		}
+		return errors.New("publish failed")
+	}
+	p.published = append(p.published, message.Payload)
+	return nil
+}
+
+func (p *outboxPublisher) Published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.published...)
+}
+
+func (p *outboxPublisher) Calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+func createOutboxTable() string {
+	name := fmt.Sprintf(`goutbox_%d`, gtime.TimestampNano())
+	dropTable(name)
+	if _, err := db.Exec(ctx, fmt.Sprintf(`
+	CREATE TABLE %s (
+		id              INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+		topic           VARCHAR(255) NOT NULL,
+		aggregate_key   VARCHAR(255) NOT NULL DEFAULT '',
+		payload         TEXT NOT NULL,
+		headers         TEXT,
+		status          VARCHAR(16) NOT NULL,
+		attempts        INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at INTEGER NOT NULL DEFAULT 0,
+		last_error      TEXT,
+		enqueued_at     INTEGER NOT NULL,
+		delivered_at    INTEGER NOT NULL DEFAULT 0
+	);
+	`, db.GetCore().QuoteWord(name),
+	)); err != nil {
+		gtest.Fatal(err)
+	}
+	return name
+}
+
+// enqueueOutbox enqueues messages of `payloads` with aggregate key `key` in a transaction.
+func enqueueOutbox(outbox *goutbox.Outbox, key string, payloads ...string) {
+	err := db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		for _, payload := range payloads {
+			if err := outbox.Enqueue(ctx, tx, "order", payload, goutbox.EnqueueOption{AggregateKey: key}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	gtest.AssertNil(err)
+}
+
+func Test_Outbox_Enqueue_Transaction(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		outbox := goutbox.New(db, table)
+		err := db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			return outbox.Enqueue(ctx, tx, "order.created", "o1", goutbox.EnqueueOption{
+				AggregateKey: "k1",
+				Headers:      map[string]string{"X-Tenant": "t1"},
+			})
+		})
+		t.AssertNil(err)
+		one, err := db.Model(table).One()
+		t.AssertNil(err)
+		t.Assert(one["topic"], "order.created")
+		t.Assert(one["aggregate_key"], "k1")
+		t.Assert(one["payload"], `"o1"`)
+		t.Assert(one["headers"], `{"X-Tenant":"t1"}`)
+		t.Assert(one["status"], "pending")
+
+		// The message is rolled back with the transaction.
+		err = db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			if err := outbox.Enqueue(ctx, tx, "order.created", "o2"); err != nil {
+				return err
+			}
+			return errors.New("rollback")
+		})
+		t.AssertNE(err, nil)
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+	})
+}
+
+func Test_Outbox_Relay_Order(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox    = goutbox.New(db, table)
+			publisher = &outboxPublisher{failures: map[string]int{`"a1"`: 1}}
+			relay     = outbox.NewRelay(publisher, goutbox.RelayOption{
+				BackoffBase: time.Hour,
+			})
+		)
+		enqueueOutbox(outbox, "a", "a1", "a2", "a3")
+		enqueueOutbox(outbox, "b", "b1", "b2")
+		enqueueOutbox(outbox, "", "c1")
+
+		// The failed message blocks the later messages of its key only.
+		delivered, err := relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 3)
+		t.Assert(publisher.Published(), []string{`"b1"`, `"b2"`, `"c1"`})
+
+		// The messages waiting for retry are not read again, which does not starve the later messages.
+		enqueueOutbox(outbox, "", "c2")
+		calls := publisher.Calls()
+		delivered, err = outbox.NewRelay(publisher, goutbox.RelayOption{Batch: 2}).RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 1)
+		t.Assert(publisher.Calls(), calls+1)
+		t.Assert(publisher.Published(), []string{`"b1"`, `"b2"`, `"c1"`, `"c2"`})
+
+		// The messages of the key are delivered in order after the retry is due.
+		_, err = db.Model(table).Data("next_attempt_at", 0).Where("status", "pending").Update()
+		t.AssertNil(err)
+		delivered, err = relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 3)
+		t.Assert(publisher.Published(), []string{`"b1"`, `"b2"`, `"c1"`, `"c2"`, `"a1"`, `"a2"`, `"a3"`})
+
+		one, err := db.Model(table).Where("payload", `"a1"`).One()
+		t.AssertNil(err)
+		t.Assert(one["status"], "delivered")
+		t.Assert(one["attempts"], 2)
+		t.AssertGT(one["delivered_at"].Int64(), 0)
+	})
+}
+
+func Test_Outbox_Relay_Backoff(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox    = goutbox.New(db, table)
+			publisher = &outboxPublisher{failures: map[string]int{`"a1"`: -1}}
+			relay     = outbox.NewRelay(publisher, goutbox.RelayOption{
+				BackoffBase: time.Minute,
+				BackoffMax:  3 * time.Minute,
+			})
+		)
+		enqueueOutbox(outbox, "a", "a1")
+		for attempts, backoff := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+			_, err := db.Model(table).Data("next_attempt_at", 0).Where("payload", `"a1"`).Update()
+			t.AssertNil(err)
+			now := time.UnixMilli(time.Now().UnixMilli())
+			delivered, err := relay.RelayOnce(ctx)
+			t.AssertNil(err)
+			t.Assert(delivered, 0)
+
+			one, err := db.Model(table).One()
+			t.AssertNil(err)
+			t.Assert(one["status"], "pending")
+			t.Assert(one["attempts"], attempts+1)
+			t.Assert(one["last_error"], "publish failed")
+			nextAttemptAt := time.UnixMilli(one["next_attempt_at"].Int64())
+			t.AssertGE(nextAttemptAt.Sub(now), backoff)
+			t.AssertLT(nextAttemptAt.Sub(now), backoff+time.Second)
+		}
+	})
+}
+
+func Test_Outbox_Relay_Dead(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox    = goutbox.New(db, table)
+			publisher = &outboxPublisher{failures: map[string]int{`"a1"`: -1}}
+			relay     = outbox.NewRelay(publisher, goutbox.RelayOption{
+				MaxAttempts: 2,
+				BackoffBase: time.Hour,
+			})
+		)
+		enqueueOutbox(outbox, "a", "a1", "a2")
+
+		delivered, err := relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 0)
+
+		// The dead message does not block the later messages of its key.
+		_, err = db.Model(table).Data("next_attempt_at", 0).Where("status", "pending").Update()
+		t.AssertNil(err)
+		delivered, err = relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 1)
+		t.Assert(publisher.Published(), []string{`"a2"`})
+
+		one, err := db.Model(table).Where("payload", `"a1"`).One()
+		t.AssertNil(err)
+		t.Assert(one["status"], "dead")
+		t.Assert(one["attempts"], 2)
+		t.Assert(one["last_error"], "publish failed")
+
+		// The dead message is not delivered anymore.
+		_, err = db.Model(table).Data("next_attempt_at", 0).WhereGT("id", 0).Update()
+		t.AssertNil(err)
+		calls := publisher.Calls()
+		delivered, err = relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 0)
+		t.Assert(publisher.Calls(), calls)
+	})
+}
+
+func Test_Outbox_Relay_Panic(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox = goutbox.New(db, table)
+			relay  = outbox.NewRelay(goutbox.PublisherFunc(func(ctx context.Context, message *goutbox.Message) error {
+				panic("publisher panic")
+			}))
+		)
+		enqueueOutbox(outbox, "a", "a1")
+		delivered, err := relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 0)
+
+		one, err := db.Model(table).One()
+		t.AssertNil(err)
+		t.Assert(one["status"], "pending")
+		t.Assert(one["attempts"], 1)
+		t.Assert(one["last_error"], "publisher panic")
+	})
+}
+
+func Test_Outbox_Relay_DeleteDelivered(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox = goutbox.New(db, table)
+			relay  = outbox.NewRelay(&outboxPublisher{}, goutbox.RelayOption{
+				DeleteDelivered: true,
+			})
+		)
+		enqueueOutbox(outbox, "a", "a1", "a2")
+		delivered, err := relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 2)
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+}
+
+func Test_Outbox_Relay_Retention(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox = goutbox.New(db, table)
+			relay  = outbox.NewRelay(&outboxPublisher{}, goutbox.RelayOption{
+				Retention: time.Hour,
+			})
+		)
+		enqueueOutbox(outbox, "a", "a1", "a2")
+		delivered, err := relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 2)
+		count, err := db.Model(table).Where("status", "delivered").Count()
+		t.AssertNil(err)
+		t.Assert(count, 2)
+
+		// The message delivered before the retention is deleted.
+		_, err = db.Model(table).
+			Data("delivered_at", time.Now().Add(-2*time.Hour).UnixMilli()).
+			Where("payload", `"a1"`).
+			Update()
+		t.AssertNil(err)
+		_, err = relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		array, err := db.Model(table).Array("payload")
+		t.AssertNil(err)
+		t.Assert(array, []string{`"a2"`})
+	})
+}
+
+func Test_Outbox_Relay_Locker(t *testing.T) {
+	table := createOutboxTable()
+	defer dropTable(table)
+
+	// The relay does nothing if another relay holds the lock.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox    = goutbox.New(db, table)
+			locker    = gcron.NewLockerMemory()
+			publisher = &outboxPublisher{}
+			relay     = outbox.NewRelay(publisher, goutbox.RelayOption{
+				Locker:          locker,
+				DeleteDelivered: true,
+			})
+			lockKey = "goutbox:" + table
+		)
+		enqueueOutbox(outbox, "a", "a1")
+		ok, err := locker.Lock(ctx, lockKey, "other", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		delivered, err := relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 0)
+		t.Assert(publisher.Calls(), 0)
+
+		// The lock is released after relaying.
+		t.AssertNil(locker.Unlock(ctx, lockKey, "other"))
+		delivered, err = relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 1)
+		ok, err = locker.Lock(ctx, lockKey, "other", time.Minute)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		t.AssertNil(locker.Unlock(ctx, lockKey, "other"))
+	})
+
+	// The lock is extended before each delivery.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox    = goutbox.New(db, table)
+			locker    = gcron.NewLockerMemory()
+			lockKey   = "goutbox:" + table
+			stolen    bool
+			publisher = &outboxPublisher{
+				onPublish: func(message *goutbox.Message) {
+					time.Sleep(60 * time.Millisecond)
+					if message.Payload == `"a3"` {
+						stolen, _ = locker.Lock(ctx, lockKey, "other", time.Minute)
+					}
+				},
+			}
+			relay = outbox.NewRelay(publisher, goutbox.RelayOption{
+				Locker:          locker,
+				LockTTL:         100 * time.Millisecond,
+				DeleteDelivered: true,
+			})
+		)
+		enqueueOutbox(outbox, "a", "a1", "a2", "a3")
+		delivered, err := relay.RelayOnce(ctx)
+		t.AssertNil(err)
+		t.Assert(delivered, 3)
+		t.Assert(stolen, false)
+	})
+
+	// The relay stops if the lock is lost.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			outbox    = goutbox.New(db, table)
+			locker    = gcron.NewLockerMemory()
+			lockKey   = "goutbox:" + table
+			publisher = &outboxPublisher{
+				onPublish: func(message *goutbox.Message) {
+					time.Sleep(150 * time.Millisecond)
+					_, _ = locker.Lock(ctx, lockKey, "other", time.Minute)
+				},
+			}
+			relay = outbox.NewRelay(publisher, goutbox.RelayOption{
+				Locker:          locker,
+				LockTTL:         100 * time.Millisecond,
+				DeleteDelivered: true,
+			})
+		)
+		enqueueOutbox(outbox, "a", "b1", "b2")
+		delivered, err := relay.RelayOnce(ctx)
+		t.Assert(gerror.Code(err), gcode.CodeOperationFailed)
+		t.Assert(delivered, 1)
+		t.Assert(publisher.Published(), []string{`"b1"`})
+	})
+}
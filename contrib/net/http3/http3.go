@@ -0,0 +1,96 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package http3 implements ghttp.HTTP3Server using quic-go, which enables HTTP/3 for ghttp.Server.
+//
+// Usage:
+//
+//	import _ "github.com/gogf/gf/contrib/net/http3/v2"
+//
+//	s := g.Server()
+//	s.EnableHTTPS("server.crt", "server.key")
+//	s.EnableHTTP3()
+package http3
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+	quichttp3 "github.com/quic-go/quic-go/http3"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// Server implements ghttp.HTTP3Server using quic-go.
+type Server struct {
+	mu     sync.Mutex
+	server *quichttp3.Server
+	closed bool
+}
+
+func init() {
+	ghttp.SetHTTP3ServerCreator(New)
+}
+
+// New creates and returns a ghttp.HTTP3Server using quic-go.
+func New() ghttp.HTTP3Server {
+	return &Server{}
+}
+
+// Serve serves HTTP/3 requests on given UDP connection with `tlsConfig` and `handler`.
+// It blocks until the server is closed, and returns http.ErrServerClosed after Shutdown or Close.
+// The connection `conn` is closed when Serve returns.
+func (s *Server) Serve(conn net.PacketConn, tlsConfig *tls.Config, handler http.Handler) error {
+	defer conn.Close()
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return http.ErrServerClosed
+	}
+	s.server = &quichttp3.Server{
+		Handler:   handler,
+		TLSConfig: quichttp3.ConfigureTLSConfig(tlsConfig),
+	}
+	server := s.server
+	s.mu.Unlock()
+
+	err := server.Serve(conn)
+	if errors.Is(err, quic.ErrServerClosed) || errors.Is(err, net.ErrClosed) {
+		return http.ErrServerClosed
+	}
+	return err
+}
+
+// Shutdown gracefully shuts down the server, waiting for active requests until `ctx` is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	server := s.markClosed()
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// Close closes the server immediately.
+func (s *Server) Close() error {
+	server := s.markClosed()
+	if server == nil {
+		return nil
+	}
+	return server.Close()
+}
+
+// markClosed marks the server closed and returns the underlying quic-go server if it is serving.
+func (s *Server) markClosed() *quichttp3.Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return s.server
+}
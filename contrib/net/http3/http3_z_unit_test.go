@@ -0,0 +1,83 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package http3_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	quichttp3 "github.com/quic-go/quic-go/http3"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+
+	"github.com/gogf/gf/contrib/net/http3/v2"
+)
+
+func Test_HTTP3(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Write(r.Proto)
+	})
+	s.EnableHTTPS(gtest.DataPath("server.crt"), gtest.DataPath("server.key"))
+	s.SetHTTPSPort(0)
+	s.EnableHTTP3()
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertGT(s.GetListenedHTTP3Port(), 0)
+
+		// HTTP/1.1 and HTTP/2 responses advertise HTTP/3 using Alt-Svc header.
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", s.GetListenedHTTPSPort()))
+		resp, err := client.Get(context.Background(), "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Alt-Svc"), fmt.Sprintf(`h3=":%d"; ma=86400`, s.GetListenedHTTP3Port()))
+		resp.Close()
+
+		transport := &quichttp3.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+		defer transport.Close()
+		h3Client := &http.Client{Transport: transport, Timeout: 5 * time.Second}
+		h3Resp, err := h3Client.Get(fmt.Sprintf("https://127.0.0.1:%d/", s.GetListenedHTTP3Port()))
+		t.AssertNil(err)
+		defer h3Resp.Body.Close()
+		body, err := io.ReadAll(h3Resp.Body)
+		t.AssertNil(err)
+		t.Assert(h3Resp.ProtoMajor, 3)
+		t.Assert(string(body), "HTTP/3.0")
+		t.Assert(h3Resp.Header.Get("Alt-Svc"), "")
+	})
+}
+
+func Test_HTTP3_ServeClosed(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		t.AssertNil(err)
+
+		server := http3.New()
+		t.AssertNil(server.Close())
+		t.Assert(server.Serve(conn, &tls.Config{}, http.NotFoundHandler()), http.ErrServerClosed)
+
+		// The connection is closed although the server does not serve on it.
+		_, err = conn.WriteTo([]byte("test"), conn.LocalAddr())
+		t.Assert(errors.Is(err, net.ErrClosed), true)
+	})
+}
@@ -42,22 +42,39 @@ func New(config Config) *Client {
 	if !gstr.HasPrefix(config.URL, "http") {
 		config.URL = fmt.Sprintf("http://%s", config.URL)
 	}
+	client = client.Prefix(config.URL)
+	client.Use(MiddlewareContextHeader)
+	client.Use(newMiddlewareRetry(config.Retry, config.RetryInterval))
+	client.Use(config.Middlewares...)
 	return &Client{
-		Client:  client.Prefix(config.URL),
+		Client:  client,
 		Handler: handler,
 	}
 }
 
 // Request sends request to service by struct object `req`, and receives response to struct object `res`.
+// The request is sent in multipart form if `req` contains upload files, or else in json.
 func (c *Client) Request(ctx context.Context, req, res any) error {
 	var (
 		method = gmeta.Get(req, gtag.Method).String()
 		path   = gmeta.Get(req, gtag.Path).String()
 	)
-	switch gstr.ToUpper(method) {
-	case http.MethodGet:
+	switch {
+	case gstr.ToUpper(method) == http.MethodGet:
 		return c.Get(ctx, path, req, res)
 
+	case hasUploadFile(req):
+		body, contentType, err := newMultipartBody(req)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		result, err := c.ContentType(contentType).DoRequest(ctx, method, c.handlePath(path, req), body)
+		if err != nil {
+			return err
+		}
+		return c.HandleResponse(ctx, result, res)
+
 	default:
 		result, err := c.ContentJson().DoRequest(ctx, method, c.handlePath(path, req), req)
 		if err != nil {
@@ -7,6 +7,8 @@
 package httpclient
 
 import (
+	"time"
+
 	"github.com/gogf/gf/v2/net/gclient"
 	"github.com/gogf/gf/v2/os/glog"
 )
@@ -18,4 +20,10 @@ type Config struct {
 	Handler Handler         // Custom response handler.
 	Logger  *glog.Logger    // Custom logger.
 	RawDump bool            // Whether auto dump request&response in stdout.
+
+	// Retry is the retry count for failed requests, which are requests failing in transport
+	// or responded with status 429 or 5xx. It can be overwritten for a single call by WithRetry.
+	Retry         int
+	RetryInterval time.Duration         // Interval between retries with jitter, it is 100 milliseconds in default.
+	Middlewares   []gclient.HandlerFunc // Custom client middlewares, eg: authentication, metrics.
 }
@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// callOptions is the options of a single call, which are carried by context.
+type callOptions struct {
+	Headers       map[string]string
+	Retry         int
+	RetryInterval time.Duration
+	RetrySet      bool
+}
+
+const ctxKeyCallOptions gctx.StrKey = "HttpClientCallOptions"
+
+// WithHeader returns a new context carrying header `key` with `value`,
+// which is sent along with the requests of SDK calls using the context.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	return WithHeaders(ctx, map[string]string{key: value})
+}
+
+// WithHeaders returns a new context carrying `headers`,
+// which are sent along with the requests of SDK calls using the context.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	options := getCallOptions(ctx)
+	newHeaders := make(map[string]string, len(options.Headers)+len(headers))
+	for k, v := range options.Headers {
+		newHeaders[k] = v
+	}
+	for k, v := range headers {
+		newHeaders[k] = v
+	}
+	options.Headers = newHeaders
+	return context.WithValue(ctx, ctxKeyCallOptions, options)
+}
+
+// WithRetry returns a new context that overwrites the retry count and interval of Config
+// for SDK calls using the context. It disables retrying if `retry` is 0.
+func WithRetry(ctx context.Context, retry int, interval time.Duration) context.Context {
+	options := getCallOptions(ctx)
+	options.Retry = retry
+	options.RetryInterval = interval
+	options.RetrySet = true
+	return context.WithValue(ctx, ctxKeyCallOptions, options)
+}
+
+// getCallOptions retrieves and returns a copy of call options from context.
+func getCallOptions(ctx context.Context) callOptions {
+	if ctx != nil {
+		if options, ok := ctx.Value(ctxKeyCallOptions).(callOptions); ok {
+			return options
+		}
+	}
+	return callOptions{}
+}
+
+// MiddlewareContextHeader is a client middleware setting the headers carried by context
+// using WithHeader or WithHeaders, which is used by Client in default.
+func MiddlewareContextHeader(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+	for k, v := range getCallOptions(r.Context()).Headers {
+		r.Header.Set(k, v)
+	}
+	return c.Next(r)
+}
+
+// newMiddlewareRetry creates and returns a client middleware retrying the requests failing in transport
+// or responded with status 429 or 5xx, with the retry count and interval from context by WithRetry,
+// or else `retry` and `interval`. It retries using gclient.MiddlewareBackoff with the fixed interval.
+func newMiddlewareRetry(retry int, interval time.Duration) gclient.HandlerFunc {
+	return func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+		var (
+			count   = retry
+			backoff = interval
+		)
+		if options := getCallOptions(r.Context()); options.RetrySet {
+			count, backoff = options.Retry, options.RetryInterval
+		}
+		if count <= 0 {
+			return c.Next(r)
+		}
+		return gclient.MiddlewareBackoff(gclient.BackoffOption{
+			MaxRetries:   count,
+			BaseInterval: backoff,
+			MaxInterval:  backoff,
+			Multiplier:   1,
+			ShouldRetry:  shouldRetry,
+		})(c, r)
+	}
+}
+
+// shouldRetry checks whether the request should be retried, which fails in transport or is
+// responded with status 429 or 5xx.
+func shouldRetry(res *gclient.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if res == nil || res.Response == nil {
+		return false
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,180 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package httpclient
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"mime/multipart"
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gmeta"
+)
+
+const (
+	uploadFormFieldName = "file"
+	uploadMaxMemory     = math.MaxInt64 // The content is kept in memory, as the temporary files cannot be removed.
+)
+
+var (
+	reflectTypeUploadFile  = reflect.TypeOf((*ghttp.UploadFile)(nil))
+	reflectTypeUploadFiles = reflect.TypeOf(ghttp.UploadFiles(nil))
+	reflectTypeMeta        = reflect.TypeOf(gmeta.Meta{})
+)
+
+// NewUploadFile creates and returns an upload file named `name` with content from `reader`,
+// which is used as the value of request field in type of *ghttp.UploadFile.
+// The request containing upload files is sent in multipart form.
+//
+// Note that the content is kept in memory until the upload file is released.
+func NewUploadFile(name string, reader io.Reader) (*ghttp.UploadFile, error) {
+	var (
+		pipeReader, pipeWriter = io.Pipe()
+		writer                 = multipart.NewWriter(pipeWriter)
+	)
+	go func() {
+		part, err := writer.CreateFormFile(uploadFormFieldName, name)
+		if err != nil {
+			pipeWriter.CloseWithError(gerror.Wrapf(err, `create form file failed for "%s"`, name))
+			return
+		}
+		if _, err = io.Copy(part, reader); err != nil {
+			pipeWriter.CloseWithError(gerror.Wrapf(err, `read content failed for "%s"`, name))
+			return
+		}
+		pipeWriter.CloseWithError(writer.Close())
+	}()
+	defer pipeReader.Close()
+	form, err := multipart.NewReader(pipeReader, writer.Boundary()).ReadForm(uploadMaxMemory)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `read form file failed for "%s"`, name)
+	}
+	return &ghttp.UploadFile{FileHeader: form.File[uploadFormFieldName][0]}, nil
+}
+
+// NewUploadFileFromPath creates and returns an upload file with content of local file `path`.
+// See NewUploadFile.
+func NewUploadFileFromPath(path string) (*ghttp.UploadFile, error) {
+	file, err := gfile.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return NewUploadFile(gfile.Basename(path), file)
+}
+
+// hasUploadFile checks whether the request object `req` has non-nil upload file fields.
+func hasUploadFile(req any) bool {
+	fields, err := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         req,
+		RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
+	})
+	if err != nil {
+		return false
+	}
+	for _, field := range fields {
+		switch field.Type().Type {
+		case reflectTypeUploadFile, reflectTypeUploadFiles:
+			if !field.IsNil() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newMultipartBody creates and returns the multipart form body of request object `req` and its content type.
+// The body is written through pipe in a goroutine while it is read, and it should be closed after use.
+func newMultipartBody(req any) (body io.ReadCloser, contentType string, err error) {
+	fields, err := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         req,
+		RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var (
+		pipeReader, pipeWriter = io.Pipe()
+		writer                 = multipart.NewWriter(pipeWriter)
+	)
+	go func() {
+		pipeWriter.CloseWithError(writeMultipartFields(writer, fields))
+	}()
+	return pipeReader, writer.FormDataContentType(), nil
+}
+
+// writeMultipartFields writes the request fields to multipart form.
+func writeMultipartFields(writer *multipart.Writer, fields []gstructs.Field) (err error) {
+	for _, field := range fields {
+		if !field.IsExported() || field.Type().Type == reflectTypeMeta || field.IsNil() {
+			continue
+		}
+		var name = field.TagPriorityName()
+		if name == "-" {
+			continue
+		}
+		switch value := field.Value.Interface().(type) {
+		case *ghttp.UploadFile:
+			err = writeMultipartFile(writer, name, value)
+		case ghttp.UploadFiles:
+			for _, file := range value {
+				if err = writeMultipartFile(writer, name, file); err != nil {
+					break
+				}
+			}
+		default:
+			err = writer.WriteField(name, multipartFieldValue(field.Value))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return gerror.Wrap(err, `form writer close failed`)
+	}
+	return nil
+}
+
+func writeMultipartFile(writer *multipart.Writer, name string, file *ghttp.UploadFile) error {
+	if file == nil || file.FileHeader == nil {
+		return nil
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return gerror.Wrapf(err, `open upload file failed for "%s"`, file.Filename)
+	}
+	defer reader.Close()
+	part, err := writer.CreateFormFile(name, file.Filename)
+	if err != nil {
+		return gerror.Wrapf(err, `create form file failed for "%s"`, file.Filename)
+	}
+	if _, err = io.Copy(part, reader); err != nil {
+		return gerror.Wrapf(err, `copy upload file failed for "%s"`, file.Filename)
+	}
+	return nil
+}
+
+// multipartFieldValue converts the field value to form value,
+// the value of composite type is converted to json string.
+func multipartFieldValue(value reflect.Value) string {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		if b, err := json.Marshal(value.Interface()); err == nil {
+			return string(b)
+		}
+	}
+	return gconv.String(value.Interface())
+}
@@ -0,0 +1,220 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package httpclient_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+
+	"github.com/gogf/gf/contrib/sdk/httpclient/v2"
+)
+
+func Test_HttpClient_Context_Header_And_Middleware(t *testing.T) {
+	type Req struct {
+		g.Meta `path:"/header" method:"get"`
+	}
+	type Res struct {
+		Token string
+		Trace string
+	}
+
+	s := g.Server(guid.S())
+	s.BindHandler("/header", func(r *ghttp.Request) {
+		r.Response.WriteJson(ghttp.DefaultHandlerResponse{
+			Data: Res{
+				Token: r.Header.Get("Token"),
+				Trace: r.Header.Get("Trace"),
+			},
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := httpclient.New(httpclient.Config{
+			URL: fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()),
+			Middlewares: []gclient.HandlerFunc{
+				func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+					r.Header.Set("Trace", "middleware")
+					return c.Next(r)
+				},
+			},
+		})
+		var (
+			res = &Res{}
+			ctx = httpclient.WithHeader(gctx.New(), "Token", "123")
+		)
+		err := client.Request(ctx, &Req{}, res)
+		t.AssertNil(err)
+		t.Assert(res.Token, "123")
+		t.Assert(res.Trace, "middleware")
+
+		// Custom middlewares are called after setting headers of context, which can override them.
+		ctx = httpclient.WithHeaders(ctx, map[string]string{"Trace": "context"})
+		err = client.Request(ctx, &Req{}, res)
+		t.AssertNil(err)
+		t.Assert(res.Token, "123")
+		t.Assert(res.Trace, "middleware")
+
+		err = client.Request(gctx.New(), &Req{}, res)
+		t.AssertNil(err)
+		t.Assert(res.Token, "")
+	})
+}
+
+func Test_HttpClient_Retry(t *testing.T) {
+	type Req struct {
+		g.Meta `path:"/retry" method:"post"`
+		Name   string
+	}
+	type Res struct {
+		Name  string
+		Times int
+	}
+
+	var times = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/retry", func(r *ghttp.Request) {
+		if n := times.Add(1); n%3 != 0 {
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+			return
+		}
+		r.Response.WriteJson(ghttp.DefaultHandlerResponse{
+			Data: Res{Name: r.Get("Name").String(), Times: times.Val()},
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := httpclient.New(httpclient.Config{
+			URL:           fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()),
+			Retry:         2,
+			RetryInterval: 10 * time.Millisecond,
+		})
+		var res = &Res{}
+		err := client.Request(gctx.New(), &Req{Name: "john"}, res)
+		t.AssertNil(err)
+		t.Assert(res.Name, "john")
+		t.Assert(res.Times, 3)
+
+		// Retrying is disabled by context.
+		ctx := httpclient.WithRetry(gctx.New(), 0, 0)
+		err = client.Request(ctx, &Req{Name: "john"}, res)
+		t.AssertNE(err, nil)
+		t.Assert(times.Val(), 4)
+	})
+}
+
+func Test_HttpClient_Upload(t *testing.T) {
+	type Req struct {
+		g.Meta `path:"/upload/{id}" method:"post"`
+		Id     int               `json:"id"`
+		Name   string            `json:"name"`
+		Tags   []string          `json:"tags"`
+		File   *ghttp.UploadFile `json:"file"`
+	}
+	type Res struct {
+		Id       int
+		Name     string
+		Tags     []string
+		FileName string
+		Content  string
+	}
+
+	var failures = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/upload/{id}", func(r *ghttp.Request) {
+		if failures.Add(-1) >= 0 {
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+			return
+		}
+		var (
+			file    = r.GetUploadFile("file")
+			content string
+		)
+		if f, err := file.Open(); err == nil {
+			buffer := make([]byte, file.Size)
+			_, _ = f.Read(buffer)
+			_ = f.Close()
+			content = string(buffer)
+		}
+		r.Response.WriteJson(ghttp.DefaultHandlerResponse{
+			Data: Res{
+				Id:       r.Get("id").Int(),
+				Name:     r.Get("name").String(),
+				Tags:     r.Get("tags").Strings(),
+				FileName: file.Filename,
+				Content:  content,
+			},
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := httpclient.New(httpclient.Config{
+			URL: fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()),
+		})
+		file, err := httpclient.NewUploadFile("test.txt", strings.NewReader("file content"))
+		t.AssertNil(err)
+		var (
+			req = &Req{Id: 1, Name: "john", Tags: []string{"a", "b"}, File: file}
+			res = &Res{}
+		)
+		err = client.Request(gctx.New(), req, res)
+		t.AssertNil(err)
+		t.Assert(res.Id, 1)
+		t.Assert(res.Name, "john")
+		t.Assert(res.Tags, []string{"a", "b"})
+		t.Assert(res.FileName, "test.txt")
+		t.Assert(res.Content, "file content")
+	})
+
+	// The streamed body is sent again in retrying.
+	gtest.C(t, func(t *gtest.T) {
+		client := httpclient.New(httpclient.Config{
+			URL:           fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()),
+			Retry:         1,
+			RetryInterval: 10 * time.Millisecond,
+		})
+		file, err := httpclient.NewUploadFile("retry.txt", strings.NewReader("retry content"))
+		t.AssertNil(err)
+		var (
+			req = &Req{Id: 2, Name: "smith", File: file}
+			res = &Res{}
+		)
+		failures.Set(1)
+		err = client.Request(gctx.New(), req, res)
+		t.AssertNil(err)
+		t.Assert(res.Id, 2)
+		t.Assert(res.Name, "smith")
+		t.Assert(res.FileName, "retry.txt")
+		t.Assert(res.Content, "retry content")
+		t.Assert(failures.Val(), -1)
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package goutbox implements the transactional outbox for gdb, which publishes messages reliably
+// after the database transaction is committed.
+//
+// The messages are enqueued into the outbox table using the same transaction as the business data,
+// so that they are committed or rolled back together. The relay then polls the outbox table and
+// delivers the messages to a publisher with retries, in order for the messages of the same aggregate key.
+// The publishers of Redis stream, HTTP webhook and event bus are provided by package goutbox/publisher.
+package goutbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// Outbox is the outbox table in database, which should be created in advance, eg in MySQL:
+//
+//	CREATE TABLE `goutbox` (
+//	    `id`              bigint       NOT NULL AUTO_INCREMENT COMMENT 'Message id',
+//	    `topic`           varchar(255) NOT NULL COMMENT 'Message topic',
+//	    `aggregate_key`   varchar(255) NOT NULL DEFAULT '' COMMENT 'Messages of the same key are delivered in order',
+//	    `payload`         longtext     NOT NULL COMMENT 'Message payload in JSON',
+//	    `headers`         text         COMMENT 'Message headers in JSON',
+//	    `status`          varchar(16)  NOT NULL COMMENT 'Status: pending, delivered or dead',
+//	    `attempts`        int          NOT NULL DEFAULT 0 COMMENT 'Delivery attempts that have been made',
+//	    `next_attempt_at` bigint       NOT NULL DEFAULT 0 COMMENT 'Timestamp of next delivery in milliseconds',
+//	    `last_error`      text         COMMENT 'Error of the last failed delivery',
+//	    `enqueued_at`     bigint       NOT NULL COMMENT 'Enqueued timestamp in milliseconds',
+//	    `delivered_at`    bigint       NOT NULL DEFAULT 0 COMMENT 'Delivered timestamp in milliseconds',
+//	    PRIMARY KEY (`id`),
+//	    KEY `status_next_attempt_at` (`status`, `next_attempt_at`),
+//	    KEY `aggregate_key_id` (`aggregate_key`, `id`)
+//	);
+type Outbox struct {
+	db    gdb.DB
+	table string
+}
+
+// Message is a message in outbox.
+type Message struct {
+	ID           int64             `json:"id"`                     // Auto-increment id, which is the delivery order.
+	Topic        string            `json:"topic"`                  // Topic of the message.
+	AggregateKey string            `json:"aggregateKey,omitempty"` // Messages of the same key are delivered in order.
+	Payload      string            `json:"payload"`                // Payload of the message in JSON.
+	Headers      map[string]string `json:"headers,omitempty"`      // Headers of the message.
+	Attempts     int               `json:"attempts"`               // Delivery attempts that have been made.
+	EnqueuedAt   time.Time         `json:"enqueuedAt"`             // Time at which the message is enqueued.
+}
+
+// EnqueueOption is the option for enqueuing message.
+type EnqueueOption struct {
+	AggregateKey string            // Messages of the same key are delivered in order of enqueuing.
+	Headers      map[string]string // Headers of the message.
+}
+
+// Publisher publishes the messages of outbox to the message system.
+type Publisher interface {
+	// Publish publishes the message, the message is delivered again later if it returns error.
+	Publish(ctx context.Context, message *Message) error
+}
+
+// PublisherFunc is the function implements Publisher.
+type PublisherFunc func(ctx context.Context, message *Message) error
+
+// outboxRecord is the message record in table.
+type outboxRecord struct {
+	Id            int64
+	Topic         string
+	AggregateKey  string
+	Payload       string
+	Headers       string
+	Status        string
+	Attempts      int
+	NextAttemptAt int64
+	LastError     string
+	EnqueuedAt    int64
+	DeliveredAt   int64
+}
+
+const (
+	defaultTable = "goutbox"
+
+	statusPending   = "pending"
+	statusDelivered = "delivered"
+	statusDead      = "dead"
+)
+
+// New creates and returns an outbox of database `db`.
+// The optional parameter `table` specifies the outbox table name, which is "goutbox" in default.
+func New(db gdb.DB, table ...string) *Outbox {
+	o := &Outbox{
+		db:    db,
+		table: defaultTable,
+	}
+	if len(table) > 0 && table[0] != "" {
+		o.table = table[0]
+	}
+	return o
+}
+
+// Enqueue enqueues a message of `topic` with `payload`, which is encoded in JSON, into outbox using
+// transaction `tx`. The message is delivered only if the transaction is committed.
+//
+// Example:
+//
+//	err := db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+//	    if _, err := tx.Model("order").Ctx(ctx).Insert(order); err != nil {
+//	        return err
+//	    }
+//	    return outbox.Enqueue(ctx, tx, "order.created", order, goutbox.EnqueueOption{
+//	        AggregateKey: order.Id,
+//	    })
+//	})
+func (o *Outbox) Enqueue(ctx context.Context, tx gdb.TX, topic string, payload any, option ...EnqueueOption) error {
+	var opt EnqueueOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var headers []byte
+	if len(opt.Headers) > 0 {
+		if headers, err = json.Marshal(opt.Headers); err != nil {
+			return err
+		}
+	}
+	now := time.Now().UnixMilli()
+	_, err = tx.Model(o.table).Ctx(ctx).Insert(map[string]any{
+		"topic":           topic,
+		"aggregate_key":   opt.AggregateKey,
+		"payload":         string(content),
+		"headers":         string(headers),
+		"status":          statusPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+		"last_error":      "",
+		"enqueued_at":     now,
+		"delivered_at":    0,
+	})
+	return err
+}
+
+// Publish implements the Publisher interface.
+func (f PublisherFunc) Publish(ctx context.Context, message *Message) error {
+	return f(ctx, message)
+}
+
+// toMessage converts the record to Message.
+func (r *outboxRecord) toMessage() *Message {
+	message := &Message{
+		ID:           r.Id,
+		Topic:        r.Topic,
+		AggregateKey: r.AggregateKey,
+		Payload:      r.Payload,
+		Attempts:     r.Attempts,
+		EnqueuedAt:   time.UnixMilli(r.EnqueuedAt),
+	}
+	if r.Headers != "" {
+		_ = json.UnmarshalUseNumber([]byte(r.Headers), &message.Headers)
+	}
+	return message
+}
@@ -0,0 +1,303 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goutbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Relay delivers the pending messages of outbox to the publisher.
+//
+// The messages are delivered in order of enqueuing. If a message fails, the later messages of
+// the same aggregate key wait until it is delivered or dead, while the messages of other keys go on.
+type Relay struct {
+	outbox    *Outbox
+	publisher Publisher
+	option    RelayOption
+	owner     string        // Owner of the relay lock.
+	timer     *gtimer.Entry // Timer entry polling the outbox.
+	started   *gtype.Bool   // Whether the relay is started.
+	relayMu   sync.Mutex    // Ensures only one relaying at the same time in process.
+	notify    chan struct{} // Triggers relaying immediately.
+	done      chan struct{} // Closed when the relay is stopped.
+}
+
+// RelayOption is the option for relay.
+type RelayOption struct {
+	Interval        time.Duration // Interval polling the outbox, it is 1 second in default.
+	Batch           int           // Max messages read from outbox in each polling, it is 100 in default.
+	MaxAttempts     int           // Max delivery attempts, the message is marked dead after that, it is 10 in default.
+	BackoffBase     time.Duration // Base interval of exponential backoff for retries, it is 1 second in default.
+	BackoffMax      time.Duration // Max interval of exponential backoff for retries, it is 10 minutes in default.
+	DeleteDelivered bool          // Delete the delivered messages instead of marking them delivered.
+	Retention       time.Duration // Retention of the messages marked delivered, they are kept forever if it is not positive.
+	Locker          Locker        // Locker ensuring only one relay among processes delivers the messages, optional.
+	LockTTL         time.Duration // TTL of the lock, which is extended before each delivery, it is 30 seconds in default.
+	Logger          glog.ILogger  // Logger for delivery errors, it uses the default logger if it is nil.
+}
+
+// Locker is the distributed lock for relays among processes, which is implemented by the lockers of gcron.
+type Locker interface {
+	// Lock tries to acquire the lock `key` for `owner`, which expires after `ttl`.
+	// It returns true if the lock is acquired, or it is already held by `owner`,
+	// in which case the expiration is extended with `ttl`.
+	Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+
+	// Unlock releases the lock `key` if it is held by `owner`.
+	Unlock(ctx context.Context, key, owner string) error
+}
+
+const (
+	defaultRelayInterval    = time.Second
+	defaultRelayBatch       = 100
+	defaultRelayMaxAttempts = 10
+	defaultRelayBackoffBase = time.Second
+	defaultRelayBackoffMax  = 10 * time.Minute
+	defaultRelayLockTTL     = 30 * time.Second
+)
+
+// NewRelay creates and returns a relay delivering the messages of outbox to `publisher`.
+// The relay does not deliver messages until it is started.
+func (o *Outbox) NewRelay(publisher Publisher, option ...RelayOption) *Relay {
+	var opt RelayOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Interval <= 0 {
+		opt.Interval = defaultRelayInterval
+	}
+	if opt.Batch <= 0 {
+		opt.Batch = defaultRelayBatch
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = defaultRelayMaxAttempts
+	}
+	if opt.BackoffBase <= 0 {
+		opt.BackoffBase = defaultRelayBackoffBase
+	}
+	if opt.BackoffMax <= 0 {
+		opt.BackoffMax = defaultRelayBackoffMax
+	}
+	if opt.LockTTL <= 0 {
+		opt.LockTTL = defaultRelayLockTTL
+	}
+	return &Relay{
+		outbox:    o,
+		publisher: publisher,
+		option:    opt,
+		owner:     guid.S(),
+		started:   gtype.NewBool(),
+		notify:    make(chan struct{}, 1),
+	}
+}
+
+// Start starts polling and delivering the messages.
+func (r *Relay) Start(ctx context.Context) {
+	if !r.started.Cas(false, true) {
+		return
+	}
+	r.done = make(chan struct{})
+	r.timer = gtimer.AddSingleton(ctx, r.option.Interval, r.poll)
+	go func(done chan struct{}) {
+		for {
+			select {
+			case <-r.notify:
+				r.poll(ctx)
+			case <-done:
+				return
+			}
+		}
+	}(r.done)
+}
+
+// Stop stops polling the messages, it waits for the delivering in progress done.
+func (r *Relay) Stop(ctx context.Context) {
+	if !r.started.Cas(true, false) {
+		return
+	}
+	r.timer.Close()
+	close(r.done)
+	r.relayMu.Lock()
+	r.relayMu.Unlock()
+}
+
+// Notify triggers delivering messages immediately, eg after the transaction enqueuing messages is committed.
+func (r *Relay) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// poll delivers the messages and logs the error.
+func (r *Relay) poll(ctx context.Context) {
+	if _, err := r.RelayOnce(ctx); err != nil {
+		r.logErrorf(ctx, `relay outbox messages failed: %+v`, err)
+	}
+}
+
+// RelayOnce delivers a batch of pending messages, and returns the count of delivered messages.
+// It does nothing if another relay holds the lock.
+func (r *Relay) RelayOnce(ctx context.Context) (delivered int, err error) {
+	if !r.relayMu.TryLock() {
+		return 0, nil
+	}
+	defer r.relayMu.Unlock()
+	lockKey := "goutbox:" + r.outbox.table
+	if r.option.Locker != nil {
+		if ok, err := r.option.Locker.Lock(ctx, lockKey, r.owner, r.option.LockTTL); err != nil || !ok {
+			return 0, err
+		}
+		defer func() {
+			if unlockErr := r.option.Locker.Unlock(ctx, lockKey, r.owner); unlockErr != nil && err == nil {
+				err = unlockErr
+			}
+		}()
+	}
+	records, err := r.pendingRecords(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// Aggregate keys whose earlier message in the batch is not delivered.
+	blocked := make(map[string]struct{})
+	for i, record := range records {
+		if ctx.Err() != nil {
+			break
+		}
+		if record.AggregateKey != "" {
+			if _, ok := blocked[record.AggregateKey]; ok {
+				continue
+			}
+		}
+		if r.option.Locker != nil && i > 0 {
+			// The lock is extended, in case the batch takes longer than its TTL.
+			ok, err := r.option.Locker.Lock(ctx, lockKey, r.owner, r.option.LockTTL)
+			if err != nil {
+				return delivered, err
+			}
+			if !ok {
+				return delivered, gerror.NewCodef(
+					gcode.CodeOperationFailed, `outbox relay lock "%s" is lost`, lockKey,
+				)
+			}
+		}
+		ok, dead := r.deliver(ctx, record)
+		if ok {
+			delivered++
+		} else if !dead && record.AggregateKey != "" {
+			// The dead message does not block the later messages.
+			blocked[record.AggregateKey] = struct{}{}
+		}
+	}
+	if r.option.Retention > 0 && !r.option.DeleteDelivered {
+		_, err = r.outbox.db.Model(r.outbox.table).Ctx(ctx).
+			Where("status", statusDelivered).
+			WhereLT("delivered_at", time.Now().Add(-r.option.Retention).UnixMilli()).
+			Delete()
+	}
+	return delivered, err
+}
+
+// pendingRecords returns a batch of pending messages which are due to deliver in order of id.
+//
+// The messages of aggregate key whose earlier message is waiting for retry are excluded, so the messages
+// blocked by the retry are not read repeatedly, and the messages of other keys are not starved.
+func (r *Relay) pendingRecords(ctx context.Context) (records []*outboxRecord, err error) {
+	var (
+		now   = time.Now().UnixMilli()
+		table = r.outbox.db.GetCore().QuotePrefixTableName(r.outbox.table)
+	)
+	err = r.outbox.db.Model(r.outbox.table).As("o").Ctx(ctx).
+		Where("o.status", statusPending).
+		WhereLTE("o.next_attempt_at", now).
+		Where(fmt.Sprintf(
+			`NOT EXISTS (SELECT 1 FROM %s b WHERE b.aggregate_key <> '' AND b.aggregate_key = o.aggregate_key `+
+				`AND b.status = ? AND b.id < o.id AND b.next_attempt_at > ?)`,
+			table,
+		), statusPending, now).
+		OrderAsc("o.id").
+		Limit(r.option.Batch).
+		Scan(&records)
+	return
+}
+
+// deliver publishes the message of `record` and updates its status.
+// It returns whether the message is delivered, and whether the message is dead after the failed delivery.
+func (r *Relay) deliver(ctx context.Context, record *outboxRecord) (ok, dead bool) {
+	var (
+		message = record.toMessage()
+		err     = r.publish(ctx, message)
+		model   = r.outbox.db.Model(r.outbox.table).Ctx(ctx).Where("id", record.Id)
+	)
+	if err == nil {
+		if r.option.DeleteDelivered {
+			_, err = model.Delete()
+		} else {
+			_, err = model.Data(map[string]any{
+				"status":       statusDelivered,
+				"attempts":     record.Attempts + 1,
+				"delivered_at": time.Now().UnixMilli(),
+			}).Update()
+		}
+		if err != nil {
+			// The message might be delivered again, which is acceptable for at-least-once delivery.
+			r.logErrorf(ctx, `mark outbox message %d delivered failed: %+v`, record.Id, err)
+		}
+		return true, false
+	}
+	var (
+		attempts = record.Attempts + 1
+		data     = map[string]any{
+			"attempts":   attempts,
+			"last_error": err.Error(),
+		}
+	)
+	dead = attempts >= r.option.MaxAttempts
+	if dead {
+		r.logErrorf(
+			ctx, `outbox message %d of topic "%s" is dead after %d attempts: %+v`,
+			record.Id, record.Topic, attempts, err,
+		)
+		data["status"] = statusDead
+	} else {
+		r.logErrorf(ctx, `deliver outbox message %d of topic "%s" failed: %+v`, record.Id, record.Topic, err)
+		data["next_attempt_at"] = time.Now().Add(utils.Backoff(r.option.BackoffBase, r.option.BackoffMax, attempts)).UnixMilli()
+	}
+	if _, err = model.Data(data).Update(); err != nil {
+		r.logErrorf(ctx, `update outbox message %d failed: %+v`, record.Id, err)
+	}
+	return false, dead
+}
+
+// publish publishes the message, the panic of publisher is returned as error.
+func (r *Relay) publish(ctx context.Context, message *Message) (err error) {
+	defer func() {
+		if exception := recover(); exception != nil {
+			err = utils.PanicToError(exception)
+		}
+	}()
+	return r.publisher.Publish(ctx, message)
+}
+
+func (r *Relay) logErrorf(ctx context.Context, format string, v ...any) {
+	logger := r.option.Logger
+	if logger == nil {
+		logger = glog.DefaultLogger()
+	}
+	logger.Errorf(ctx, format, v...)
+}
@@ -0,0 +1,34 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goutbox_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/database/goutbox"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	ctx = gctx.New()
+)
+
+func Test_PublisherFunc(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			topic     string
+			publisher goutbox.Publisher = goutbox.PublisherFunc(func(ctx context.Context, message *goutbox.Message) error {
+				topic = message.Topic
+				return nil
+			})
+		)
+		t.AssertNil(publisher.Publish(ctx, &goutbox.Message{Topic: "order.created"}))
+		t.Assert(topic, "order.created")
+	})
+}
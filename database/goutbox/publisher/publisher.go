@@ -0,0 +1,124 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package publisher provides the publishers delivering the outbox messages to Redis stream,
+// HTTP webhook and event bus.
+package publisher
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gogf/gf/v2/database/goutbox"
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/os/gevent"
+)
+
+// RedisStream is the publisher appending messages to Redis stream,
+// the message is stored in fields "id", "topic", "key", "payload" and "headers" of stream entry.
+type RedisStream struct {
+	redis  *gredis.Redis
+	stream string
+	maxLen int64
+}
+
+// Webhook is the publisher posting messages in JSON to HTTP webhook,
+// the message is delivered if the webhook responds status 2xx.
+type Webhook struct {
+	client *gclient.Client
+	url    string
+}
+
+// Event is the publisher publishing messages to event bus, the message topic is used as event topic.
+type Event struct {
+	bus *gevent.Bus
+}
+
+const (
+	defaultRedisStreamMaxLen = 100000
+)
+
+var (
+	_ goutbox.Publisher = (*RedisStream)(nil)
+	_ goutbox.Publisher = (*Webhook)(nil)
+	_ goutbox.Publisher = (*Event)(nil)
+)
+
+// NewRedisStream creates and returns a publisher appending messages to Redis stream `stream`.
+// The optional parameter `maxLen` specifies the approximate max length of stream, which is 100000 in default.
+func NewRedisStream(redis *gredis.Redis, stream string, maxLen ...int64) *RedisStream {
+	p := &RedisStream{
+		redis:  redis,
+		stream: stream,
+		maxLen: defaultRedisStreamMaxLen,
+	}
+	if len(maxLen) > 0 && maxLen[0] > 0 {
+		p.maxLen = maxLen[0]
+	}
+	return p
+}
+
+// Publish appends the message to the stream.
+func (p *RedisStream) Publish(ctx context.Context, message *goutbox.Message) error {
+	headers, err := json.Marshal(message.Headers)
+	if err != nil {
+		return err
+	}
+	_, err = p.redis.Do(
+		ctx, "XADD", p.stream, "MAXLEN", "~", p.maxLen, "*",
+		"id", message.ID,
+		"topic", message.Topic,
+		"key", message.AggregateKey,
+		"payload", message.Payload,
+		"headers", string(headers),
+	)
+	return err
+}
+
+// NewWebhook creates and returns a publisher posting messages to `url` using `client`.
+// A new client is created if `client` is nil.
+func NewWebhook(client *gclient.Client, url string) *Webhook {
+	if client == nil {
+		client = gclient.New()
+	}
+	return &Webhook{
+		client: client,
+		url:    url,
+	}
+}
+
+// Publish posts the message in JSON to the webhook, the message headers are sent as HTTP headers.
+func (p *Webhook) Publish(ctx context.Context, message *goutbox.Message) error {
+	response, err := p.client.ContentJson().Header(message.Headers).Post(ctx, p.url, message)
+	if err != nil {
+		return err
+	}
+	defer response.Close()
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed,
+			`webhook responds status %d: %s`,
+			response.StatusCode, response.ReadAllString(),
+		)
+	}
+	return nil
+}
+
+// NewEvent creates and returns a publisher publishing messages to event bus `bus`.
+func NewEvent(bus *gevent.Bus) *Event {
+	return &Event{
+		bus: bus,
+	}
+}
+
+// Publish publishes the message payload as event of the message topic.
+func (p *Event) Publish(ctx context.Context, message *goutbox.Message) error {
+	return p.bus.Publish(ctx, message.Topic, json.RawMessage(message.Payload))
+}
@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package publisher_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/database/goutbox"
+	"github.com/gogf/gf/v2/database/goutbox/publisher"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gevent"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+var (
+	ctx = gctx.New()
+)
+
+func Test_Webhook(t *testing.T) {
+	var received = make(chan map[string]any, 1)
+	s := ghttp.GetServer(guid.S())
+	s.BindHandler("/webhook", func(r *ghttp.Request) {
+		if r.Header.Get("X-Tenant") != "t1" {
+			r.Response.WriteStatus(http.StatusBadRequest, "missing tenant")
+			return
+		}
+		received <- r.GetMap()
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			url     = fmt.Sprintf("http://127.0.0.1:%d/webhook", s.GetListenedPort())
+			webhook = publisher.NewWebhook(nil, url)
+			message = &goutbox.Message{
+				ID:           1,
+				Topic:        "order.created",
+				AggregateKey: "order-1",
+				Payload:      `{"id":1}`,
+				Headers:      map[string]string{"X-Tenant": "t1"},
+			}
+		)
+		t.AssertNil(webhook.Publish(ctx, message))
+		data := <-received
+		t.Assert(data["id"], 1)
+		t.Assert(data["topic"], "order.created")
+		t.Assert(data["aggregateKey"], "order-1")
+		t.Assert(data["payload"], `{"id":1}`)
+
+		// Non-2xx status fails the delivery.
+		message.Headers = nil
+		err := webhook.Publish(ctx, message)
+		t.Assert(gerror.Code(err), gcode.CodeOperationFailed)
+	})
+}
+
+func Test_Event(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			bus      = gevent.New()
+			received = make(chan *gevent.Event, 1)
+		)
+		t.AssertNil(bus.Start(ctx))
+		defer bus.Close(ctx)
+		bus.Subscribe("order.*", func(ctx context.Context, event *gevent.Event) error {
+			received <- event
+			return nil
+		})
+		t.AssertNil(publisher.NewEvent(bus).Publish(ctx, &goutbox.Message{
+			ID:      1,
+			Topic:   "order.created",
+			Payload: `{"id":1}`,
+		}))
+		event := <-received
+		t.Assert(event.Topic, "order.created")
+		t.Assert(event.Payload, `{"id":1}`)
+	})
+}
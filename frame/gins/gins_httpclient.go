@@ -7,16 +7,51 @@
 package gins
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/gogf/gf/v2/internal/consts"
 	"github.com/gogf/gf/v2/internal/instance"
+	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+const (
+	defaultHttpClientName = "default"
 )
 
 // HttpClient returns an instance of http client with specified name.
+//
+// The client is configured with the configuration node "httpclient.<name>" if it exists,
+// and the name is "default" if it is not given. See gclient.Client.SetConfigWithMap.
+// Note that it panics if any error occurs during instance creating.
 func HttpClient(name ...any) *gclient.Client {
 	var instanceKey = fmt.Sprintf("%s.%v", frameCoreComponentNameHttpClient, name)
 	return instance.GetOrSetFuncLock(instanceKey, func() any {
-		return gclient.New()
+		var (
+			ctx        = context.Background()
+			client     = gclient.New()
+			clientName = defaultHttpClientName
+		)
+		if len(name) > 0 && gconv.String(name[0]) != "" {
+			clientName = gconv.String(name[0])
+		}
+		if !Config().Available(ctx) {
+			return client
+		}
+		configMap, err := Config().Data(ctx)
+		if err != nil {
+			intlog.Errorf(ctx, `retrieve config data map failed: %+v`, err)
+		}
+		if _, v := gutil.MapPossibleItemByKey(configMap, consts.ConfigNodeNameHttpClient); v != nil {
+			if _, v = gutil.MapPossibleItemByKey(gconv.Map(v), clientName); v != nil {
+				if err = client.SetConfigWithMap(gconv.Map(v)); err != nil {
+					panic(err)
+				}
+			}
+		}
+		return client
 	}).(*gclient.Client)
 }
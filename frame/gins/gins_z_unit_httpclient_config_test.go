@@ -0,0 +1,43 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gins_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/gins"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Client_Config(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := gins.Config().GetAdapter().(*gcfg.AdapterFile).AddPath(gtest.DataPath("httpclient"))
+		t.AssertNil(err)
+		defer gins.Config().GetAdapter().(*gcfg.AdapterFile).Clear()
+
+		time.Sleep(500 * time.Millisecond)
+
+		var (
+			ctx    = context.Background()
+			mock   = gclient.NewMockTransport()
+			client = gins.HttpClient("upstream")
+		)
+		mock.On("GET", "/api/user").Reply(200, "ok")
+		client.SetTransport(mock)
+		t.Assert(client.Client.Timeout, 5*time.Second)
+		t.Assert(client.GetContent(ctx, "/user"), "ok")
+
+		calls := mock.Calls()
+		t.Assert(len(calls), 1)
+		t.Assert(calls[0].URL, "http://127.0.0.1:8080/api/user")
+		t.Assert(calls[0].Header.Get("X-Client"), "gins")
+	})
+}
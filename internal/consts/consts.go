@@ -14,6 +14,7 @@ const (
 	ConfigNodeNameViewer          = "viewer"
 	ConfigNodeNameServer          = "server"     // General version configuration item name.
 	ConfigNodeNameServerSecondary = "httpserver" // New version configuration item name support from v2.
+	ConfigNodeNameHttpClient      = "httpclient"
 
 	// StackFilterKeyForGoFrame is the stack filtering key for all GoFrame module paths.
 	// Eg: .../pkg/mod/github.com/gogf/gf/v2@v2.0.0-20211011134327-54dd11f51122/debug/gdebug/gdebug_caller.go
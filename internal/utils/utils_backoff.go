@@ -0,0 +1,21 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package utils
+
+import (
+	"time"
+)
+
+// Backoff returns the exponential backoff interval for the retry after `attempts`,
+// which is `base` doubled for each attempt after the first and limited to `max`.
+func Backoff(base, max time.Duration, attempts int) time.Duration {
+	interval := base
+	for i := 1; i < attempts && interval < max; i++ {
+		interval *= 2
+	}
+	return min(interval, max)
+}
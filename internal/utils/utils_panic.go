@@ -0,0 +1,21 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package utils
+
+import (
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// PanicToError converts the recovered `exception` to error of code gcode.CodeInternalPanic.
+// The `exception` is returned directly if it is already an error with stack.
+func PanicToError(exception any) error {
+	if v, ok := exception.(error); ok && gerror.HasStack(v) {
+		return v
+	}
+	return gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+}
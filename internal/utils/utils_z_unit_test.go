@@ -10,8 +10,11 @@ import (
 	"io"
 	"reflect"
 	"testing"
+	"time"
 	"unsafe"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/utils"
 	"github.com/gogf/gf/v2/test/gtest"
 )
@@ -138,3 +141,23 @@ func TestIsASCII(t *testing.T) {
 		t.AssertEQ(utils.IsASCII("😁😭❤️😓"), false)
 	})
 }
+
+func Test_PanicToError(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := utils.PanicToError("exception")
+		t.Assert(gerror.Code(err), gcode.CodeInternalPanic)
+		t.Assert(err.Error(), "exception")
+
+		origin := gerror.NewCode(gcode.CodeInvalidParameter, "invalid")
+		t.Assert(utils.PanicToError(origin) == origin, true)
+	})
+}
+
+func Test_Backoff(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(utils.Backoff(time.Second, time.Minute, 1), time.Second)
+		t.Assert(utils.Backoff(time.Second, time.Minute, 2), 2*time.Second)
+		t.Assert(utils.Backoff(time.Second, time.Minute, 4), 8*time.Second)
+		t.Assert(utils.Backoff(time.Second, time.Minute, 100), time.Minute)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/net/gsel"
 	"github.com/gogf/gf/v2/net/gsvc"
@@ -36,20 +37,22 @@ type Client struct {
 	middlewareHandler []HandlerFunc     // Interceptor handlers
 	discovery         gsvc.Discovery    // Discovery for service.
 	builder           gsel.Builder      // Builder for request balance.
+	breakers          *gset.Set         // Circuit breakers used by the client, which are used in node filtering.
 }
 
 const (
-	httpProtocolName          = `http`
-	httpParamFileHolder       = `@file:`
-	httpRegexParamJson        = `^[\w\[\]]+=.+`
-	httpRegexHeaderRaw        = `^([\w\-]+):\s*(.+)`
-	httpHeaderHost            = `Host`
-	httpHeaderCookie          = `Cookie`
-	httpHeaderUserAgent       = `User-Agent`
-	httpHeaderContentType     = `Content-Type`
-	httpHeaderContentTypeJson = `application/json`
-	httpHeaderContentTypeXml  = `application/xml`
-	httpHeaderContentTypeForm = `application/x-www-form-urlencoded`
+	httpProtocolName                 = `http`
+	httpParamFileHolder              = `@file:`
+	httpRegexParamJson               = `^[\w\[\]]+=.+`
+	httpRegexHeaderRaw               = `^([\w\-]+):\s*(.+)`
+	httpHeaderHost                   = `Host`
+	httpHeaderCookie                 = `Cookie`
+	httpHeaderUserAgent              = `User-Agent`
+	httpHeaderContentType            = `Content-Type`
+	httpHeaderContentTypeJson        = `application/json`
+	httpHeaderContentTypeXml         = `application/xml`
+	httpHeaderContentTypeForm        = `application/x-www-form-urlencoded`
+	httpHeaderContentTypeOctetStream = `application/octet-stream`
 )
 
 var (
@@ -111,6 +114,7 @@ func NewWithHttpClient(client *http.Client) *Client {
 		cookies:   make(map[string]string),
 		builder:   gsel.GetBuilder(),
 		discovery: nil,
+		breakers:  gset.New(true),
 	}
 	c.header[httpHeaderUserAgent] = defaultClientAgent
 	// It enables OpenTelemetry for client in default.
@@ -130,6 +134,9 @@ func (c *Client) Clone() *Client {
 	for k, v := range c.cookies {
 		newClient.cookies[k] = v
 	}
+	if c.breakers != nil {
+		newClient.breakers = gset.NewFrom(c.breakers.Slice(), true)
+	}
 	return newClient
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/gogf/gf/v2/net/gsvc"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // SetBrowserMode enables browser mode of the client.
@@ -279,3 +280,49 @@ func (c *Client) SetTransport(transport http.RoundTripper) *Client {
 	c.Transport = transport
 	return c
 }
+
+// SetConfigWithMap sets the client configuration with map, which is usually from the configuration node.
+//
+// The supported keys are "prefix", "timeout", "agent", "header", "retry", "retryInterval", "proxy" and "oauth2".
+// The "oauth2" node is parsed by OAuth2ConfigFromMap, which adds the OAuth2 middleware to the client.
+func (c *Client) SetConfigWithMap(m map[string]any) error {
+	var config struct {
+		Prefix        string
+		Timeout       time.Duration
+		Agent         string
+		Header        map[string]string
+		Retry         int
+		RetryInterval time.Duration
+		Proxy         string
+		OAuth2        map[string]any
+	}
+	if err := gconv.Struct(m, &config); err != nil {
+		return gerror.Wrap(err, `invalid client configuration`)
+	}
+	if config.Prefix != "" {
+		c.SetPrefix(config.Prefix)
+	}
+	if config.Timeout > 0 {
+		c.SetTimeout(config.Timeout)
+	}
+	if config.Agent != "" {
+		c.SetAgent(config.Agent)
+	}
+	if len(config.Header) > 0 {
+		c.SetHeaderMap(config.Header)
+	}
+	if config.Retry > 0 {
+		c.SetRetry(config.Retry, config.RetryInterval)
+	}
+	if config.Proxy != "" {
+		c.SetProxy(config.Proxy)
+	}
+	if len(config.OAuth2) > 0 {
+		oauth2Config, err := OAuth2ConfigFromMap(config.OAuth2)
+		if err != nil {
+			return err
+		}
+		c.Use(MiddlewareOAuth2(oauth2Config))
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/net/gsel"
 	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/os/gctx"
 )
 
 type discoveryNode struct {
@@ -36,6 +37,9 @@ func (n *discoveryNode) Address() string {
 // service prefix to its selector map cache.
 var clientSelectorMap = gmap.New(true)
 
+// ctxKeyDiscoveryService is the context key for the service name of request picked by service discovery.
+const ctxKeyDiscoveryService gctx.StrKey = "GClientDiscoveryService"
+
 // internalMiddlewareDiscovery is a client middleware that enables service discovery feature for client.
 func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response, err error) {
 	if c.discovery == nil {
@@ -71,7 +75,8 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 		selectorMapKey   = service.GetPrefix()
 		selectorMapValue = clientSelectorMap.GetOrSetFuncLock(selectorMapKey, func() any {
 			intlog.Printf(ctx, `http client create selector for service "%s"`, selectorMapKey)
-			selector := c.builder.Build()
+			// The nodes of which circuit breakers of the client are open are skipped.
+			selector := gsel.NewSelectorWithFilter(c.builder.Build(), breakerNodeFilter)
 			// Update selector nodes.
 			if err = updateSelectorNodesByService(ctx, selector, service); err != nil {
 				return nil
@@ -82,15 +87,23 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 	if err != nil {
 		return nil, err
 	}
-	selector := selectorMapValue.(gsel.Selector)
+	var (
+		selector = selectorMapValue.(gsel.Selector)
+		pickCtx  = ctx
+	)
+	if c.breakers != nil && c.breakers.Size() > 0 {
+		// The selector is shared by clients, so the breakers of current client are passed for node filtering.
+		pickCtx = context.WithValue(ctx, ctxKeyBreakers, c.breakers.Slice())
+	}
 	// Pick one node from multiple addresses.
-	node, done, err := selector.Pick(ctx)
+	node, done, err := selector.Pick(pickCtx)
 	if err != nil {
 		return nil, err
 	}
 	if done != nil {
 		defer done(ctx, gsel.DoneInfo{})
 	}
+	r = r.WithContext(context.WithValue(r.Context(), ctxKeyDiscoveryService, service.GetName()))
 	r.Host = node.Address()
 	r.URL.Host = node.Address()
 	return c.Next(r)
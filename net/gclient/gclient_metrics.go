@@ -23,6 +23,7 @@ type localMetricManager struct {
 	HttpClientConnectionDuration   gmetric.Histogram
 	HttpClientRequestBodySize      gmetric.Counter
 	HttpClientResponseBodySize     gmetric.Counter
+	HttpClientBreakerState         gmetric.ObservableGauge
 }
 
 const (
@@ -32,6 +33,7 @@ const (
 	metricAttrKeyHttpRequestMethod      = "http.request.method"
 	metricAttrKeyHttpResponseStatusCode = "http.response.status_code"
 	metricAttrKeyNetworkProtocolVersion = "network.protocol.version"
+	metricAttrKeyBreakerKey             = "breaker.key"
 )
 
 var (
@@ -122,6 +124,15 @@ func newMetricManager() *localMetricManager {
 				Buckets:    durationBuckets,
 			},
 		),
+		HttpClientBreakerState: meter.MustObservableGauge(
+			"http.client.breaker.state",
+			gmetric.MetricOption{
+				Help:       "Current state of circuit breakers, 0 for closed, 1 for open and 2 for half-open.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+				Callback:   observeBreakerState,
+			},
+		),
 	}
 	return mm
 }
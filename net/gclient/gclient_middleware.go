@@ -48,3 +48,18 @@ func (m *clientMiddleware) Next(req *http.Request) (resp *Response, err error) {
 	}
 	return m.resp, m.err
 }
+
+// nextFunc returns a function that calls the rest middlewares and sends the request from current
+// position of middleware chain, which can be called multiple times, eg: for retrying.
+// This should only be call in HandlerFunc.
+func (c *Client) nextFunc(r *http.Request) func(req *http.Request) (*Response, error) {
+	m, ok := r.Context().Value(clientMiddlewareKey).(*clientMiddleware)
+	if !ok {
+		return c.callRequest
+	}
+	index := m.handlerIndex
+	return func(req *http.Request) (*Response, error) {
+		m.handlerIndex, m.resp, m.err = index, nil, nil
+		return m.Next(req)
+	}
+}
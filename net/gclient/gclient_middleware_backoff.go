@@ -0,0 +1,164 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+const (
+	defaultBackoffMaxRetries   = 3
+	defaultBackoffBaseInterval = 100 * time.Millisecond
+	defaultBackoffMaxInterval  = 10 * time.Second
+	defaultBackoffMultiplier   = 2
+	defaultBackoffJitter       = 0.2
+)
+
+// BackoffOption is the option for backoff retrying middleware.
+type BackoffOption struct {
+	// MaxRetries is the maximum retry count, it is 3 in default.
+	MaxRetries int
+
+	// BaseInterval is the interval before the first retry, it is 100 milliseconds in default.
+	BaseInterval time.Duration
+
+	// MaxInterval is the maximum interval between retries, it is 10 seconds in default.
+	// The interval of "Retry-After" header is also limited by it.
+	MaxInterval time.Duration
+
+	// Multiplier is the growth factor of interval for each retry, it is 2 in default.
+	Multiplier float64
+
+	// Jitter is the random factor in [0, 1] applied to intervals, it is 0.2 in default,
+	// which means the interval is randomized in [interval*0.8, interval*1.2].
+	Jitter float64
+
+	// ShouldRetry checks whether the request should be retried.
+	// In default, requests failing in transport or responded with status 429, 502, 503 and 504 are retried.
+	ShouldRetry func(resp *Response, err error) bool
+}
+
+// MiddlewareBackoff creates and returns a client middleware retrying failed requests
+// with exponential backoff and jitter. It honors the "Retry-After" header of responses,
+// which is either seconds or HTTP date.
+//
+// Note that all requests are retried regardless of their methods, use ShouldRetry of `option`
+// to retry only idempotent requests if necessary.
+func MiddlewareBackoff(option BackoffOption) HandlerFunc {
+	if option.MaxRetries <= 0 {
+		option.MaxRetries = defaultBackoffMaxRetries
+	}
+	if option.BaseInterval <= 0 {
+		option.BaseInterval = defaultBackoffBaseInterval
+	}
+	if option.MaxInterval <= 0 {
+		option.MaxInterval = defaultBackoffMaxInterval
+	}
+	if option.Multiplier < 1 {
+		option.Multiplier = defaultBackoffMultiplier
+	}
+	if option.Jitter <= 0 || option.Jitter > 1 {
+		option.Jitter = defaultBackoffJitter
+	}
+	if option.ShouldRetry == nil {
+		option.ShouldRetry = shouldRetryResponse
+	}
+	return func(c *Client, r *http.Request) (resp *Response, err error) {
+		var (
+			ctx  = r.Context()
+			next = c.nextFunc(r)
+			body []byte
+		)
+		// The request body is consumed in sending, it is cached for retrying
+		// if it cannot be got again by GetBody.
+		if r.Body != nil && r.GetBody == nil {
+			if body, err = io.ReadAll(r.Body); err != nil {
+				return nil, err
+			}
+		}
+		for retry := 0; ; retry++ {
+			switch {
+			case r.GetBody == nil:
+				r.Body = utils.NewReadCloser(body, false)
+			case retry > 0:
+				if r.Body, err = r.GetBody(); err != nil {
+					return nil, err
+				}
+			}
+			resp, err = next(r)
+			if retry >= option.MaxRetries || !option.ShouldRetry(resp, err) {
+				return resp, err
+			}
+			interval := option.interval(retry, resp)
+			if resp != nil && resp.Response != nil {
+				_ = resp.Close()
+			}
+			intlog.Printf(ctx, `retry request "%s %s" after %s`, r.Method, r.URL.String(), interval)
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// interval calculates the interval before the retry of `retry` times.
+func (o *BackoffOption) interval(retry int, resp *Response) time.Duration {
+	if resp != nil && resp.Response != nil {
+		if interval, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(interval, o.MaxInterval)
+		}
+	}
+	interval := float64(o.BaseInterval) * math.Pow(o.Multiplier, float64(retry))
+	interval = min(interval, float64(o.MaxInterval))
+	// Randomizes the interval in [interval*(1-jitter), interval*(1+jitter)].
+	interval *= 1 - o.Jitter + 2*o.Jitter*float64(grand.N(0, 1000))/1000
+	return time.Duration(interval)
+}
+
+// parseRetryAfter parses the value of "Retry-After" header, which is either seconds or HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// shouldRetryResponse is the default retry checking function.
+func shouldRetryResponse(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp == nil || resp.Response == nil {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
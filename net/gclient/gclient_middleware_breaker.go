@@ -0,0 +1,439 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+// BreakerState is the state of circuit breaker.
+type BreakerState int
+
+const (
+	BreakerStateClosed   BreakerState = iota // Requests are allowed, and their results are counted.
+	BreakerStateOpen                         // Requests are rejected until the open timeout elapses.
+	BreakerStateHalfOpen                     // Limited probe requests are allowed to check the recovery.
+)
+
+// String returns the name of state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerStateOpen:
+		return "open"
+	case BreakerStateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+const (
+	defaultBreakerWindow        = 10 * time.Second
+	defaultBreakerMinRequests   = 20
+	defaultBreakerFailureRate   = 0.5
+	defaultBreakerSlowRate      = 0.5
+	defaultBreakerOpenTimeout   = 5 * time.Second
+	defaultBreakerProbeRequests = 1
+	defaultBreakerIdleTimeout   = 10 * time.Minute
+	breakerWindowBuckets        = 10
+)
+
+// BreakerOption is the option for circuit breaker.
+type BreakerOption struct {
+	// Key returns the key of the request, requests of different keys are counted by separate breakers.
+	// It is BreakerKeyHost in default, which is the node address if service discovery is enabled.
+	// Use BreakerKeyService for breaking by service.
+	Key func(r *http.Request) string
+
+	// Window is the time window counting the results of requests, it is 10 seconds in default.
+	Window time.Duration
+
+	// MinRequests is the minimum request number in window before the breaker can be opened,
+	// it is 20 in default.
+	MinRequests int
+
+	// FailureRate is the failure rate threshold in window opening the breaker, it is 0.5 in default.
+	FailureRate float64
+
+	// SlowDuration is the duration threshold of slow requests. The latency threshold is disabled if it's 0.
+	SlowDuration time.Duration
+
+	// SlowRate is the slow request rate threshold in window opening the breaker, it is 0.5 in default.
+	SlowRate float64
+
+	// OpenTimeout is the duration of open state before the breaker turns to half-open,
+	// it is 5 seconds in default.
+	OpenTimeout time.Duration
+
+	// ProbeRequests is the number of probe requests allowed in half-open state, it is 1 in default.
+	// The breaker is closed if all probe requests succeed, or else it is opened again.
+	ProbeRequests int
+
+	// IsFailure checks whether the request fails.
+	// In default, requests failing in transport or responded with status 5xx are failures.
+	IsFailure func(resp *Response, err error) bool
+
+	// IdleTimeout is the duration after which the breaker state of a key without requests is removed,
+	// it is 10 minutes in default. The state is not removed while it rejects requests.
+	IdleTimeout time.Duration
+}
+
+// Breaker is the circuit breaker stopping requests to failing dependencies.
+// Create it using NewBreaker, and use its Middleware as client middleware.
+//
+// Breakers also feed back into node selection of service discovery of the clients using them,
+// the nodes of which breakers are open are skipped in picking.
+type Breaker struct {
+	option   BreakerOption
+	circuits *gmap.StrAnyMap // Key => *breakerCircuit.
+	sweptAt  *gtype.Int64    // Timestamp in nanoseconds of last removing idle circuits.
+}
+
+// breakerCircuit is the breaker state of a key.
+type breakerCircuit struct {
+	mu           sync.Mutex
+	option       *BreakerOption
+	usedAt       *gtype.Int64 // Timestamp in nanoseconds of last request.
+	state        BreakerState
+	openedAt     time.Time
+	probing      int // Probe requests in flight in half-open state.
+	probeSuccess int // Succeeded probe requests in half-open state.
+	buckets      [breakerWindowBuckets]breakerBucket
+}
+
+// breakerBucket counts the requests of a time slot in window.
+type breakerBucket struct {
+	slot     int64
+	total    int
+	failures int
+	slows    int
+}
+
+var (
+	// breakers are the breakers having circuits, which are used for metrics.
+	// The breaker is removed from it once all its circuits are removed as idle, or it is closed.
+	breakers = gset.New(true)
+)
+
+// ctxKeyBreakers is the context key for the breakers of client, which are used in node filtering.
+const ctxKeyBreakers gctx.StrKey = "GClientBreakers"
+
+// MiddlewareBreaker creates and returns a client middleware of circuit breaker with `option`.
+// See Breaker.
+func MiddlewareBreaker(option BreakerOption) HandlerFunc {
+	return NewBreaker(option).Middleware
+}
+
+// NewBreaker creates and returns a circuit breaker with `option`.
+func NewBreaker(option BreakerOption) *Breaker {
+	if option.Key == nil {
+		option.Key = BreakerKeyHost
+	}
+	if option.Window <= 0 {
+		option.Window = defaultBreakerWindow
+	}
+	if option.MinRequests <= 0 {
+		option.MinRequests = defaultBreakerMinRequests
+	}
+	if option.FailureRate <= 0 {
+		option.FailureRate = defaultBreakerFailureRate
+	}
+	if option.SlowRate <= 0 {
+		option.SlowRate = defaultBreakerSlowRate
+	}
+	if option.OpenTimeout <= 0 {
+		option.OpenTimeout = defaultBreakerOpenTimeout
+	}
+	if option.ProbeRequests <= 0 {
+		option.ProbeRequests = defaultBreakerProbeRequests
+	}
+	if option.IsFailure == nil {
+		option.IsFailure = isFailureResponse
+	}
+	if option.IdleTimeout <= 0 {
+		option.IdleTimeout = defaultBreakerIdleTimeout
+	}
+	return &Breaker{
+		option:   option,
+		circuits: gmap.NewStrAnyMap(true),
+		sweptAt:  gtype.NewInt64(time.Now().UnixNano()),
+	}
+}
+
+// BreakerKeyHost returns the host of request as breaker key.
+func BreakerKeyHost(r *http.Request) string {
+	return r.URL.Host
+}
+
+// BreakerKeyService returns the service name of request as breaker key if service discovery is enabled,
+// or else it returns the host of request.
+func BreakerKeyService(r *http.Request) string {
+	if name, ok := r.Context().Value(ctxKeyDiscoveryService).(string); ok && name != "" {
+		return name
+	}
+	return r.URL.Host
+}
+
+// State returns the current state of breaker for `key`.
+func (b *Breaker) State(key string) BreakerState {
+	if v := b.circuits.Get(key); v != nil {
+		circuit := v.(*breakerCircuit)
+		circuit.mu.Lock()
+		defer circuit.mu.Unlock()
+		return circuit.state
+	}
+	return BreakerStateClosed
+}
+
+// Close removes all the breaker states, and removes the breaker from metrics.
+// It should be called if the breaker is not used anymore, eg the client using it is dropped.
+func (b *Breaker) Close() {
+	b.circuits.LockFunc(func(m map[string]any) {
+		clear(m)
+		breakers.Remove(b)
+	})
+}
+
+// Middleware is the client middleware of the breaker.
+// It rejects the request with error of code gcode.CodeServerBusy if the breaker is open.
+func (b *Breaker) Middleware(c *Client, r *http.Request) (*Response, error) {
+	var (
+		now     = time.Now()
+		key     = b.option.Key(r)
+		circuit = b.circuits.GetOrSetFuncLock(key, func() any {
+			// It is called with the lock of circuits, which is consistent with removing idle circuits.
+			breakers.Add(b)
+			return &breakerCircuit{option: &b.option, usedAt: gtype.NewInt64()}
+		}).(*breakerCircuit)
+	)
+	if c.breakers != nil && !c.breakers.Contains(b) {
+		// The breaker is used in node filtering of service discovery for the client.
+		c.breakers.Add(b)
+	}
+	circuit.usedAt.Set(now.UnixNano())
+	b.removeIdle(now)
+	probe, ok := circuit.allow(now)
+	if !ok {
+		return nil, gerror.NewCodef(gcode.CodeServerBusy, `circuit breaker is open for "%s"`, key)
+	}
+	var (
+		startTime = time.Now()
+		finished  bool
+	)
+	defer func() {
+		if !finished {
+			// The request panics in the rest middlewares, which is counted as a failure,
+			// or else the probe slot in half-open state is never released.
+			circuit.record(time.Now(), probe, true, false)
+		}
+	}()
+	resp, err := c.Next(r)
+	finished = true
+	var (
+		duration = time.Since(startTime)
+		failure  = b.option.IsFailure(resp, err)
+		slow     = b.option.SlowDuration > 0 && duration >= b.option.SlowDuration
+	)
+	if state := circuit.record(time.Now(), probe, failure, slow); state != BreakerStateClosed {
+		intlog.Printf(r.Context(), `circuit breaker for "%s" turns to state "%s"`, key, state)
+	}
+	return resp, err
+}
+
+// available checks whether the breaker of `key` allows requests, without changing its state.
+func (b *Breaker) available(key string) bool {
+	if v := b.circuits.Get(key); v != nil {
+		return v.(*breakerCircuit).available(time.Now())
+	}
+	return true
+}
+
+// removeIdle removes the circuits of keys without requests for IdleTimeout, which is done once in IdleTimeout.
+// The breaker is removed from metrics if all its circuits are removed.
+func (b *Breaker) removeIdle(now time.Time) {
+	sweptAt := b.sweptAt.Val()
+	if now.UnixNano()-sweptAt < int64(b.option.IdleTimeout) || !b.sweptAt.Cas(sweptAt, now.UnixNano()) {
+		return
+	}
+	b.circuits.LockFunc(func(m map[string]any) {
+		for key, v := range m {
+			circuit := v.(*breakerCircuit)
+			if now.UnixNano()-circuit.usedAt.Val() >= int64(b.option.IdleTimeout) && circuit.available(now) {
+				delete(m, key)
+			}
+		}
+		if len(m) == 0 {
+			breakers.Remove(b)
+		}
+	})
+}
+
+// allow checks whether the request is allowed, it returns whether it is a probe request in half-open state.
+func (c *breakerCircuit) allow(now time.Time) (probe bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch c.state {
+	case BreakerStateOpen:
+		if now.Sub(c.openedAt) < c.option.OpenTimeout {
+			return false, false
+		}
+		c.state = BreakerStateHalfOpen
+		c.probing, c.probeSuccess = 0, 0
+		fallthrough
+
+	case BreakerStateHalfOpen:
+		if c.probing >= c.option.ProbeRequests {
+			return false, false
+		}
+		c.probing++
+		return true, true
+
+	default:
+		return false, true
+	}
+}
+
+// record counts the result of request and updates the state, it returns the state changed to,
+// or BreakerStateClosed if it's not changed.
+func (c *breakerCircuit) record(now time.Time, probe, failure, slow bool) BreakerState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if probe {
+		if c.state != BreakerStateHalfOpen {
+			return BreakerStateClosed
+		}
+		if failure || slow {
+			c.open(now)
+			return BreakerStateOpen
+		}
+		if c.probeSuccess++; c.probeSuccess >= c.option.ProbeRequests {
+			c.state = BreakerStateClosed
+			c.buckets = [breakerWindowBuckets]breakerBucket{}
+		}
+		return BreakerStateClosed
+	}
+	if c.state != BreakerStateClosed {
+		// The request was allowed before the state changed.
+		return BreakerStateClosed
+	}
+	var (
+		bucketDuration = c.option.Window / breakerWindowBuckets
+		slot           = now.UnixNano() / int64(max(bucketDuration, 1))
+		bucket         = &c.buckets[slot%breakerWindowBuckets]
+	)
+	if bucket.slot != slot {
+		*bucket = breakerBucket{slot: slot}
+	}
+	bucket.total++
+	if failure {
+		bucket.failures++
+	}
+	if slow {
+		bucket.slows++
+	}
+	var total, failures, slows int
+	for _, item := range c.buckets {
+		if slot-item.slot < breakerWindowBuckets {
+			total += item.total
+			failures += item.failures
+			slows += item.slows
+		}
+	}
+	if total < c.option.MinRequests {
+		return BreakerStateClosed
+	}
+	if float64(failures)/float64(total) >= c.option.FailureRate ||
+		(c.option.SlowDuration > 0 && float64(slows)/float64(total) >= c.option.SlowRate) {
+		c.open(now)
+		return BreakerStateOpen
+	}
+	return BreakerStateClosed
+}
+
+func (c *breakerCircuit) open(now time.Time) {
+	c.state = BreakerStateOpen
+	c.openedAt = now
+	c.buckets = [breakerWindowBuckets]breakerBucket{}
+}
+
+// available checks whether a request would be allowed.
+func (c *breakerCircuit) available(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch c.state {
+	case BreakerStateOpen:
+		return now.Sub(c.openedAt) >= c.option.OpenTimeout
+	case BreakerStateHalfOpen:
+		return c.probing < c.option.ProbeRequests
+	default:
+		return true
+	}
+}
+
+// breakerNodeFilter filters out the nodes of which breakers are open.
+// The breakers are the ones used by the client, which are passed by context,
+// so no node is filtered out for the clients without breakers.
+func breakerNodeFilter(ctx context.Context, node gsel.Node) bool {
+	clientBreakers, _ := ctx.Value(ctxKeyBreakers).([]any)
+	if len(clientBreakers) == 0 {
+		return true
+	}
+	now := time.Now()
+	for _, v := range clientBreakers {
+		b := v.(*Breaker)
+		b.removeIdle(now)
+		if !b.available(node.Address()) {
+			return false
+		}
+	}
+	return true
+}
+
+// isFailureResponse is the default failure checking function,
+// which treats transport errors and status 5xx as failures.
+func isFailureResponse(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.Response != nil && resp.StatusCode >= http.StatusInternalServerError
+}
+
+// observeBreakerState is the metric callback observing states of all breakers.
+func observeBreakerState(ctx context.Context, obs gmetric.MetricObserver) error {
+	now := time.Now()
+	for _, v := range breakers.Slice() {
+		b := v.(*Breaker)
+		// The breakers no longer used are removed here, as their middlewares are not called anymore.
+		b.removeIdle(now)
+		b.circuits.Iterator(func(key string, value any) bool {
+			circuit := value.(*breakerCircuit)
+			circuit.mu.Lock()
+			state := circuit.state
+			circuit.mu.Unlock()
+			obs.Observe(float64(state), gmetric.Option{
+				Attributes: gmetric.Attributes{
+					gmetric.NewAttribute(metricAttrKeyBreakerKey, key),
+				},
+			})
+			return true
+		})
+	}
+	return nil
+}
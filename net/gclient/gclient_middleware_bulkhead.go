@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// BulkheadOption is the option for bulkhead middleware.
+type BulkheadOption struct {
+	// Key returns the key of the request, requests of different keys are limited separately.
+	// It is BreakerKeyHost in default.
+	Key func(r *http.Request) string
+
+	// MaxConcurrency is the maximum number of concurrent requests of each key.
+	MaxConcurrency int
+
+	// MaxWait is the maximum duration waiting for an available slot.
+	// The request is rejected immediately if no slot is available and it's 0.
+	MaxWait time.Duration
+}
+
+// MiddlewareBulkhead creates and returns a client middleware limiting the concurrent requests of each key,
+// which isolates dependencies so that a slow dependency does not exhaust all resources of the client.
+// The request exceeding the limit is rejected with error of code gcode.CodeServerBusy.
+// The concurrency is not limited if MaxConcurrency of `option` is not positive.
+func MiddlewareBulkhead(option BulkheadOption) HandlerFunc {
+	if option.Key == nil {
+		option.Key = BreakerKeyHost
+	}
+	var semaphores = gmap.NewStrAnyMap(true)
+	return func(c *Client, r *http.Request) (*Response, error) {
+		if option.MaxConcurrency <= 0 {
+			return c.Next(r)
+		}
+		var (
+			key       = option.Key(r)
+			semaphore = semaphores.GetOrSetFuncLock(key, func() any {
+				return make(chan struct{}, option.MaxConcurrency)
+			}).(chan struct{})
+		)
+		select {
+		case semaphore <- struct{}{}:
+		default:
+			if option.MaxWait <= 0 {
+				return nil, gerror.NewCodef(gcode.CodeServerBusy, `bulkhead is full for "%s"`, key)
+			}
+			timer := time.NewTimer(option.MaxWait)
+			defer timer.Stop()
+			select {
+			case semaphore <- struct{}{}:
+			case <-timer.C:
+				return nil, gerror.NewCodef(gcode.CodeServerBusy, `bulkhead is full for "%s"`, key)
+			case <-r.Context().Done():
+				return nil, gerror.WrapCode(gcode.CodeServerBusy, r.Context().Err(), `waiting for bulkhead canceled`)
+			}
+		}
+		defer func() { <-semaphore }()
+		return c.Next(r)
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gmd5"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+const (
+	defaultCacheKeyPrefix = "gclient:cache:"
+	defaultCacheDuration  = time.Hour
+)
+
+// CacheOption is the option for response cache middleware.
+type CacheOption struct {
+	// Adapter is the storage of cached responses, it is a memory adapter in default.
+	Adapter gcache.Adapter
+
+	// Key returns the cache key of the request. In default, it consists of the request method and url,
+	// and the digest of "Authorization" and "Cookie" headers if they present.
+	Key func(r *http.Request) string
+
+	// Duration is the storage duration of entries that have validators, which keeps stale entries
+	// in adapter for revalidation, it is 1 hour in default.
+	// The freshness lifetime is used if it is longer.
+	Duration time.Duration
+
+	// DefaultTTL is the freshness lifetime of responses without any freshness information or validators,
+	// these responses are not cached if it's 0.
+	DefaultTTL time.Duration
+}
+
+// cacheEntry is the cached response, which is stored in adapter as JSON.
+type cacheEntry struct {
+	StatusCode int               `json:"statusCode"`
+	Header     http.Header       `json:"header"`
+	Body       []byte            `json:"body"`
+	Vary       map[string]string `json:"vary,omitempty"` // Request header values varying the response.
+	Expires    int64             `json:"expires"`        // Freshness expiration in unix milliseconds.
+}
+
+// cacheCall is an in-flight request that identical requests wait for.
+type cacheCall struct {
+	wg    sync.WaitGroup
+	entry *cacheEntry
+	err   error
+}
+
+// responseCache implements the cache middleware.
+type responseCache struct {
+	option CacheOption
+	mu     sync.Mutex
+	calls  map[string]*cacheCall // Key => in-flight request.
+}
+
+// MiddlewareCache creates and returns a client middleware caching responses of GET requests.
+//
+// It honors the "Cache-Control", "Expires", "ETag" and "Last-Modified" headers of responses as a private cache:
+// fresh responses are served from the cache, and stale responses are revalidated using conditional requests.
+// Concurrent identical GET requests are also coalesced into one upstream request.
+//
+// Note that the response bodies of GET requests are read into memory.
+func MiddlewareCache(option ...CacheOption) HandlerFunc {
+	c := &responseCache{
+		calls: make(map[string]*cacheCall),
+	}
+	if len(option) > 0 {
+		c.option = option[0]
+	}
+	if c.option.Adapter == nil {
+		c.option.Adapter = gcache.NewAdapterMemory()
+	}
+	if c.option.Key == nil {
+		c.option.Key = cacheKeyDefault
+	}
+	if c.option.Duration <= 0 {
+		c.option.Duration = defaultCacheDuration
+	}
+	return c.Middleware
+}
+
+// cacheKeyDefault is the default cache key function.
+func cacheKeyDefault(r *http.Request) string {
+	key := r.Method + " " + r.URL.String()
+	if credential := r.Header.Get("Authorization") + r.Header.Get("Cookie"); credential != "" {
+		key += " " + gmd5.MustEncryptString(credential)
+	}
+	return key
+}
+
+// Middleware is the client middleware handler.
+func (c *responseCache) Middleware(client *Client, r *http.Request) (*Response, error) {
+	if r.Method != http.MethodGet ||
+		r.Header.Get("Range") != "" ||
+		r.Header.Get("If-None-Match") != "" ||
+		r.Header.Get("If-Modified-Since") != "" {
+		return client.Next(r)
+	}
+	var (
+		ctx           = r.Context()
+		key           = c.option.Key(r)
+		requestPolicy = parseCacheControl(r.Header.Get("Cache-Control"))
+	)
+	if _, ok := requestPolicy["no-store"]; ok {
+		return client.Next(r)
+	}
+	entry := c.get(ctx, key, r)
+	if entry != nil {
+		_, noCache := requestPolicy["no-cache"]
+		if !noCache && entry.Expires > time.Now().UnixMilli() {
+			return entry.response(r), nil
+		}
+	}
+	// Identical requests are coalesced into one upstream request.
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		call.wg.Wait()
+		if call.err != nil {
+			return nil, call.err
+		}
+		// The response varies on request headers that differ from the in-flight one,
+		// so it does its own upstream request.
+		if !call.entry.match(r) {
+			return c.fetchResponse(client, r, key, entry)
+		}
+		return call.entry.response(r), nil
+	}
+	call := &cacheCall{}
+	call.wg.Add(1)
+	c.calls[key] = call
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	call.entry, call.err = c.fetch(client, r, key, entry)
+	if call.err != nil {
+		return nil, call.err
+	}
+	return call.entry.response(r), nil
+}
+
+// fetchResponse sends the request upstream without coalescing and returns the response.
+func (c *responseCache) fetchResponse(client *Client, r *http.Request, key string, stale *cacheEntry) (*Response, error) {
+	entry, err := c.fetch(client, r, key, stale)
+	if err != nil {
+		return nil, err
+	}
+	return entry.response(r), nil
+}
+
+// fetch sends the request upstream, revalidating the stale `entry` if it has validators.
+// It returns the response as entry, which is cached if it is cacheable.
+func (c *responseCache) fetch(client *Client, r *http.Request, key string, stale *cacheEntry) (*cacheEntry, error) {
+	var ctx = r.Context()
+	if stale != nil {
+		if etag := stale.Header.Get("ETag"); etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := stale.Header.Get("Last-Modified"); lastModified != "" {
+			r.Header.Set("If-Modified-Since", lastModified)
+		}
+		// The conditional headers are added by the middleware, which are removed on every path
+		// as the request did not have them.
+		defer func() {
+			r.Header.Del("If-None-Match")
+			r.Header.Del("If-Modified-Since")
+		}()
+	}
+	resp, err := client.Next(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	if stale != nil {
+		if resp.StatusCode == http.StatusNotModified {
+			// Updates the stored headers with the ones of the 304 response.
+			for k, v := range resp.Header {
+				stale.Header[k] = v
+			}
+			c.set(ctx, key, stale)
+			return stale, nil
+		}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	entry := &cacheEntry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+	}
+	for _, name := range strings.Split(resp.Header.Get("Vary"), ",") {
+		if name = strings.TrimSpace(name); name != "" && name != "*" {
+			if entry.Vary == nil {
+				entry.Vary = make(map[string]string)
+			}
+			entry.Vary[name] = r.Header.Get(name)
+		}
+	}
+	if resp.StatusCode == http.StatusOK {
+		c.set(ctx, key, entry)
+	}
+	return entry, nil
+}
+
+// get retrieves the cached entry matching the request `r`.
+func (c *responseCache) get(ctx context.Context, key string, r *http.Request) *cacheEntry {
+	v, err := c.option.Adapter.Get(ctx, defaultCacheKeyPrefix+key)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return nil
+	}
+	if v.IsNil() {
+		return nil
+	}
+	var entry *cacheEntry
+	if err = gjson.Unmarshal(v.Bytes(), &entry); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return nil
+	}
+	if !entry.match(r) {
+		return nil
+	}
+	return entry
+}
+
+// set stores the entry if it is cacheable, it also calculates the freshness of the entry.
+func (c *responseCache) set(ctx context.Context, key string, entry *cacheEntry) {
+	var (
+		policy           = parseCacheControl(entry.Header.Get("Cache-Control"))
+		hasValidator     = entry.Header.Get("ETag") != "" || entry.Header.Get("Last-Modified") != ""
+		freshness        time.Duration
+		hasFreshnessInfo = true
+	)
+	if _, ok := policy["no-store"]; ok {
+		return
+	}
+	if strings.TrimSpace(entry.Header.Get("Vary")) == "*" {
+		return
+	}
+	if _, ok := policy["no-cache"]; ok {
+		freshness = 0
+	} else if maxAge, ok := policy["max-age"]; ok {
+		seconds, _ := strconv.Atoi(maxAge)
+		freshness = time.Duration(seconds) * time.Second
+		if age, err := strconv.Atoi(entry.Header.Get("Age")); err == nil {
+			freshness -= time.Duration(age) * time.Second
+		}
+	} else if expires := entry.Header.Get("Expires"); expires != "" {
+		expiresTime, _ := http.ParseTime(expires)
+		date, err := http.ParseTime(entry.Header.Get("Date"))
+		if err != nil {
+			date = time.Now()
+		}
+		freshness = expiresTime.Sub(date)
+	} else {
+		hasFreshnessInfo = false
+		freshness = c.option.DefaultTTL
+	}
+	if !hasValidator && (freshness <= 0 || (!hasFreshnessInfo && c.option.DefaultTTL <= 0)) {
+		return
+	}
+	freshness = max(freshness, 0)
+	entry.Expires = time.Now().Add(freshness).UnixMilli()
+	duration := freshness
+	if hasValidator {
+		duration = max(duration, c.option.Duration)
+	}
+	content, err := gjson.Marshal(entry)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return
+	}
+	if err = c.option.Adapter.Set(ctx, defaultCacheKeyPrefix+key, content, duration); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// match checks whether the request `r` has the same values of headers that the entry varies on.
+func (e *cacheEntry) match(r *http.Request) bool {
+	for name, value := range e.Vary {
+		if r.Header.Get(name) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// response creates and returns a new Response for request `r` from the entry.
+func (e *cacheEntry) response(r *http.Request) *Response {
+	return &Response{
+		Response: &http.Response{
+			Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+			StatusCode:    e.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        e.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(e.Body)),
+			ContentLength: int64(len(e.Body)),
+			Request:       r,
+		},
+		request: r,
+	}
+}
+
+// parseCacheControl parses the "Cache-Control" header into directive map.
+func parseCacheControl(value string) map[string]string {
+	var directives = make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(item, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), `"`)
+	}
+	return directives
+}
@@ -0,0 +1,329 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gmd5"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// OAuth2 grant types.
+const (
+	OAuth2GrantClientCredentials = "client_credentials"
+	OAuth2GrantRefreshToken      = "refresh_token"
+	OAuth2GrantJwtBearer         = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+)
+
+// OAuth2 client authentication styles in token requests.
+const (
+	OAuth2AuthStyleHeader = "header" // Client credentials are sent in HTTP basic authentication header.
+	OAuth2AuthStyleParams = "params" // Client credentials are sent in form parameters.
+)
+
+const (
+	defaultOAuth2ExpiryDelta  = 10 * time.Second
+	defaultOAuth2TokenType    = "Bearer"
+	oauth2CacheKeyPrefix      = "gclient:oauth2:"
+	oauth2HeaderAuthorization = "Authorization"
+)
+
+// OAuth2Config is the configuration for OAuth2 middleware.
+type OAuth2Config struct {
+	// TokenURL is the url of token endpoint.
+	TokenURL string `json:"tokenUrl"`
+
+	// GrantType is the grant type of token requests, it is OAuth2GrantClientCredentials in default.
+	GrantType string `json:"grantType"`
+
+	// ClientID and ClientSecret are the client credentials.
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+
+	// AuthStyle is the style sending client credentials, it is OAuth2AuthStyleHeader in default.
+	AuthStyle string `json:"authStyle"`
+
+	// Scopes are the requested scopes.
+	Scopes []string `json:"scopes"`
+
+	// Params are the additional form parameters of token requests, like "audience".
+	Params map[string]string `json:"params"`
+
+	// RefreshToken is the refresh token for OAuth2GrantRefreshToken.
+	RefreshToken string `json:"refreshToken"`
+
+	// Assertion is the signed JWT for OAuth2GrantJwtBearer.
+	Assertion string `json:"assertion"`
+
+	// AssertionFunc creates the signed JWT for each token request of OAuth2GrantJwtBearer,
+	// which takes priority over Assertion.
+	AssertionFunc func(ctx context.Context) (string, error) `json:"-"`
+
+	// ExpiryDelta is the duration before expiry that tokens are refreshed, it is 10 seconds in default.
+	ExpiryDelta time.Duration `json:"expiryDelta"`
+
+	// CacheKey is the cache key of token, it is generated from the configuration in default.
+	CacheKey string `json:"cacheKey"`
+
+	// Cache is the storage of tokens, it is a memory adapter in default.
+	Cache gcache.Adapter `json:"-"`
+
+	// Client is the client sending token requests, it is a new client in default.
+	Client *Client `json:"-"`
+}
+
+// OAuth2Token is the token responded by token endpoint.
+type OAuth2Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
+	Expiry       int64  `json:"expiry,omitempty"` // Expiry time in unix milliseconds, it is 0 if the token never expires.
+}
+
+// OAuth2 obtains OAuth2 access tokens and authorizes requests with them.
+// Create it using NewOAuth2, and use its Middleware as client middleware.
+type OAuth2 struct {
+	config       OAuth2Config
+	mu           sync.Mutex // Ensures only one token request at the same time.
+	refreshToken string     // Latest refresh token, which might be rotated by the token endpoint.
+}
+
+// oauth2ErrorResponse is the error response of token endpoint.
+type oauth2ErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// OAuth2ConfigFromMap parses and returns OAuth2 configuration from map, which is usually from the configuration node.
+//
+// Example configuration:
+//
+//	tokenUrl:     "https://auth.example.com/oauth/token"
+//	clientId:     "id"
+//	clientSecret: "secret"
+//	scopes:       ["read", "write"]
+//	expiryDelta:  "30s"
+func OAuth2ConfigFromMap(m map[string]any) (config OAuth2Config, err error) {
+	if err = gconv.Struct(m, &config); err != nil {
+		return config, gerror.Wrap(err, `invalid OAuth2 configuration`)
+	}
+	return config, nil
+}
+
+// MiddlewareOAuth2 creates and returns a client middleware authorizing requests with OAuth2 access tokens.
+// See OAuth2.
+func MiddlewareOAuth2(config OAuth2Config) HandlerFunc {
+	return NewOAuth2(config).Middleware
+}
+
+// NewOAuth2 creates and returns an OAuth2 with `config`.
+func NewOAuth2(config OAuth2Config) *OAuth2 {
+	if config.GrantType == "" {
+		config.GrantType = OAuth2GrantClientCredentials
+	}
+	if config.AuthStyle == "" {
+		config.AuthStyle = OAuth2AuthStyleHeader
+	}
+	if config.ExpiryDelta <= 0 {
+		config.ExpiryDelta = defaultOAuth2ExpiryDelta
+	}
+	if config.Cache == nil {
+		config.Cache = gcache.NewAdapterMemory()
+	}
+	if config.Client == nil {
+		config.Client = New()
+	}
+	if config.CacheKey == "" {
+		config.CacheKey = gmd5.MustEncryptString(strings.Join([]string{
+			config.TokenURL, config.GrantType, config.ClientID, strings.Join(config.Scopes, " "),
+		}, "|"))
+	}
+	return &OAuth2{
+		config:       config,
+		refreshToken: config.RefreshToken,
+	}
+}
+
+// Middleware is the client middleware of OAuth2, which sets the "Authorization" header with access token.
+// The token is cached until shortly before its expiry, and the request is retried once with a new token
+// if it is responded with status 401.
+func (o *OAuth2) Middleware(c *Client, r *http.Request) (resp *Response, err error) {
+	var (
+		ctx  = r.Context()
+		next = c.nextFunc(r)
+		body []byte
+	)
+	token, err := o.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Body != nil {
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+	}
+	r.Body = utils.NewReadCloser(body, false)
+	r.Header.Set(oauth2HeaderAuthorization, token.authorization())
+	if resp, err = next(r); err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// The token might be revoked, it retries once with a new token.
+	intlog.Printf(ctx, `request "%s %s" unauthorized, retry with new token`, r.Method, r.URL.String())
+	_ = resp.Close()
+	if token, err = o.renew(ctx, token); err != nil {
+		return nil, err
+	}
+	r.Body = utils.NewReadCloser(body, false)
+	r.Header.Set(oauth2HeaderAuthorization, token.authorization())
+	return next(r)
+}
+
+// Token returns the cached token, or obtains a new token from token endpoint if it is absent or about to expire.
+func (o *OAuth2) Token(ctx context.Context) (*OAuth2Token, error) {
+	if token := o.cachedToken(ctx); token != nil {
+		return token, nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	// The token might be obtained by other goroutine when waiting for the lock.
+	if token := o.cachedToken(ctx); token != nil {
+		return token, nil
+	}
+	return o.fetch(ctx)
+}
+
+// renew obtains a new token if the cached token is still `expired`.
+func (o *OAuth2) renew(ctx context.Context, expired *OAuth2Token) (*OAuth2Token, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if token := o.cachedToken(ctx); token != nil && token.AccessToken != expired.AccessToken {
+		return token, nil
+	}
+	return o.fetch(ctx)
+}
+
+// cachedToken returns the cached token which is not about to expire.
+func (o *OAuth2) cachedToken(ctx context.Context) *OAuth2Token {
+	v, err := o.config.Cache.Get(ctx, oauth2CacheKeyPrefix+o.config.CacheKey)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return nil
+	}
+	if v.IsNil() {
+		return nil
+	}
+	var token *OAuth2Token
+	if err = gjson.Unmarshal(v.Bytes(), &token); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return nil
+	}
+	if token.Expiry > 0 && time.Now().Add(o.config.ExpiryDelta).UnixMilli() >= token.Expiry {
+		return nil
+	}
+	return token
+}
+
+// fetch obtains a new token from token endpoint and caches it.
+func (o *OAuth2) fetch(ctx context.Context) (*OAuth2Token, error) {
+	var (
+		params = url.Values{}
+		client = o.config.Client.ContentType("application/x-www-form-urlencoded")
+	)
+	params.Set("grant_type", o.config.GrantType)
+	if len(o.config.Scopes) > 0 {
+		params.Set("scope", strings.Join(o.config.Scopes, " "))
+	}
+	switch o.config.GrantType {
+	case OAuth2GrantRefreshToken:
+		params.Set("refresh_token", o.refreshToken)
+	case OAuth2GrantJwtBearer:
+		assertion := o.config.Assertion
+		if o.config.AssertionFunc != nil {
+			var err error
+			if assertion, err = o.config.AssertionFunc(ctx); err != nil {
+				return nil, err
+			}
+		}
+		params.Set("assertion", assertion)
+	}
+	for k, v := range o.config.Params {
+		params.Set(k, v)
+	}
+	if o.config.ClientID != "" {
+		if o.config.AuthStyle == OAuth2AuthStyleParams {
+			params.Set("client_id", o.config.ClientID)
+			if o.config.ClientSecret != "" {
+				params.Set("client_secret", o.config.ClientSecret)
+			}
+		} else {
+			client = client.BasicAuth(url.QueryEscape(o.config.ClientID), url.QueryEscape(o.config.ClientSecret))
+		}
+	}
+	resp, err := client.Post(ctx, o.config.TokenURL, params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	content := resp.ReadAll()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp *oauth2ErrorResponse
+		_ = gjson.Unmarshal(content, &errResp)
+		if errResp != nil && errResp.Error != "" {
+			return nil, gerror.NewCodef(
+				gcode.CodeNotAuthorized, `obtain OAuth2 token failed with status "%s": %s %s`,
+				resp.Status, errResp.Error, errResp.ErrorDescription,
+			)
+		}
+		return nil, gerror.NewCodef(
+			gcode.CodeNotAuthorized, `obtain OAuth2 token failed with status "%s"`, resp.Status,
+		)
+	}
+	var token *OAuth2Token
+	if err = gjson.Unmarshal(content, &token); err != nil {
+		return nil, gerror.Wrapf(err, `invalid OAuth2 token response: %s`, content)
+	}
+	if token == nil || token.AccessToken == "" {
+		return nil, gerror.NewCodef(gcode.CodeNotAuthorized, `invalid OAuth2 token response: %s`, content)
+	}
+	if token.RefreshToken != "" {
+		o.refreshToken = token.RefreshToken
+	}
+	var duration time.Duration
+	if token.ExpiresIn > 0 {
+		duration = time.Duration(token.ExpiresIn) * time.Second
+		token.Expiry = time.Now().Add(duration).UnixMilli()
+	}
+	if tokenContent, err := gjson.Marshal(token); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	} else if err = o.config.Cache.Set(ctx, oauth2CacheKeyPrefix+o.config.CacheKey, tokenContent, duration); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+	return token, nil
+}
+
+// authorization returns the value of "Authorization" header.
+func (t *OAuth2Token) authorization() string {
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, defaultOAuth2TokenType) {
+		tokenType = defaultOAuth2TokenType
+	}
+	return tokenType + " " + t.AccessToken
+}
@@ -124,6 +124,9 @@ func (c *Client) PostForm(ctx context.Context, url string, data map[string]strin
 // else it uses "application/x-www-form-urlencoded". It also automatically detects the post
 // content for JSON format, and for that it automatically sets the Content-Type as
 // "application/json".
+//
+// If the data is an io.Reader, it is sent as the request body as it is without being converted to
+// parameters, and its Content-Type is "application/octet-stream" if no custom Content-Type is set.
 func (c *Client) DoRequest(
 	ctx context.Context, method, url string, data ...any,
 ) (resp *Response, err error) {
@@ -172,7 +175,13 @@ func (c *Client) prepareRequest(ctx context.Context, method, url string, data ..
 	var (
 		params             string
 		allowFileUploading = true
+		bodyReader         io.Reader
 	)
+	if len(data) > 0 {
+		if reader, ok := data[0].(io.Reader); ok {
+			bodyReader, data = reader, nil
+		}
+	}
 	if len(data) > 0 {
 		mediaType, _, err := mime.ParseMediaType(c.header[httpHeaderContentType])
 		if err != nil {
@@ -210,7 +219,14 @@ func (c *Client) prepareRequest(ctx context.Context, method, url string, data ..
 			params = httputil.BuildParams(data[0], c.noUrlEncode)
 		}
 	}
-	if method == http.MethodGet {
+	if bodyReader != nil {
+		// The reader is sent as the request body as it is.
+		if req, err = http.NewRequest(method, url, bodyReader); err != nil {
+			err = gerror.Wrapf(err, `http.NewRequest failed for method "%s" and URL "%s"`, method, url)
+			return nil, err
+		}
+		req.Header.Set(httpHeaderContentType, httpHeaderContentTypeOctetStream)
+	} else if method == http.MethodGet {
 		var bodyBuffer *bytes.Buffer
 		if params != "" {
 			mediaType, _, err := mime.ParseMediaType(c.header[httpHeaderContentType])
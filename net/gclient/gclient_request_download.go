@@ -0,0 +1,444 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	defaultDownloadSegmentSize = 1024 * 1024
+	downloadTempFileExt        = ".download"
+	downloadMetaFileExt        = ".download.meta"
+	downloadMetaSavingInterval = time.Second
+	downloadBufferSize         = 32 * 1024
+	downloadFilePermission     = 0644
+)
+
+// DownloadOption is the option for file downloading.
+type DownloadOption struct {
+	// Concurrency is the number of segments downloaded in parallel, it is 1 in default.
+	// It takes effect only if the server supports Range requests.
+	Concurrency int
+
+	// SegmentSize is the minimum size of each segment, it is 1MB in default.
+	SegmentSize int64
+
+	// Checksum is the expected checksum of the file in format "algorithm:hex", like "sha256:9f86d0...".
+	// Algorithms md5, sha1, sha256 and sha512 are supported. The checksum is not verified if it's empty.
+	Checksum string
+
+	// Progress is called when the downloaded bytes change, `total` is -1 if the size is unknown.
+	Progress func(downloaded, total int64)
+}
+
+// downloadMeta is the state of a partial download, which is saved along with the partial file for resumption.
+type downloadMeta struct {
+	URL       string             `json:"url"`
+	Validator string             `json:"validator"` // ETag or Last-Modified of the resource.
+	Total     int64              `json:"total"`
+	Segments  []*downloadSegment `json:"segments"`
+}
+
+// downloadSegment is a byte range [Start, End] of the file.
+type downloadSegment struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	Done  int64 `json:"done"` // Downloaded bytes of the segment, accessed atomically.
+}
+
+// downloader implements the file downloading.
+type downloader struct {
+	client     *Client
+	url        string
+	path       string
+	tempPath   string
+	metaPath   string
+	option     DownloadOption
+	downloaded atomic.Int64
+	progressMu sync.Mutex
+}
+
+// Download downloads the file of `url` to `path`.
+//
+// The file is downloaded to a temporary file "<path>.download" at first, and then it is renamed to `path`
+// atomically after the download completes and the checksum is verified.
+// If the server supports Range requests, the file is downloaded in segments in parallel,
+// and an interrupted download is resumed from the partial file in next calling.
+// The timeout of client is disabled for downloading, use `ctx` to control it.
+func (c *Client) Download(ctx context.Context, url, path string, option ...DownloadOption) error {
+	d := &downloader{
+		client:   c.Timeout(0),
+		url:      url,
+		path:     path,
+		tempPath: path + downloadTempFileExt,
+		metaPath: path + downloadMetaFileExt,
+	}
+	if len(option) > 0 {
+		d.option = option[0]
+	}
+	if d.option.Concurrency <= 0 {
+		d.option.Concurrency = 1
+	}
+	if d.option.SegmentSize <= 0 {
+		d.option.SegmentSize = defaultDownloadSegmentSize
+	}
+	if d.option.Checksum != "" {
+		if _, _, err := parseChecksum(d.option.Checksum); err != nil {
+			return err
+		}
+	}
+	if err := gfile.Mkdir(gfile.Dir(path)); err != nil {
+		return err
+	}
+	return d.download(ctx)
+}
+
+func (d *downloader) download(ctx context.Context) error {
+	// Probes whether the server supports Range requests.
+	resp, err := d.client.Header(map[string]string{
+		"Range": "bytes=0-0",
+	}).Get(ctx, d.url)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Range requests are not supported, it downloads the whole file in the probe request.
+		defer resp.Close()
+		return d.downloadWhole(resp)
+
+	case http.StatusPartialContent:
+		_ = resp.Close()
+
+	default:
+		_ = resp.Close()
+		return gerror.NewCodef(gcode.CodeOperationFailed, `download "%s" failed with status "%s"`, d.url, resp.Status)
+	}
+	total, err := parseContentRangeTotal(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return err
+	}
+	validator := resp.Header.Get("ETag")
+	if validator == "" {
+		validator = resp.Header.Get("Last-Modified")
+	}
+	meta := d.loadMeta(ctx, validator, total)
+	if meta == nil {
+		meta = d.newMeta(validator, total)
+		_ = gfile.Remove(d.tempPath)
+	}
+	for _, segment := range meta.Segments {
+		d.downloaded.Add(segment.Done)
+	}
+	file, err := gfile.OpenFile(d.tempPath, os.O_CREATE|os.O_WRONLY, downloadFilePermission)
+	if err != nil {
+		return err
+	}
+	if err = file.Truncate(total); err != nil {
+		_ = file.Close()
+		return gerror.Wrapf(err, `truncate file "%s" failed`, d.tempPath)
+	}
+	err = d.downloadSegments(ctx, file, meta)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = gerror.Wrapf(closeErr, `close file "%s" failed`, d.tempPath)
+	}
+	if err != nil {
+		// The state is saved for resumption.
+		if saveErr := d.saveMeta(meta); saveErr != nil {
+			intlog.Errorf(ctx, `%+v`, saveErr)
+		}
+		return err
+	}
+	return d.complete()
+}
+
+// downloadWhole writes the whole response body to file, which is used if Range requests are not supported.
+func (d *downloader) downloadWhole(resp *Response) error {
+	_ = gfile.Remove(d.metaPath)
+	file, err := gfile.OpenFile(d.tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, downloadFilePermission)
+	if err != nil {
+		return err
+	}
+	var (
+		total  = resp.ContentLength
+		buffer = make([]byte, downloadBufferSize)
+	)
+	for {
+		n, readErr := resp.Body.Read(buffer)
+		if n > 0 {
+			if _, err = file.Write(buffer[:n]); err != nil {
+				_ = file.Close()
+				return gerror.Wrapf(err, `write file "%s" failed`, d.tempPath)
+			}
+			d.progress(int64(n), total)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			_ = file.Close()
+			return gerror.Wrapf(readErr, `download "%s" failed`, d.url)
+		}
+	}
+	if err = file.Close(); err != nil {
+		return gerror.Wrapf(err, `close file "%s" failed`, d.tempPath)
+	}
+	return d.complete()
+}
+
+// downloadSegments downloads the incomplete segments in parallel.
+func (d *downloader) downloadSegments(ctx context.Context, file *os.File, meta *downloadMeta) error {
+	var (
+		wg          sync.WaitGroup
+		errOnce     sync.Once
+		downloadErr error
+		done        = make(chan struct{})
+		saverDone   = make(chan struct{})
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Saves the state periodically, so that the download can be resumed even if the process exits.
+	go func() {
+		defer close(saverDone)
+		ticker := time.NewTicker(downloadMetaSavingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := d.saveMeta(meta); err != nil {
+					intlog.Errorf(ctx, `%+v`, err)
+				}
+			}
+		}
+	}()
+	for _, segment := range meta.Segments {
+		if segment.Start+atomic.LoadInt64(&segment.Done) > segment.End {
+			continue
+		}
+		wg.Add(1)
+		go func(segment *downloadSegment) {
+			defer wg.Done()
+			if err := d.downloadSegment(ctx, file, segment, meta); err != nil {
+				errOnce.Do(func() {
+					downloadErr = err
+					cancel()
+				})
+			}
+		}(segment)
+	}
+	wg.Wait()
+	close(done)
+	<-saverDone
+	return downloadErr
+}
+
+// downloadSegment downloads the rest bytes of `segment`.
+func (d *downloader) downloadSegment(
+	ctx context.Context, file *os.File, segment *downloadSegment, meta *downloadMeta,
+) error {
+	var (
+		offset  = segment.Start + atomic.LoadInt64(&segment.Done)
+		headers = map[string]string{
+			"Range": fmt.Sprintf("bytes=%d-%d", offset, segment.End),
+		}
+	)
+	if meta.Validator != "" && !strings.HasPrefix(meta.Validator, "W/") {
+		headers["If-Range"] = meta.Validator
+	}
+	resp, err := d.client.Header(headers).Get(ctx, d.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed,
+			`download "%s" failed with status "%s", the resource might be changed`, d.url, resp.Status,
+		)
+	}
+	var buffer = make([]byte, downloadBufferSize)
+	for offset <= segment.End {
+		n, readErr := resp.Body.Read(buffer)
+		if n > 0 {
+			n = int(min(int64(n), segment.End-offset+1))
+			if _, err = file.WriteAt(buffer[:n], offset); err != nil {
+				return gerror.Wrapf(err, `write file "%s" failed`, d.tempPath)
+			}
+			offset += int64(n)
+			atomic.AddInt64(&segment.Done, int64(n))
+			d.progress(int64(n), meta.Total)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return gerror.Wrapf(readErr, `download "%s" failed`, d.url)
+		}
+	}
+	if offset <= segment.End {
+		return gerror.NewCodef(gcode.CodeOperationFailed, `download "%s" failed: unexpected end of content`, d.url)
+	}
+	return nil
+}
+
+// complete verifies the checksum and renames the temporary file to the target path.
+func (d *downloader) complete() error {
+	if d.option.Checksum != "" {
+		if err := verifyFileChecksum(d.tempPath, d.option.Checksum); err != nil {
+			_ = gfile.Remove(d.tempPath)
+			_ = gfile.Remove(d.metaPath)
+			return err
+		}
+	}
+	if err := gfile.Rename(d.tempPath, d.path); err != nil {
+		return err
+	}
+	return gfile.Remove(d.metaPath)
+}
+
+func (d *downloader) progress(n, total int64) {
+	downloaded := d.downloaded.Add(n)
+	if d.option.Progress != nil {
+		d.progressMu.Lock()
+		d.option.Progress(downloaded, total)
+		d.progressMu.Unlock()
+	}
+}
+
+// newMeta creates the state dividing the file into segments.
+func (d *downloader) newMeta(validator string, total int64) *downloadMeta {
+	var (
+		count = int64(d.option.Concurrency)
+		meta  = &downloadMeta{
+			URL:       d.url,
+			Validator: validator,
+			Total:     total,
+		}
+	)
+	if maxCount := (total + d.option.SegmentSize - 1) / d.option.SegmentSize; count > maxCount {
+		count = max(maxCount, 1)
+	}
+	size := (total + count - 1) / count
+	for start := int64(0); start < total; start += size {
+		meta.Segments = append(meta.Segments, &downloadSegment{
+			Start: start,
+			End:   min(start+size, total) - 1,
+		})
+	}
+	return meta
+}
+
+// loadMeta loads the state of partial download, it returns nil if there's no valid state.
+func (d *downloader) loadMeta(ctx context.Context, validator string, total int64) *downloadMeta {
+	if !gfile.Exists(d.metaPath) || !gfile.Exists(d.tempPath) {
+		return nil
+	}
+	var meta *downloadMeta
+	if err := gjson.Unmarshal(gfile.GetBytes(d.metaPath), &meta); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return nil
+	}
+	if meta == nil || meta.URL != d.url || meta.Validator != validator || meta.Total != total {
+		return nil
+	}
+	return meta
+}
+
+// saveMeta saves the state of partial download.
+func (d *downloader) saveMeta(meta *downloadMeta) error {
+	var snapshot = *meta
+	snapshot.Segments = make([]*downloadSegment, len(meta.Segments))
+	for i, segment := range meta.Segments {
+		snapshot.Segments[i] = &downloadSegment{
+			Start: segment.Start,
+			End:   segment.End,
+			Done:  atomic.LoadInt64(&segment.Done),
+		}
+	}
+	content, err := gjson.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return gfile.PutBytes(d.metaPath, content)
+}
+
+// parseContentRangeTotal parses the total size from header "Content-Range", like "bytes 0-0/1024".
+func parseContentRangeTotal(contentRange string) (int64, error) {
+	_, totalStr, found := strings.Cut(strings.TrimPrefix(contentRange, "bytes "), "/")
+	if !found || totalStr == "*" {
+		return 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid Content-Range "%s"`, contentRange)
+	}
+	total, err := strconv.ParseInt(totalStr, 10, 64)
+	if err != nil {
+		return 0, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid Content-Range "%s"`, contentRange)
+	}
+	return total, nil
+}
+
+// parseChecksum parses checksum in format "algorithm:hex".
+func parseChecksum(checksum string) (h hash.Hash, expect string, err error) {
+	algorithm, expect, found := strings.Cut(checksum, ":")
+	if found {
+		switch strings.ToLower(algorithm) {
+		case "md5":
+			return md5.New(), strings.ToLower(expect), nil
+		case "sha1":
+			return sha1.New(), strings.ToLower(expect), nil
+		case "sha256":
+			return sha256.New(), strings.ToLower(expect), nil
+		case "sha512":
+			return sha512.New(), strings.ToLower(expect), nil
+		}
+	}
+	return nil, "", gerror.NewCodef(gcode.CodeInvalidParameter, `invalid checksum "%s"`, checksum)
+}
+
+// verifyFileChecksum verifies the checksum of file `path`.
+func verifyFileChecksum(path, checksum string) error {
+	h, expect, err := parseChecksum(checksum)
+	if err != nil {
+		return err
+	}
+	file, err := gfile.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = io.Copy(h, file); err != nil {
+		return gerror.Wrapf(err, `read file "%s" failed`, path)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expect {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed, `checksum mismatch, expect "%s" but got "%s"`, expect, actual,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	defaultUploadFieldName       = "file"
+	httpHeaderContentTypeOctet   = "application/octet-stream"
+	httpStatusResumeIncomplete   = 308
+	httpHeaderContentRange       = "Content-Range"
+	httpHeaderContentDisposition = "Content-Disposition"
+)
+
+// UploadOption is the option for file uploading.
+type UploadOption struct {
+	// Method is the request method, it is POST in default.
+	Method string
+
+	// FieldName is the form field name of the file, it is "file" in default.
+	// It is used only if the file is not uploaded in chunks.
+	FieldName string
+
+	// Data is the additional form fields sent along with the file.
+	// It is used only if the file is not uploaded in chunks.
+	Data map[string]any
+
+	// Progress is called when the uploaded bytes change.
+	Progress func(uploaded, total int64)
+
+	// ChunkSize enables chunked uploading if it is positive, which uploads the file in chunks of the size.
+	ChunkSize int64
+
+	// Offset is the offset of file that chunked uploading starts from, which is used for resuming
+	// an interrupted chunked uploading with the offset the server has received.
+	Offset int64
+
+	// ChunkHandler uploads a chunk, which implements the chunked upload protocol of server.
+	// In default, each chunk is sent with the "Content-Range: bytes <start>-<end>/<total>" header
+	// and the chunk content as request body, and the server should respond status 2xx or 308.
+	ChunkHandler func(ctx context.Context, c *Client, chunk *UploadChunk) (*Response, error)
+}
+
+// UploadChunk is a chunk of the file in chunked uploading.
+type UploadChunk struct {
+	Method   string // Request method.
+	URL      string // Request url.
+	FileName string // Base name of the file.
+	Index    int    // Index of the chunk, starting from 0.
+	Offset   int64  // Offset of the chunk in the file.
+	Total    int64  // Total size of the file.
+	Data     []byte // Content of the chunk.
+}
+
+// Upload uploads the file of `path` to `url`.
+//
+// The file is uploaded in a multipart form request in default, or in chunks if ChunkSize of `option` is positive.
+// For chunked uploading, it returns the response of the last chunk.
+func (c *Client) Upload(ctx context.Context, url, path string, option ...UploadOption) (*Response, error) {
+	var opt UploadOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Method == "" {
+		opt.Method = http.MethodPost
+	}
+	if opt.FieldName == "" {
+		opt.FieldName = defaultUploadFieldName
+	}
+	if opt.ChunkHandler == nil {
+		opt.ChunkHandler = uploadChunkDefault
+	}
+	if !gfile.Exists(path) {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `"%s" does not exist`, path)
+	}
+	if opt.ChunkSize > 0 {
+		return c.uploadChunks(ctx, url, path, opt)
+	}
+	var data = make(map[string]any, len(opt.Data)+1)
+	for k, v := range opt.Data {
+		data[k] = v
+	}
+	data[opt.FieldName] = httpParamFileHolder + path
+	client := c
+	if opt.Progress != nil {
+		client = c.Clone()
+		client.Transport = &uploadProgressTransport{
+			transport: c.Transport,
+			progress:  opt.Progress,
+		}
+	}
+	return client.DoRequest(ctx, opt.Method, url, data)
+}
+
+// uploadChunks uploads the file in chunks.
+func (c *Client) uploadChunks(ctx context.Context, url, path string, option UploadOption) (*Response, error) {
+	file, err := gfile.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, gerror.Wrapf(err, `stat file "%s" failed`, path)
+	}
+	var (
+		total  = stat.Size()
+		offset = option.Offset
+		buffer = make([]byte, option.ChunkSize)
+		resp   *Response
+	)
+	if offset < 0 || offset > total {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid offset %d for file size %d`, offset, total)
+	}
+	for index := int(offset / option.ChunkSize); offset < total || resp == nil; index++ {
+		n, err := file.ReadAt(buffer[:min(option.ChunkSize, total-offset)], offset)
+		if err != nil && err != io.EOF {
+			return nil, gerror.Wrapf(err, `read file "%s" failed`, path)
+		}
+		if resp != nil {
+			_ = resp.Close()
+		}
+		resp, err = option.ChunkHandler(ctx, c, &UploadChunk{
+			Method:   option.Method,
+			URL:      url,
+			FileName: gfile.Basename(path),
+			Index:    index,
+			Offset:   offset,
+			Total:    total,
+			Data:     buffer[:n],
+		})
+		if err != nil {
+			return nil, err
+		}
+		offset += int64(n)
+		if option.Progress != nil {
+			option.Progress(offset, total)
+		}
+	}
+	return resp, nil
+}
+
+// uploadChunkDefault uploads the chunk with "Content-Range" header.
+func uploadChunkDefault(ctx context.Context, c *Client, chunk *UploadChunk) (*Response, error) {
+	contentRange := fmt.Sprintf("bytes */%d", chunk.Total)
+	if len(chunk.Data) > 0 {
+		contentRange = fmt.Sprintf(
+			"bytes %d-%d/%d", chunk.Offset, chunk.Offset+int64(len(chunk.Data))-1, chunk.Total,
+		)
+	}
+	resp, err := c.Header(map[string]string{
+		httpHeaderContentRange:       contentRange,
+		httpHeaderContentDisposition: fmt.Sprintf(`attachment; filename="%s"`, chunk.FileName),
+	}).ContentType(httpHeaderContentTypeOctet).DoRequest(ctx, chunk.Method, chunk.URL, chunk.Data)
+	if err != nil {
+		return nil, err
+	}
+	if (resp.StatusCode < 200 || resp.StatusCode >= 300) && resp.StatusCode != httpStatusResumeIncomplete {
+		_ = resp.Close()
+		return nil, gerror.NewCodef(
+			gcode.CodeOperationFailed, `upload chunk %d failed with status "%s"`, chunk.Index, resp.Status,
+		)
+	}
+	return resp, nil
+}
+
+// uploadProgressTransport reports the progress of sending request body.
+type uploadProgressTransport struct {
+	transport http.RoundTripper
+	progress  func(uploaded, total int64)
+}
+
+func (t *uploadProgressTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = &uploadProgressReader{
+			ReadCloser: r.Body,
+			total:      r.ContentLength,
+			progress:   t.progress,
+		}
+	}
+	return transport.RoundTrip(r)
+}
+
+// uploadProgressReader counts the bytes read from the request body.
+type uploadProgressReader struct {
+	io.ReadCloser
+	total    int64
+	uploaded atomic.Int64
+	progress func(uploaded, total int64)
+}
+
+func (r *uploadProgressReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	if n > 0 {
+		r.progress(r.uploaded.Add(int64(n)), r.total)
+	}
+	return
+}
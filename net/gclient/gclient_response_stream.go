@@ -0,0 +1,84 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// JsonLinesDecoder decodes line-delimited JSON stream, also known as NDJSON or JSON Lines,
+// in which each line is a JSON value.
+type JsonLinesDecoder struct {
+	reader *bufio.Reader
+	line   int
+}
+
+// NewJsonLinesDecoder creates and returns a JsonLinesDecoder reading from `reader`.
+func NewJsonLinesDecoder(reader io.Reader) *JsonLinesDecoder {
+	return &JsonLinesDecoder{
+		reader: bufio.NewReader(reader),
+	}
+}
+
+// JsonLines returns a JsonLinesDecoder reading the response body as line-delimited JSON stream.
+// The response should be closed after decoding.
+//
+// Example:
+//
+//	defer resp.Close()
+//	decoder := resp.JsonLines()
+//	for {
+//		var item Item
+//		if err := decoder.Decode(&item); err == io.EOF {
+//			break
+//		} else if err != nil {
+//			return err
+//		}
+//	}
+func (r *Response) JsonLines() *JsonLinesDecoder {
+	if r == nil || r.Response == nil {
+		return NewJsonLinesDecoder(bytes.NewReader(nil))
+	}
+	return NewJsonLinesDecoder(r.Body)
+}
+
+// Decode reads the next JSON value and scans it into `pointer` using gconv,
+// which can be type of struct/*struct/map/slice. Empty lines are skipped.
+// It returns io.EOF if there's no more value in the stream.
+func (d *JsonLinesDecoder) Decode(pointer any) error {
+	for {
+		content, err := d.reader.ReadBytes('\n')
+		if len(content) == 0 && err != nil {
+			return err
+		}
+		d.line++
+		if content = bytes.TrimSpace(content); len(content) == 0 {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err = scanJsonContent(content, pointer); err != nil {
+			return gerror.Wrapf(err, `decode JSON value failed at line %d`, d.line)
+		}
+		return nil
+	}
+}
+
+// scanJsonContent decodes JSON `content` and scans it into `pointer` using gconv.
+func scanJsonContent(content []byte, pointer any) error {
+	j, err := gjson.DecodeToJson(content)
+	if err != nil {
+		return err
+	}
+	return j.Scan(pointer)
+}
@@ -0,0 +1,282 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+const (
+	defaultSSERetryInterval = 3 * time.Second
+	sseDefaultEventType     = "message"
+)
+
+// SSEOption is the option for Server-Sent Events stream.
+type SSEOption struct {
+	// Method is the request method, it is GET in default.
+	Method string
+
+	// Data is the request data, which is sent in each connecting.
+	Data any
+
+	// LastEventID is the initial "Last-Event-ID" header value.
+	LastEventID string
+
+	// RetryInterval is the reconnecting interval, it is 3 seconds in default,
+	// and it is overwritten by the "retry" field of events.
+	RetryInterval time.Duration
+
+	// MaxRetries is the maximum number of consecutive failed reconnecting,
+	// the stream reconnects until the context is done if it's 0.
+	MaxRetries int
+}
+
+// SSEEvent is an event of Server-Sent Events stream.
+type SSEEvent struct {
+	ID    string // ID is the event id, which is sent as "Last-Event-ID" header in reconnecting.
+	Event string // Event is the event type, it is "message" in default.
+	Data  string // Data is the event data, lines of multiple "data" fields are joined with "\n".
+}
+
+// SSEStream is the Server-Sent Events stream created by Client.SSE,
+// which reconnects automatically when the connection is lost.
+type SSEStream struct {
+	mu          sync.Mutex
+	ctx         context.Context
+	cancel      context.CancelFunc
+	client      *Client
+	url         string
+	option      SSEOption
+	events      chan *SSEEvent
+	done        chan struct{}
+	err         error
+	lastEventID string
+	retry       time.Duration
+}
+
+// SSE connects `url` and returns the Server-Sent Events stream, the events of which are received by Events.
+//
+// The stream reconnects with "Last-Event-ID" header when the connection is lost, following the "retry" hints
+// of server, and it stops when the context is done, Close is called, or the server responds with status 204.
+// The timeout of client is disabled for the stream, use `ctx` to control the lifetime of the stream.
+//
+// It returns error if the first connecting fails or the server responds status other than 200.
+func (c *Client) SSE(ctx context.Context, url string, option ...SSEOption) (*SSEStream, error) {
+	s := &SSEStream{
+		client: c.Timeout(0),
+		url:    url,
+		events: make(chan *SSEEvent),
+		done:   make(chan struct{}),
+	}
+	if len(option) > 0 {
+		s.option = option[0]
+	}
+	if s.option.Method == "" {
+		s.option.Method = http.MethodGet
+	}
+	if s.option.RetryInterval <= 0 {
+		s.option.RetryInterval = defaultSSERetryInterval
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.lastEventID = s.option.LastEventID
+	s.retry = s.option.RetryInterval
+	resp, err := s.connect()
+	if err != nil {
+		s.cancel()
+		return nil, err
+	}
+	go s.run(resp)
+	return s, nil
+}
+
+// Events returns the channel receiving events, which is closed when the stream stops.
+func (s *SSEStream) Events() <-chan *SSEEvent {
+	return s.events
+}
+
+// Err returns the error stopping the stream, it returns nil if the stream is stopped normally.
+// It should be called after the channel of Events is closed.
+func (s *SSEStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// LastEventID returns the id of the last received event.
+func (s *SSEStream) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastEventID
+}
+
+// Close stops the stream and waits until the connection is closed.
+func (s *SSEStream) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// connect sends the request, it returns nil response without error if the server responds status 204,
+// which means the stream should not be reconnected.
+func (s *SSEStream) connect() (*Response, error) {
+	var headers = map[string]string{
+		"Accept":        "text/event-stream",
+		"Cache-Control": "no-cache",
+	}
+	if id := s.LastEventID(); id != "" {
+		headers["Last-Event-ID"] = id
+	}
+	var data []any
+	if s.option.Data != nil {
+		data = append(data, s.option.Data)
+	}
+	resp, err := s.client.Header(headers).DoRequest(s.ctx, s.option.Method, s.url, data...)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNoContent:
+		_ = resp.Close()
+		return nil, nil
+	default:
+		_ = resp.Close()
+		return nil, gerror.NewCodef(
+			gcode.CodeOperationFailed, `unexpected status "%s" of event stream "%s"`, resp.Status, s.url,
+		)
+	}
+}
+
+// run reads events from the connection and reconnects when it is lost.
+func (s *SSEStream) run(resp *Response) {
+	defer func() {
+		s.cancel()
+		close(s.events)
+		close(s.done)
+	}()
+	var failures int
+	for resp != nil {
+		if err := s.read(resp.Body); err != nil && s.ctx.Err() == nil {
+			intlog.Errorf(s.ctx, `read event stream "%s" failed: %+v`, s.url, err)
+		}
+		_ = resp.Close()
+		resp = nil
+		for resp == nil {
+			timer := time.NewTimer(s.retry)
+			select {
+			case <-s.ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			var err error
+			if resp, err = s.connect(); err == nil {
+				if resp == nil {
+					// Status 204 stops reconnecting.
+					return
+				}
+				failures = 0
+				continue
+			}
+			if s.ctx.Err() != nil {
+				return
+			}
+			intlog.Errorf(s.ctx, `reconnect event stream "%s" failed: %+v`, s.url, err)
+			if failures++; s.option.MaxRetries > 0 && failures >= s.option.MaxRetries {
+				s.mu.Lock()
+				s.err = err
+				s.mu.Unlock()
+				return
+			}
+		}
+	}
+}
+
+// read parses events from `reader` and sends them to the events channel, it returns nil at the end of stream.
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation.
+func (s *SSEStream) read(reader io.Reader) error {
+	var (
+		buffer    = bufio.NewReader(reader)
+		event     = &SSEEvent{}
+		data      strings.Builder
+		hasData   bool
+		eventID   = s.LastEventID()
+		line      string
+		err       error
+		lineBytes []byte
+	)
+	for {
+		if lineBytes, err = buffer.ReadBytes('\n'); err != nil && len(lineBytes) == 0 {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimRight(string(lineBytes), "\r\n")
+		if line == "" {
+			// Dispatches the event.
+			if hasData {
+				event.ID = eventID
+				event.Data = data.String()
+				if event.Event == "" {
+					event.Event = sseDefaultEventType
+				}
+				select {
+				case s.events <- event:
+				case <-s.ctx.Done():
+					return nil
+				}
+			}
+			event, hasData = &SSEEvent{}, false
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// Comment.
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				eventID = value
+				s.mu.Lock()
+				s.lastEventID = value
+				s.mu.Unlock()
+			}
+		case "retry":
+			if milliseconds, parseErr := strconv.Atoi(value); parseErr == nil && milliseconds >= 0 {
+				s.retry = time.Duration(milliseconds) * time.Millisecond
+			}
+		}
+	}
+}
+
+// Scan decodes the JSON data of event into `pointer`, which can be type of struct/*struct/map/slice.
+func (e *SSEEvent) Scan(pointer any) error {
+	return scanJsonContent([]byte(e.Data), pointer)
+}
@@ -0,0 +1,240 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// MockTransport is a programmable transport serving mocked responses without sending requests,
+// which also records the calls for assertions in tests.
+//
+// Example:
+//
+//	mock := gclient.NewMockTransport()
+//	mock.On("GET", "/user/*").ReplyJson(200, g.Map{"id": 1})
+//	client := g.Client().SetTransport(mock)
+type MockTransport struct {
+	mu     sync.RWMutex
+	routes []*MockRoute
+	calls  []*MockCall
+}
+
+// MockRoute is a mocked route, created by MockTransport.On.
+type MockRoute struct {
+	mu      sync.Mutex
+	method  string
+	pattern string
+	times   int // Maximum matched times, no limit if it's 0.
+	calls   int
+	handler func(r *http.Request) (*http.Response, error)
+}
+
+// MockCall is a request received by MockTransport.
+type MockCall struct {
+	Method string      // Request method.
+	URL    string      // Request url.
+	Path   string      // Request url path.
+	Header http.Header // Request headers.
+	Body   []byte      // Request body.
+}
+
+// NewMockTransport creates and returns a new MockTransport.
+func NewMockTransport() *MockTransport {
+	return &MockTransport{
+		routes: make([]*MockRoute, 0),
+		calls:  make([]*MockCall, 0),
+	}
+}
+
+// On adds and returns a route matching requests of `method` and url path `pattern`.
+// The `method` "*" matches all methods, and the `pattern` supports wildcards of path.Match, like "/user/*".
+// Routes are matched in the order they are added, and the route without reply responds with status 200.
+func (m *MockTransport) On(method, pattern string) *MockRoute {
+	route := &MockRoute{
+		method:  strings.ToUpper(method),
+		pattern: pattern,
+	}
+	route.Reply(http.StatusOK, nil)
+	m.mu.Lock()
+	m.routes = append(m.routes, route)
+	m.mu.Unlock()
+	return route
+}
+
+// Calls returns the received requests matching `method` and url path `pattern`,
+// or all received requests if no parameter is given.
+func (m *MockTransport) Calls(methodAndPattern ...string) []*MockCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var calls = make([]*MockCall, 0, len(m.calls))
+	for _, call := range m.calls {
+		if len(methodAndPattern) >= 2 && !mockMatch(
+			strings.ToUpper(methodAndPattern[0]), methodAndPattern[1], call.Method, call.Path,
+		) {
+			continue
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// Reset clears all routes and received requests.
+func (m *MockTransport) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// New slices are allocated, as the old ones might be still in use by RoundTrip and Calls.
+	m.routes = make([]*MockRoute, 0)
+	m.calls = make([]*MockCall, 0)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (m *MockTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var call = &MockCall{
+		Method: r.Method,
+		URL:    r.URL.String(),
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+	}
+	if r.Body != nil {
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		call.Body = body
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	m.mu.Lock()
+	m.calls = append(m.calls, call)
+	routes := m.routes
+	m.mu.Unlock()
+	for _, route := range routes {
+		if route.take(r) {
+			route.mu.Lock()
+			handler := route.handler
+			route.mu.Unlock()
+			return handler(r)
+		}
+	}
+	return nil, gerror.NewCodef(gcode.CodeNotFound, `no mocked route for request "%s %s"`, r.Method, r.URL.String())
+}
+
+// Times limits the route matching at most `n` times.
+func (r *MockRoute) Times(n int) *MockRoute {
+	r.mu.Lock()
+	r.times = n
+	r.mu.Unlock()
+	return r
+}
+
+// Reply sets the response of the route with `status` and `body`.
+// The `body` is sent as it is if it is string or []byte, or else it is encoded as JSON.
+func (r *MockRoute) Reply(status int, body any) *MockRoute {
+	var (
+		content     []byte
+		contentType string
+	)
+	switch v := body.(type) {
+	case nil:
+	case string:
+		content = []byte(v)
+	case []byte:
+		content = v
+	default:
+		content = gjson.MustEncode(v)
+		contentType = httpHeaderContentTypeJson
+	}
+	return r.ReplyFunc(func(req *http.Request) (*http.Response, error) {
+		resp := newMockResponse(req, status, content)
+		if contentType != "" {
+			resp.Header.Set(httpHeaderContentType, contentType)
+		}
+		return resp, nil
+	})
+}
+
+// ReplyJson sets the response of the route with `status` and `body` encoded as JSON.
+func (r *MockRoute) ReplyJson(status int, body any) *MockRoute {
+	return r.ReplyFunc(func(req *http.Request) (*http.Response, error) {
+		resp := newMockResponse(req, status, gjson.MustEncode(body))
+		resp.Header.Set(httpHeaderContentType, httpHeaderContentTypeJson)
+		return resp, nil
+	})
+}
+
+// ReplyError sets the route failing with `err` like network errors.
+func (r *MockRoute) ReplyError(err error) *MockRoute {
+	return r.ReplyFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, err
+	})
+}
+
+// ReplyFunc sets the handler creating responses for the route.
+func (r *MockRoute) ReplyFunc(handler func(r *http.Request) (*http.Response, error)) *MockRoute {
+	r.mu.Lock()
+	r.handler = handler
+	r.mu.Unlock()
+	return r
+}
+
+// Calls returns the matched times of the route.
+func (r *MockRoute) Calls() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls
+}
+
+// take checks whether the route matches the request, and increases its matched times if it matches.
+func (r *MockRoute) take(req *http.Request) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.times > 0 && r.calls >= r.times {
+		return false
+	}
+	if !mockMatch(r.method, r.pattern, req.Method, req.URL.Path) {
+		return false
+	}
+	r.calls++
+	return true
+}
+
+// mockMatch checks whether `method` and `urlPath` match the route `routeMethod` and `routePattern`.
+func mockMatch(routeMethod, routePattern, method, urlPath string) bool {
+	if routeMethod != "*" && routeMethod != method {
+		return false
+	}
+	if routePattern == urlPath {
+		return true
+	}
+	matched, _ := path.Match(routePattern, urlPath)
+	return matched
+}
+
+func newMockResponse(r *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
@@ -0,0 +1,488 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	harVersion      = "1.2"
+	harCreatorName  = "gclient"
+	harRedactedText = "[REDACTED]"
+)
+
+// DefaultRedactHeaders are the headers redacted by Recorder in default.
+var DefaultRedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// RecorderOption is the option for Recorder.
+type RecorderOption struct {
+	// Transport is the underlying transport sending requests, it is http.DefaultTransport in default.
+	Transport http.RoundTripper
+
+	// RedactHeaders are the header names of which values are redacted in recording,
+	// it is DefaultRedactHeaders in default.
+	RedactHeaders []string
+}
+
+// Recorder is a transport that records request/response pairs to file, which can be served back by Replayer.
+// The file is in HAR format if its extension is ".har" or ".json", or else it is in YAML format.
+// The records are kept in memory and written to file by Save or Close.
+//
+// Use it in tests with Client.SetTransport, eg:
+//
+//	recorder := gclient.NewRecorder(gtest.DataPath("user.har"))
+//	defer recorder.Close()
+//	client.SetTransport(recorder)
+type Recorder struct {
+	mu      sync.Mutex
+	path    string
+	option  RecorderOption
+	entries []*harEntry
+}
+
+// ReplayerOption is the option for Replayer.
+type ReplayerOption struct {
+	// Match checks whether the recorded request matches the request `r`.
+	// In default, requests having the same method, url path and query parameters are matched.
+	Match func(recorded, r *http.Request) bool
+
+	// Fallback is the transport sending the requests that match no recorded ones.
+	// Error is returned for these requests if it is nil.
+	Fallback http.RoundTripper
+}
+
+// Replayer is a transport that serves recorded responses back for matched requests,
+// without sending requests to the server.
+// The recorded responses of matched requests are served in order, and the last one is served repeatedly.
+type Replayer struct {
+	mu      sync.Mutex
+	option  ReplayerOption
+	entries []*harEntry
+	served  map[*harEntry]bool
+}
+
+// harFile is the root of HAR file.
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"` // Non-standard but commonly used for binary content.
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// NewRecorder creates and returns a Recorder writing records to file `path`.
+// The existing records in the file are overwritten when the records are saved.
+func NewRecorder(path string, option ...RecorderOption) *Recorder {
+	r := &Recorder{
+		path:    path,
+		entries: make([]*harEntry, 0),
+	}
+	if len(option) > 0 {
+		r.option = option[0]
+	}
+	if r.option.Transport == nil {
+		r.option.Transport = http.DefaultTransport
+	}
+	if r.option.RedactHeaders == nil {
+		r.option.RedactHeaders = DefaultRedactHeaders
+	}
+	return r
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var (
+		startTime = time.Now()
+		reqBody   []byte
+		err       error
+	)
+	if req.Body != nil {
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := r.option.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	entry := &harEntry{
+		StartedDateTime: startTime.Format(time.RFC3339Nano),
+		Time:            float64(time.Since(startTime).Microseconds()) / 1000,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Headers:     r.headers(req.Header),
+			QueryString: harQueryString(req.URL.Query()),
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     r.headers(resp.Header),
+		},
+	}
+	if len(reqBody) > 0 {
+		text, encoding := harEncodeBody(reqBody)
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     text,
+			Encoding: encoding,
+		}
+	}
+	text, encoding := harEncodeBody(respBody)
+	entry.Response.Content = harContent{
+		Size:     len(respBody),
+		MimeType: resp.Header.Get("Content-Type"),
+		Text:     text,
+		Encoding: encoding,
+	}
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes all the records to file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	entries := r.entries
+	r.mu.Unlock()
+	return saveHarFile(r.path, entries)
+}
+
+// Close writes all the records to file, it is an alias of Save, which implements io.Closer.
+func (r *Recorder) Close() error {
+	return r.Save()
+}
+
+// headers converts `header` to HAR headers, with sensitive values redacted.
+func (r *Recorder) headers(header http.Header) []harNameValue {
+	var items = make([]harNameValue, 0, len(header))
+	for _, name := range sortedKeys(header) {
+		values := header[name]
+		redacted := false
+		for _, v := range r.option.RedactHeaders {
+			if strings.EqualFold(v, name) {
+				redacted = true
+				break
+			}
+		}
+		for _, value := range values {
+			if redacted {
+				value = harRedactedText
+			}
+			items = append(items, harNameValue{Name: name, Value: value})
+		}
+	}
+	return items
+}
+
+// NewReplayer creates and returns a Replayer serving the records in file `path`,
+// which is created by Recorder or other HAR tools.
+func NewReplayer(path string, option ...ReplayerOption) (*Replayer, error) {
+	entries, err := loadHarFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Replayer{
+		entries: entries,
+		served:  make(map[*harEntry]bool),
+	}
+	if len(option) > 0 {
+		r.option = option[0]
+	}
+	if r.option.Match == nil {
+		r.option.Match = replayMatchDefault
+	}
+	return r, nil
+}
+
+// MustNewReplayer acts as NewReplayer, but it panics if any error occurs.
+func MustNewReplayer(path string, option ...ReplayerOption) *Replayer {
+	r, err := NewReplayer(path, option...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry, err := r.match(req)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		if r.option.Fallback != nil {
+			return r.option.Fallback.RoundTrip(req)
+		}
+		return nil, gerror.NewCodef(
+			gcode.CodeNotFound, `no recorded response for request "%s %s"`, req.Method, req.URL.String(),
+		)
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	body, err := harDecodeBody(entry.Response.Content.Text, entry.Response.Content.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Response.Status, entry.Response.StatusText),
+		StatusCode:    entry.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+	for _, item := range entry.Response.Headers {
+		resp.Header.Add(item.Name, item.Value)
+	}
+	return resp, nil
+}
+
+// match finds the recorded entry for the request, it returns nil if no entry matched.
+func (r *Replayer) match(req *http.Request) (*harEntry, error) {
+	var (
+		reqBody []byte
+		err     error
+	)
+	if req.Body != nil {
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var last *harEntry
+	for _, entry := range r.entries {
+		recorded, err := entry.Request.httpRequest()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		if !r.option.Match(recorded, req) {
+			continue
+		}
+		last = entry
+		if !r.served[entry] {
+			r.served[entry] = true
+			break
+		}
+	}
+	req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	return last, nil
+}
+
+// replayMatchDefault matches requests by method, url path and query parameters.
+func replayMatchDefault(recorded, r *http.Request) bool {
+	return recorded.Method == r.Method &&
+		recorded.URL.Path == r.URL.Path &&
+		recorded.URL.Query().Encode() == r.URL.Query().Encode()
+}
+
+// httpRequest converts the recorded request to http.Request.
+func (r *harRequest) httpRequest() (*http.Request, error) {
+	var body []byte
+	if r.PostData != nil {
+		var err error
+		if body, err = harDecodeBody(r.PostData.Text, r.PostData.Encoding); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(r.Method, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, gerror.Wrapf(err, `invalid recorded request "%s %s"`, r.Method, r.URL)
+	}
+	for _, item := range r.Headers {
+		req.Header.Add(item.Name, item.Value)
+	}
+	return req, nil
+}
+
+// saveHarFile writes `entries` to file `path` in the format of its extension.
+func saveHarFile(path string, entries []*harEntry) error {
+	var (
+		content []byte
+		err     error
+		har     = harFile{
+			Log: harLog{
+				Version: harVersion,
+				Creator: harCreator{Name: harCreatorName, Version: harVersion},
+				Entries: entries,
+			},
+		}
+	)
+	if isHarJsonFile(path) {
+		content, err = gjson.MarshalIndent(har, "", "  ")
+	} else {
+		var j *gjson.Json
+		if j, err = gjson.LoadContentType(gjson.ContentTypeJSON, gjson.MustEncode(har)); err == nil {
+			content, err = j.ToYaml()
+		}
+	}
+	if err != nil {
+		return gerror.Wrapf(err, `encode records failed for file "%s"`, path)
+	}
+	return gfile.PutBytes(path, content)
+}
+
+// loadHarFile reads the entries from file `path` in the format of its extension.
+func loadHarFile(path string) ([]*harEntry, error) {
+	if !gfile.Exists(path) {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `records file "%s" does not exist`, path)
+	}
+	var (
+		har         harFile
+		contentType = gjson.ContentTypeYaml
+	)
+	if isHarJsonFile(path) {
+		contentType = gjson.ContentTypeJSON
+	}
+	j, err := gjson.LoadContentType(contentType, gfile.GetBytes(path))
+	if err != nil {
+		return nil, gerror.Wrapf(err, `load records failed for file "%s"`, path)
+	}
+	if err = j.Scan(&har); err != nil {
+		return nil, gerror.Wrapf(err, `load records failed for file "%s"`, path)
+	}
+	return har.Log.Entries, nil
+}
+
+func isHarJsonFile(path string) bool {
+	switch strings.ToLower(gfile.ExtName(path)) {
+	case "har", "json":
+		return true
+	}
+	return false
+}
+
+// harQueryString converts the query parameters to HAR name-value pairs.
+func harQueryString(values url.Values) []harNameValue {
+	var items = make([]harNameValue, 0, len(values))
+	for _, name := range sortedKeys(values) {
+		list := values[name]
+		for _, value := range list {
+			items = append(items, harNameValue{Name: name, Value: value})
+		}
+	}
+	return items
+}
+
+func sortedKeys[M ~map[string][]string](m M) []string {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// harEncodeBody encodes body as text, binary body is encoded using base64.
+func harEncodeBody(body []byte) (text, encoding string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// harDecodeBody decodes the body encoded by harEncodeBody.
+func harDecodeBody(text, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		body, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return nil, gerror.Wrap(err, `decode base64 body failed`)
+		}
+		return body, nil
+	}
+	return []byte(text), nil
+}
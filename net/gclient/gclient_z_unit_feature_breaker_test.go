@@ -0,0 +1,393 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Middleware_Breaker(t *testing.T) {
+	var healthy = gtype.NewBool()
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		if !healthy.Val() {
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			host    = fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort())
+			breaker = gclient.NewBreaker(gclient.BreakerOption{
+				MinRequests: 2,
+				OpenTimeout: 200 * time.Millisecond,
+			})
+			client = g.Client().Prefix("http://" + host)
+		)
+		client.Use(breaker.Middleware)
+		t.Assert(breaker.State(host), gclient.BreakerStateClosed)
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(ctx, "/")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, http.StatusInternalServerError)
+			resp.Close()
+		}
+		t.Assert(breaker.State(host), gclient.BreakerStateOpen)
+
+		// Rejected without sending the request.
+		_, err := client.Get(ctx, "/")
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeServerBusy)
+
+		// Failed probe opens the breaker again.
+		time.Sleep(250 * time.Millisecond)
+		resp, err := client.Get(ctx, "/")
+		t.AssertNil(err)
+		resp.Close()
+		t.Assert(breaker.State(host), gclient.BreakerStateOpen)
+
+		// Succeeded probe closes the breaker.
+		healthy.Set(true)
+		time.Sleep(250 * time.Millisecond)
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+		t.Assert(breaker.State(host), gclient.BreakerStateClosed)
+	})
+}
+
+func Test_Client_Middleware_Breaker_Panic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			host    = "127.0.0.1:1"
+			status  = gtype.NewString("fail")
+			breaker = gclient.NewBreaker(gclient.BreakerOption{
+				MinRequests: 1,
+				OpenTimeout: 100 * time.Millisecond,
+			})
+			client = g.Client().Prefix("http://" + host)
+		)
+		client.Use(breaker.Middleware, func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+			switch status.Val() {
+			case "panic":
+				panic("probe panics")
+			case "fail":
+				return nil, gerror.New("request failed")
+			default:
+				return nil, nil
+			}
+		})
+		_, err := client.Get(ctx, "/")
+		t.AssertNE(err, nil)
+		t.Assert(breaker.State(host), gclient.BreakerStateOpen)
+
+		// The panic of probe request is counted as a failure, which releases the probe slot.
+		status.Set("panic")
+		time.Sleep(150 * time.Millisecond)
+		func() {
+			defer func() {
+				t.AssertNE(recover(), nil)
+			}()
+			_, _ = client.Get(ctx, "/")
+		}()
+		t.Assert(breaker.State(host), gclient.BreakerStateOpen)
+
+		status.Set("ok")
+		time.Sleep(150 * time.Millisecond)
+		_, err = client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(breaker.State(host), gclient.BreakerStateClosed)
+	})
+}
+
+// testBreakerDiscovery is a discovery returning the fixed service for testing.
+type testBreakerDiscovery struct {
+	service gsvc.Service
+}
+
+// testBreakerWatcher is a watcher that never reports changes.
+type testBreakerWatcher struct {
+	closed chan struct{}
+}
+
+func (d *testBreakerDiscovery) Search(ctx context.Context, in gsvc.SearchInput) ([]gsvc.Service, error) {
+	return []gsvc.Service{d.service}, nil
+}
+
+func (d *testBreakerDiscovery) Watch(ctx context.Context, key string) (gsvc.Watcher, error) {
+	return &testBreakerWatcher{closed: make(chan struct{})}, nil
+}
+
+func (w *testBreakerWatcher) Proceed() ([]gsvc.Service, error) {
+	<-w.closed
+	return nil, gerror.New("watcher closed")
+}
+
+func (w *testBreakerWatcher) Close() error {
+	close(w.closed)
+	return nil
+}
+
+func Test_Client_Middleware_Breaker_Discovery(t *testing.T) {
+	sFailed := g.Server(guid.S())
+	sFailed.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.WriteStatus(http.StatusInternalServerError)
+	})
+	sFailed.SetDumpRouterMap(false)
+	sFailed.Start()
+	defer sFailed.Shutdown()
+
+	sHealthy := g.Server(guid.S())
+	sHealthy.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Write("ok")
+	})
+	sHealthy.SetDumpRouterMap(false)
+	sHealthy.Start()
+	defer sHealthy.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name      = guid.S()
+			discovery = &testBreakerDiscovery{service: &gsvc.LocalService{
+				Name: name,
+				Endpoints: gsvc.NewEndpoints(fmt.Sprintf(
+					"127.0.0.1:%d,127.0.0.1:%d", sFailed.GetListenedPort(), sHealthy.GetListenedPort(),
+				)),
+			}}
+			breakerClient = gclient.New().Discovery(discovery).Prefix("http://" + name)
+			client        = gclient.New().Discovery(discovery).Prefix("http://" + name)
+			failedNode    = fmt.Sprintf("127.0.0.1:%d", sFailed.GetListenedPort())
+			breaker       = gclient.NewBreaker(gclient.BreakerOption{
+				MinRequests: 2,
+				OpenTimeout: time.Minute,
+			})
+		)
+		defer breaker.Close()
+		breakerClient.SetBuilder(gsel.NewBuilderRoundRobin())
+		breakerClient.Use(breaker.Middleware)
+		for i := 0; i < 4; i++ {
+			resp, err := breakerClient.Get(ctx, "/")
+			t.AssertNil(err)
+			resp.Close()
+		}
+		t.Assert(breaker.State(failedNode), gclient.BreakerStateOpen)
+
+		// The node of which breaker is open is skipped for the client using the breaker.
+		for i := 0; i < 4; i++ {
+			t.Assert(breakerClient.GetContent(ctx, "/"), "ok")
+		}
+
+		// The other client sharing the same service is not affected.
+		var failures int
+		for i := 0; i < 4; i++ {
+			resp, err := client.Get(ctx, "/")
+			t.AssertNil(err)
+			if resp.StatusCode == http.StatusInternalServerError {
+				failures++
+			}
+			resp.Close()
+		}
+		t.Assert(failures, 2)
+	})
+}
+
+func Test_Client_Middleware_Breaker_Slow(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		time.Sleep(100 * time.Millisecond)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			host   = fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort())
+			client = g.Client().Prefix("http://" + host)
+		)
+		client.Use(gclient.MiddlewareBreaker(gclient.BreakerOption{
+			MinRequests:  2,
+			SlowDuration: 50 * time.Millisecond,
+		}))
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+		_, err := client.Get(ctx, "/")
+		t.Assert(gerror.Code(err), gcode.CodeServerBusy)
+	})
+}
+
+func Test_Client_Middleware_Breaker_Idle(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.WriteStatus(http.StatusInternalServerError)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	var (
+		prefix  = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		request = func(client *gclient.Client, key string) {
+			resp, err := client.Header(g.MapStrStr{"X-Key": key}).Get(ctx, "/")
+			if err == nil {
+				resp.Close()
+			}
+		}
+		keyFunc = func(r *http.Request) string {
+			return r.Header.Get("X-Key")
+		}
+	)
+	// The idle state is removed.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			breaker = gclient.NewBreaker(gclient.BreakerOption{
+				Key:         keyFunc,
+				MinRequests: 1,
+				OpenTimeout: 100 * time.Millisecond,
+				IdleTimeout: 200 * time.Millisecond,
+			})
+			client = g.Client().Prefix(prefix).Use(breaker.Middleware)
+		)
+		request(client, "a")
+		t.Assert(breaker.State("a"), gclient.BreakerStateOpen)
+
+		time.Sleep(300 * time.Millisecond)
+		request(client, "b")
+		t.Assert(breaker.State("a"), gclient.BreakerStateClosed)
+		t.Assert(breaker.State("b"), gclient.BreakerStateOpen)
+
+		breaker.Close()
+		t.Assert(breaker.State("b"), gclient.BreakerStateClosed)
+	})
+
+	// The state rejecting requests is not removed.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			breaker = gclient.NewBreaker(gclient.BreakerOption{
+				Key:         keyFunc,
+				MinRequests: 1,
+				OpenTimeout: time.Minute,
+				IdleTimeout: 100 * time.Millisecond,
+			})
+			client = g.Client().Prefix(prefix).Use(breaker.Middleware)
+		)
+		defer breaker.Close()
+		request(client, "a")
+		time.Sleep(200 * time.Millisecond)
+		request(client, "b")
+		t.Assert(breaker.State("a"), gclient.BreakerStateOpen)
+	})
+}
+
+func Test_Client_Middleware_Bulkhead(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		time.Sleep(300 * time.Millisecond)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.Use(gclient.MiddlewareBulkhead(gclient.BulkheadOption{
+			MaxConcurrency: 1,
+			MaxWait:        50 * time.Millisecond,
+		}))
+		var done = make(chan string)
+		go func() {
+			done <- client.GetContent(ctx, "/")
+		}()
+		time.Sleep(100 * time.Millisecond)
+		_, err := client.Get(ctx, "/")
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeServerBusy)
+		t.Assert(<-done, "ok")
+		// The slot is released.
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+	})
+}
+
+func Test_Client_Middleware_Backoff(t *testing.T) {
+	var count = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		if count.Add(1) < 3 {
+			r.Response.Header().Set("Retry-After", "0")
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+			return
+		}
+		r.Response.Write(r.GetBodyString())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.Use(gclient.MiddlewareBackoff(gclient.BackoffOption{
+			MaxInterval: time.Second,
+		}))
+		t.Assert(client.PostContent(ctx, "/", "body"), "body")
+		t.Assert(count.Val(), 3)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		count.Set(0)
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.Use(gclient.MiddlewareBackoff(gclient.BackoffOption{
+			MaxRetries: 1,
+		}))
+		resp, err := client.Post(ctx, "/", "body")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+		t.Assert(count.Val(), 2)
+	})
+	// The body of reader which cannot be got again is cached for retrying.
+	gtest.C(t, func(t *gtest.T) {
+		count.Set(0)
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.Use(gclient.MiddlewareBackoff(gclient.BackoffOption{
+			MaxInterval: time.Second,
+		}))
+		reader, writer := io.Pipe()
+		go func() {
+			_, _ = writer.Write([]byte("streamed body"))
+			_ = writer.Close()
+		}()
+		t.Assert(client.PostContent(ctx, "/", reader), "streamed body")
+		t.Assert(count.Val(), 3)
+	})
+}
@@ -0,0 +1,209 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Middleware_Cache(t *testing.T) {
+	var (
+		count       = gtype.NewInt()
+		notModified = gtype.NewInt()
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/max-age", func(r *ghttp.Request) {
+		r.Response.Header().Set("Cache-Control", "max-age=60")
+		r.Response.WriteJson(g.Map{"count": count.Add(1)})
+	})
+	s.BindHandler("/etag", func(r *ghttp.Request) {
+		count.Add(1)
+		r.Response.Header().Set("Cache-Control", "no-cache")
+		r.Response.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			r.Response.WriteHeader(http.StatusNotModified)
+			return
+		}
+		r.Response.Write("etag")
+	})
+	s.BindHandler("/no-store", func(r *ghttp.Request) {
+		r.Response.Header().Set("Cache-Control", "no-store")
+		r.Response.Write(count.Add(1))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		count.Set(0)
+		client := g.Client().Prefix(prefix)
+		client.Use(gclient.MiddlewareCache(gclient.CacheOption{
+			Adapter: gcache.NewAdapterMemory(),
+		}))
+		t.Assert(client.GetContent(ctx, "/max-age"), `{"count":1}`)
+		t.Assert(client.GetVar(ctx, "/max-age").Map()["count"], 1)
+
+		var res struct {
+			Count int `json:"count"`
+		}
+		t.AssertNil(client.ContentJson().DoRequestObj(ctx, &struct {
+			g.Meta `path:"/max-age" method:"get"`
+		}{}, &res))
+		t.Assert(res.Count, 1)
+		t.Assert(count.Val(), 1)
+
+		// Requests of other methods are not cached.
+		t.Assert(client.PostContent(ctx, "/max-age"), `{"count":2}`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		count.Set(0)
+		client := g.Client().Prefix(prefix)
+		client.Use(gclient.MiddlewareCache())
+		t.Assert(client.GetContent(ctx, "/etag"), "etag")
+		t.Assert(client.GetContent(ctx, "/etag"), "etag")
+		t.Assert(client.GetContent(ctx, "/etag"), "etag")
+		t.Assert(count.Val(), 3)
+		t.Assert(notModified.Val(), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		count.Set(0)
+		client := g.Client().Prefix(prefix)
+		client.Use(gclient.MiddlewareCache(gclient.CacheOption{
+			DefaultTTL: time.Minute,
+		}))
+		t.Assert(client.GetContent(ctx, "/no-store"), "1")
+		t.Assert(client.GetContent(ctx, "/no-store"), "2")
+	})
+}
+
+func Test_Client_Middleware_Cache_Coalescing(t *testing.T) {
+	var count = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		count.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg       sync.WaitGroup
+			contents = make([]string, 5)
+			client   = g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		)
+		client.Use(gclient.MiddlewareCache())
+		for i := range contents {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				contents[i] = client.GetContent(ctx, "/")
+			}(i)
+		}
+		wg.Wait()
+		t.Assert(contents, []string{"ok", "ok", "ok", "ok", "ok"})
+		t.Assert(count.Val(), 1)
+
+		// The response without freshness information is not cached.
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+		t.Assert(count.Val(), 2)
+	})
+}
+
+func Test_Client_Middleware_Cache_Vary(t *testing.T) {
+	var count = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		count.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		r.Response.Header().Set("Vary", "Accept-Language")
+		r.Response.Write(r.Header.Get("Accept-Language"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg        sync.WaitGroup
+			languages = []string{"en", "en", "zh", "zh", "fr"}
+			contents  = make([]string, len(languages))
+			client    = g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		)
+		client.Use(gclient.MiddlewareCache())
+		for i := range languages {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				contents[i] = client.Header(g.MapStrStr{"Accept-Language": languages[i]}).GetContent(ctx, "/")
+			}(i)
+		}
+		wg.Wait()
+		t.Assert(contents, languages)
+		t.Assert(count.Val() >= 3, true)
+	})
+}
+
+func Test_Client_Middleware_Cache_ConditionalHeader(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Header().Set("Cache-Control", "no-cache")
+		r.Response.Header().Set("ETag", `"v1"`)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			failed      = gtype.NewBool()
+			ifNoneMatch = gtype.NewString()
+			client      = g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		)
+		client.Use(func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+			resp, err := c.Next(r)
+			ifNoneMatch.Set(r.Header.Get("If-None-Match"))
+			return resp, err
+		})
+		client.Use(gclient.MiddlewareCache())
+		client.Use(func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+			if failed.Val() {
+				return nil, fmt.Errorf("upstream error")
+			}
+			return c.Next(r)
+		})
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+		t.Assert(ifNoneMatch.Val(), "")
+
+		// The conditional headers are removed although the request fails.
+		failed.Set(true)
+		t.Assert(client.GetContent(ctx, "/"), "")
+		t.Assert(ifNoneMatch.Val(), "")
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Middleware_OAuth2(t *testing.T) {
+	var (
+		tokenCount = gtype.NewInt()
+		validToken = gtype.NewString()
+		expiresIn  = gtype.NewInt(3600)
+		lastParams = gtype.NewAny()
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/token", func(r *ghttp.Request) {
+		lastParams.Set(r.GetMap())
+		user, pass, ok := r.Request.BasicAuth()
+		if !ok {
+			user, pass = r.Get("client_id").String(), r.Get("client_secret").String()
+		}
+		if user != "id" || pass != "secret" {
+			r.Response.WriteStatus(http.StatusUnauthorized)
+			r.Response.ClearBuffer()
+			r.Response.WriteJson(g.Map{"error": "invalid_client", "error_description": "bad credentials"})
+			return
+		}
+		token := fmt.Sprintf("token%d", tokenCount.Add(1))
+		validToken.Set(token)
+		r.Response.WriteJson(g.Map{
+			"access_token":  token,
+			"token_type":    "bearer",
+			"expires_in":    expiresIn.Val(),
+			"refresh_token": "refresh" + token,
+		})
+	})
+	s.BindHandler("/api", func(r *ghttp.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+validToken.Val() {
+			r.Response.WriteStatus(http.StatusUnauthorized)
+			return
+		}
+		r.Response.Write("ok:", r.GetBodyString())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	newConfig := func() gclient.OAuth2Config {
+		return gclient.OAuth2Config{
+			TokenURL:     prefix + "/token",
+			ClientID:     "id",
+			ClientSecret: "secret",
+			Scopes:       []string{"read", "write"},
+		}
+	}
+	// Client credentials, the token is cached and obtained only once for concurrent requests.
+	gtest.C(t, func(t *gtest.T) {
+		tokenCount.Set(0)
+		client := g.Client().Prefix(prefix)
+		client.Use(gclient.MiddlewareOAuth2(newConfig()))
+		var (
+			wg       sync.WaitGroup
+			contents = make([]string, 10)
+		)
+		for i := range contents {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				contents[i] = client.PostContent(ctx, "/api", "data")
+			}(i)
+		}
+		wg.Wait()
+		for _, content := range contents {
+			t.Assert(content, "ok:data")
+		}
+		t.Assert(tokenCount.Val(), 1)
+		t.Assert(lastParams.Val().(map[string]any)["grant_type"], gclient.OAuth2GrantClientCredentials)
+		t.Assert(lastParams.Val().(map[string]any)["scope"], "read write")
+
+		// The token is revoked, the request is retried once with new token.
+		validToken.Set("revoked")
+		tokenCount.Add(10)
+		t.Assert(client.PostContent(ctx, "/api", "data"), "ok:data")
+		t.Assert(tokenCount.Val(), 12)
+	})
+	// The token is about to expire.
+	gtest.C(t, func(t *gtest.T) {
+		tokenCount.Set(0)
+		expiresIn.Set(5)
+		defer expiresIn.Set(3600)
+		config := newConfig()
+		config.AuthStyle = gclient.OAuth2AuthStyleParams
+		oauth2 := gclient.NewOAuth2(config)
+		client := g.Client().Prefix(prefix)
+		client.Use(oauth2.Middleware)
+		t.Assert(client.GetContent(ctx, "/api"), "ok:")
+		t.Assert(client.GetContent(ctx, "/api"), "ok:")
+		t.Assert(tokenCount.Val(), 2)
+
+		token, err := oauth2.Token(ctx)
+		t.AssertNil(err)
+		t.Assert(token.AccessToken, "token3")
+		t.Assert(token.TokenType, "bearer")
+	})
+	// Refresh token and JWT bearer grants.
+	gtest.C(t, func(t *gtest.T) {
+		config := newConfig()
+		config.GrantType = gclient.OAuth2GrantRefreshToken
+		config.RefreshToken = "initial"
+		oauth2 := gclient.NewOAuth2(config)
+		_, err := oauth2.Token(ctx)
+		t.AssertNil(err)
+		t.Assert(lastParams.Val().(map[string]any)["refresh_token"], "initial")
+
+		config = newConfig()
+		config.GrantType = gclient.OAuth2GrantJwtBearer
+		config.AssertionFunc = func(ctx context.Context) (string, error) {
+			return "jwt", nil
+		}
+		_, err = gclient.NewOAuth2(config).Token(ctx)
+		t.AssertNil(err)
+		t.Assert(lastParams.Val().(map[string]any)["grant_type"], gclient.OAuth2GrantJwtBearer)
+		t.Assert(lastParams.Val().(map[string]any)["assertion"], "jwt")
+	})
+	// Invalid client credentials.
+	gtest.C(t, func(t *gtest.T) {
+		config := newConfig()
+		config.ClientSecret = "invalid"
+		client := g.Client().Prefix(prefix)
+		client.Use(gclient.MiddlewareOAuth2(config))
+		_, err := client.Get(ctx, "/api")
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeNotAuthorized)
+		t.AssertIN("invalid_client", err.Error())
+	})
+	// Configuration map.
+	gtest.C(t, func(t *gtest.T) {
+		config, err := gclient.OAuth2ConfigFromMap(g.Map{
+			"tokenUrl":     prefix + "/token",
+			"clientId":     "id",
+			"clientSecret": "secret",
+			"scopes":       g.Slice{"read"},
+			"expiryDelta":  "30s",
+		})
+		t.AssertNil(err)
+		t.Assert(config.TokenURL, prefix+"/token")
+		t.Assert(config.Scopes, g.SliceStr{"read"})
+		t.Assert(config.ExpiryDelta, 30*time.Second)
+
+		client := g.Client()
+		t.AssertNil(client.SetConfigWithMap(g.Map{
+			"prefix": prefix,
+			"header": g.MapStrStr{"X-Test": "1"},
+			"oauth2": g.Map{
+				"tokenUrl":     prefix + "/token",
+				"clientId":     "id",
+				"clientSecret": "secret",
+			},
+		}))
+		t.Assert(client.GetContent(ctx, "/api"), "ok:")
+	})
+}
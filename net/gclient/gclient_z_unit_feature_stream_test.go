@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_SSE(t *testing.T) {
+	var failCount = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/sse", func(r *ghttp.Request) {
+		r.Response.Header().Set("Content-Type", "text/event-stream")
+		switch r.Header.Get("Last-Event-ID") {
+		case "":
+			r.Response.Write("retry: 50\n\n")
+			r.Response.Write("id: 1\ndata: {\"n\":1}\n\n")
+			r.Response.Write(": comment\n")
+			r.Response.Write("id: 2\nevent: update\ndata: line1\ndata: line2\n\n")
+		case "2":
+			r.Response.Write("id: 3\r\ndata: done\r\n\r\n")
+		default:
+			r.Response.WriteHeader(http.StatusNoContent)
+		}
+	})
+	s.BindHandler("/sse-fail", func(r *ghttp.Request) {
+		if failCount.Add(1) > 1 {
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		r.Response.Header().Set("Content-Type", "text/event-stream")
+		r.Response.Write("data: once\n\n")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		stream, err := g.Client().Prefix(prefix).SSE(ctx, "/sse")
+		t.AssertNil(err)
+		defer stream.Close()
+
+		var events []*gclient.SSEEvent
+		for event := range stream.Events() {
+			events = append(events, event)
+		}
+		t.AssertNil(stream.Err())
+		t.Assert(len(events), 3)
+		t.Assert(events[0].ID, "1")
+		t.Assert(events[0].Event, "message")
+		var data struct {
+			N int
+		}
+		t.AssertNil(events[0].Scan(&data))
+		t.Assert(data.N, 1)
+		t.Assert(events[1].ID, "2")
+		t.Assert(events[1].Event, "update")
+		t.Assert(events[1].Data, "line1\nline2")
+		t.Assert(events[2].ID, "3")
+		t.Assert(events[2].Data, "done")
+		t.Assert(stream.LastEventID(), "3")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		stream, err := g.Client().Prefix(prefix).SSE(ctx, "/sse-fail", gclient.SSEOption{
+			RetryInterval: 10 * time.Millisecond,
+			MaxRetries:    2,
+		})
+		t.AssertNil(err)
+		var events []string
+		for event := range stream.Events() {
+			events = append(events, event.Data)
+		}
+		t.Assert(events, []string{"once"})
+		t.AssertNE(stream.Err(), nil)
+		t.Assert(failCount.Val(), 3)
+
+		_, err = g.Client().Prefix(prefix).SSE(ctx, "/sse-fail")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Client_JsonLines(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/ndjson", func(r *ghttp.Request) {
+		r.Response.Header().Set("Content-Type", "application/x-ndjson")
+		r.Response.Write("{\"id\":1,\"name\":\"john\"}\n\n{\"id\":\"2\",\"name\":\"smith\"}\n")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	type Item struct {
+		Id   int
+		Name string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		resp, err := client.Get(ctx, "/ndjson")
+		t.AssertNil(err)
+		defer resp.Close()
+
+		var (
+			items   []Item
+			decoder = resp.JsonLines()
+		)
+		for {
+			var item Item
+			if err = decoder.Decode(&item); err != nil {
+				break
+			}
+			items = append(items, item)
+		}
+		t.Assert(err, io.EOF)
+		t.Assert(items, []Item{{Id: 1, Name: "john"}, {Id: 2, Name: "smith"}})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		decoder := gclient.NewJsonLinesDecoder(strings.NewReader("{\"id\":1}\n{bad}"))
+		var item Item
+		t.AssertNil(decoder.Decode(&item))
+		t.Assert(item.Id, 1)
+		err := decoder.Decode(&item)
+		t.AssertNE(err, nil)
+		t.AssertNE(err, io.EOF)
+	})
+}
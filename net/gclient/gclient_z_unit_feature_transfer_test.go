@@ -0,0 +1,225 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/grand"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Download(t *testing.T) {
+	var (
+		content  = grand.B(3*1024*1024 + 123)
+		modTime  = time.Now().Add(-time.Hour).Truncate(time.Second)
+		ranges   = garray.NewStrArray(true)
+		checksum = sha256.Sum256(content)
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/file", func(r *ghttp.Request) {
+		ranges.Append(r.Header.Get("Range"))
+		r.Response.ServeContent("file.bin", modTime, bytes.NewReader(content))
+	})
+	s.BindHandler("/plain", func(r *ghttp.Request) {
+		r.Response.Write(content)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	var (
+		dir    = gfile.Temp(guid.S())
+		client = g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+	)
+	defer gfile.RemoveAll(dir)
+
+	// Parallel segments.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path     = gfile.Join(dir, "parallel.bin")
+			progress = gtype.NewInt64()
+			total    = gtype.NewInt64()
+		)
+		ranges.Clear()
+		err := client.Download(ctx, "/file", path, gclient.DownloadOption{
+			Concurrency: 4,
+			SegmentSize: 512 * 1024,
+			Checksum:    "sha256:" + hex.EncodeToString(checksum[:]),
+			Progress: func(downloaded, size int64) {
+				progress.Set(downloaded)
+				total.Set(size)
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(bytes.Equal(gfile.GetBytes(path), content), true)
+		t.Assert(progress.Val(), len(content))
+		t.Assert(total.Val(), len(content))
+		t.Assert(ranges.Len(), 5)
+		t.Assert(gfile.Exists(path+".download"), false)
+		t.Assert(gfile.Exists(path+".download.meta"), false)
+	})
+	// Resuming from partial file.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path = gfile.Join(dir, "resume.bin")
+			half = int64(len(content) / 2)
+			meta = g.Map{
+				"url":       "/file",
+				"validator": modTime.UTC().Format(http.TimeFormat),
+				"total":     len(content),
+				"segments": g.Slice{
+					g.Map{"start": 0, "end": len(content) - 1, "done": half},
+				},
+			}
+		)
+		t.AssertNil(gfile.PutBytes(path+".download", content[:half]))
+		t.AssertNil(gfile.PutContents(path+".download.meta", gjson.MustEncodeString(meta)))
+		ranges.Clear()
+		t.AssertNil(client.Download(ctx, "/file", path))
+		t.Assert(bytes.Equal(gfile.GetBytes(path), content), true)
+		t.Assert(ranges.Slice(), g.SliceStr{
+			"bytes=0-0",
+			fmt.Sprintf("bytes=%d-%d", half, len(content)-1),
+		})
+	})
+	// Checksum mismatch.
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Join(dir, "checksum.bin")
+		err := client.Download(ctx, "/file", path, gclient.DownloadOption{
+			Checksum: "md5:00000000000000000000000000000000",
+		})
+		t.AssertNE(err, nil)
+		t.Assert(gfile.Exists(path), false)
+		t.Assert(gfile.Exists(path+".download"), false)
+
+		t.AssertNE(client.Download(ctx, "/file", path, gclient.DownloadOption{Checksum: "crc:1"}), nil)
+	})
+	// Range requests are not supported.
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Join(dir, "plain.bin")
+		t.AssertNil(client.Download(ctx, "/plain", path, gclient.DownloadOption{
+			Concurrency: 4,
+		}))
+		t.Assert(bytes.Equal(gfile.GetBytes(path), content), true)
+	})
+}
+
+func Test_Client_Upload(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		chunks  = bytes.NewBuffer(nil)
+		content = grand.B(100*1024 + 7)
+		dir     = gfile.Temp(guid.S())
+		path    = gfile.Join(dir, "upload.bin")
+	)
+	defer gfile.RemoveAll(dir)
+	s := g.Server(guid.S())
+	s.BindHandler("/form", func(r *ghttp.Request) {
+		file := r.GetUploadFile("file")
+		if file == nil {
+			r.Response.WriteStatus(http.StatusBadRequest)
+			return
+		}
+		r.Response.Writef("%s:%d:%s", file.Filename, file.Size, r.Get("name"))
+	})
+	s.BindHandler("/chunk", func(r *ghttp.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var (
+			contentRange = strings.TrimPrefix(r.Header.Get("Content-Range"), "bytes ")
+			rangeStr, _  = strings.CutSuffix(contentRange, "/"+strconv.Itoa(len(content)))
+			start, _, _  = strings.Cut(rangeStr, "-")
+		)
+		if strconv.Itoa(chunks.Len()) != start {
+			r.Response.WriteStatus(http.StatusConflict)
+			return
+		}
+		chunks.Write(r.GetBody())
+		if chunks.Len() < len(content) {
+			r.Response.WriteHeader(308)
+			return
+		}
+		r.Response.Writef("done:%d", chunks.Len())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gfile.PutBytes(path, content))
+		var uploaded, total int64
+		resp, err := client.Upload(ctx, "/form", path, gclient.UploadOption{
+			Data: g.Map{"name": "john"},
+			Progress: func(n, size int64) {
+				uploaded, total = n, size
+			},
+		})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), fmt.Sprintf("upload.bin:%d:john", len(content)))
+		t.AssertGT(uploaded, len(content))
+		t.Assert(uploaded, total)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			progress  []int64
+			chunkSize = int64(40 * 1024)
+		)
+		resp, err := client.Upload(ctx, "/chunk", path, gclient.UploadOption{
+			ChunkSize: chunkSize,
+			Progress: func(n, size int64) {
+				progress = append(progress, n)
+			},
+		})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), fmt.Sprintf("done:%d", len(content)))
+		t.Assert(bytes.Equal(chunks.Bytes(), content), true)
+		t.Assert(progress, []int64{chunkSize, 2 * chunkSize, int64(len(content))})
+	})
+	// Resuming from offset.
+	gtest.C(t, func(t *gtest.T) {
+		chunks.Reset()
+		chunks.Write(content[:50*1024])
+		resp, err := client.Upload(ctx, "/chunk", path, gclient.UploadOption{
+			ChunkSize: 30 * 1024,
+			Offset:    50 * 1024,
+		})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), fmt.Sprintf("done:%d", len(content)))
+		t.Assert(bytes.Equal(chunks.Bytes(), content), true)
+
+		// Conflicted offset.
+		_, err = client.Upload(ctx, "/chunk", path, gclient.UploadOption{
+			ChunkSize: 30 * 1024,
+			Offset:    10,
+		})
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Transport_Record_Replay(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/user", func(r *ghttp.Request) {
+		r.Response.Header().Set("Set-Cookie", "session=secret")
+		r.Response.Writef("%s:%s", r.Method, r.Get("name"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		for _, name := range []string{"records.har", "records.yaml"} {
+			var (
+				path   = gfile.Temp(guid.S(), name)
+				prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+			)
+			defer gfile.RemoveAll(gfile.Dir(path))
+
+			client := g.Client().Prefix(prefix).SetHeader("Authorization", "Bearer token")
+			recorder := gclient.NewRecorder(path)
+			client.SetTransport(recorder)
+			t.Assert(client.GetContent(ctx, "/user?name=john"), "GET:john")
+			t.Assert(client.PostContent(ctx, "/user", "name=smith"), "POST:smith")
+
+			// The records are written to file on Close.
+			t.Assert(gfile.Exists(path), false)
+			t.AssertNil(recorder.Close())
+			content := gfile.GetContents(path)
+			t.Assert(gfile.Exists(path), true)
+			t.AssertIN("[REDACTED]", content)
+			t.AssertNI("Bearer token", content)
+			t.AssertNI("session=secret", content)
+
+			client = g.Client().Prefix(prefix)
+			client.SetTransport(gclient.MustNewReplayer(path))
+			t.Assert(client.PostContent(ctx, "/user", "name=smith"), "POST:smith")
+			t.Assert(client.GetContent(ctx, "/user?name=john"), "GET:john")
+			_, err := client.Get(ctx, "/user?name=alice")
+			t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		}
+	})
+}
+
+func Test_Client_Transport_Replay_DataPath(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix("http://127.0.0.1")
+		client.SetTransport(gclient.MustNewReplayer(gtest.DataPath("transport", "replay.yaml")))
+
+		resp, err := client.Get(ctx, "/user?id=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.Header.Get("Content-Type"), "application/json")
+		t.Assert(resp.ReadAllString(), `{"id":1,"name":"john"}`)
+		resp.Close()
+
+		// Responses of the same request are served in order, and the last one repeatedly.
+		t.Assert(client.PostContent(ctx, "/user", `{"name":"smith"}`), "created1")
+		t.Assert(client.PostContent(ctx, "/user", `{"name":"smith"}`), "created2")
+		t.Assert(client.PostContent(ctx, "/user", `{"name":"smith"}`), "created2")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gclient.NewReplayer(gtest.DataPath("transport", "none.yaml"))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Client_Transport_Mock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		mock := gclient.NewMockTransport()
+		mock.On("GET", "/user/*").ReplyJson(http.StatusOK, g.Map{"id": 1})
+		mock.On("POST", "/user").Times(1).Reply(http.StatusCreated, "created")
+		mock.On("*", "/error").ReplyError(errors.New("connection refused"))
+
+		client := g.Client().Prefix("http://127.0.0.1")
+		client.SetTransport(mock)
+
+		var res struct {
+			Id int
+		}
+		t.AssertNil(client.GetVar(ctx, "/user/1").Scan(&res))
+		t.Assert(res.Id, 1)
+
+		resp, err := client.Post(ctx, "/user", g.Map{"name": "john"})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.ReadAllString(), "created")
+		resp.Close()
+
+		// The route is limited to match once.
+		_, err = client.Post(ctx, "/user", g.Map{"name": "john"})
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+
+		_, err = client.Delete(ctx, "/error")
+		t.AssertNE(err, nil)
+
+		calls := mock.Calls("POST", "/user")
+		t.Assert(len(calls), 2)
+		t.Assert(string(calls[0].Body), "name=john")
+		t.Assert(len(mock.Calls("GET", "/user/*")), 1)
+		t.Assert(len(mock.Calls()), 4)
+
+		mock.Reset()
+		t.Assert(len(mock.Calls()), 0)
+	})
+	// Reset is concurrent safe with the requests in flight.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg     sync.WaitGroup
+			mock   = gclient.NewMockTransport()
+			client = g.Client().Prefix("http://127.0.0.1")
+		)
+		client.SetTransport(mock)
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, _ = client.Get(ctx, "/user")
+			}()
+			go func() {
+				defer wg.Done()
+				mock.Reset()
+				mock.On("GET", "/user").Reply(http.StatusOK, "ok")
+			}()
+		}
+		wg.Wait()
+		mock.Reset()
+		mock.On("GET", "/user").Reply(http.StatusOK, "ok")
+		t.Assert(client.GetContent(ctx, "/user"), "ok")
+		t.Assert(len(mock.Calls()), 1)
+	})
+}
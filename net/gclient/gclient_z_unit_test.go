@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -625,6 +626,38 @@ func TestClient_DoRequest(t *testing.T) {
 	})
 }
 
+func TestClient_DoRequest_Reader(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/body", func(r *ghttp.Request) {
+		r.Response.Writef("%s:%s", r.Header.Get("Content-Type"), r.GetBody())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	url := fmt.Sprintf("127.0.0.1:%d/body", s.GetListenedPort())
+	// The reader is sent as it is rather than being converted to parameters.
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().DoRequest(ctx, http.MethodPost, url, strings.NewReader("name=john&age=18"))
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "application/octet-stream:name=john&age=18")
+	})
+	// The custom Content-Type is used if it is set.
+	gtest.C(t, func(t *gtest.T) {
+		reader, writer := io.Pipe()
+		go func() {
+			_, _ = writer.Write([]byte(`{"name":"john"}`))
+			_ = writer.Close()
+		}()
+		resp, err := g.Client().ContentJson().DoRequest(ctx, http.MethodPut, url, reader)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), `application/json:{"name":"john"}`)
+	})
+}
+
 func TestClient_RequestVar(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		var (
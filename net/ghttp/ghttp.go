@@ -47,6 +47,9 @@ type (
 		serviceMu        sync.Mutex                // Concurrent safety for operations of attribute service.
 		service          gsvc.Service              // The service for Registry.
 		registrar        gsvc.Registrar            // Registrar for service register.
+		http3AltSvc      string                    // The "Alt-Svc" header value advertising HTTP/3 endpoints.
+		autoCertHandler  http.Handler              // The handler for ACME HTTP-01 challenges.
+		certReloader     *certReloader             // Certificate holder for hot reloading certificate files.
 	}
 
 	// Router object.
@@ -163,6 +166,7 @@ const (
 	specialMethodNameIndex             = "Index"
 	defaultEndpointPort                = 80
 	noPrintInternalRoute               = "internalMiddlewareServerTracing"
+	http3AltSvcMaxAge                  = 86400 // Max age in seconds of the HTTP/3 "Alt-Svc" advertisement.
 )
 
 const (
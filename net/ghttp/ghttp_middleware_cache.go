@@ -0,0 +1,312 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+// CacheOption is the option for response caching middleware.
+type CacheOption struct {
+	// Cache is the storage for full responses.
+	// It uses a dedicated LRU memory cache of the middleware if it is nil.
+	Cache *gcache.Cache
+
+	// CacheSize is the maximum count of responses in the default LRU memory cache,
+	// which is 10000 in default. It does not take effect if Cache is given.
+	CacheSize int
+
+	// TTL is the default duration for storing responses.
+	// The responses are not stored if it is not positive,
+	// unless the route declares meta tag `cache`, eg: `cache:"10s"`.
+	TTL time.Duration
+
+	// WeakETag specifies generating weak ETag like `W/"xxx"` instead of strong ETag.
+	// It can be overwritten by meta tag `etag`, eg: `etag:"weak"`, `etag:"strong"` or `etag:"off"`.
+	WeakETag bool
+
+	// VaryHeaders are the request header names that make up the cache key.
+	// It is merged with meta tag `cacheVary`, eg: `cacheVary:"Accept-Language,Authorization"`.
+	VaryHeaders []string
+
+	// KeyPrefix is the prefix for cache keys, which is "ghttp.response:" in default.
+	KeyPrefix string
+}
+
+// cachedResponse is the response content stored in cache.
+// Its attributes are exported as it might be encoded by cache adapters, like redis.
+type cachedResponse struct {
+	Status       int
+	Header       map[string][]string
+	Body         []byte
+	ETag         string
+	LastModified string
+}
+
+const (
+	defaultCacheKeyPrefix = "ghttp.response:"
+	defaultCacheSize      = 10000
+	etagModeOff           = "off"
+	etagModeWeak          = "weak"
+	etagModeStrong        = "strong"
+)
+
+var (
+	// defaultMiddlewareCache is the response caching middleware using default options.
+	defaultMiddlewareCache = MiddlewareCacheWithOption(CacheOption{})
+
+	// cacheStoredHeaders are the response headers that are stored along with cached response.
+	cacheStoredHeaders = []string{
+		"Content-Type", "Content-Language", "Content-Disposition", "Cache-Control", "Expires", "Vary",
+	}
+)
+
+// MiddlewareCache is a middleware that computes ETag for buffered responses of GET/HEAD requests,
+// and answers conditional requests with header `If-None-Match` or `If-Modified-Since` using status 304.
+//
+// The route can enable storing full responses using meta tags of its request struct, for example:
+//
+//	type GetUserReq struct {
+//	    g.Meta `path:"/user" method:"get" cache:"1m" cacheVary:"Accept-Language" etag:"weak"`
+//	    Id     int
+//	}
+//
+// Note that it should be registered before MiddlewareHandlerResponse,
+// as it works on the response buffer that MiddlewareHandlerResponse produces.
+func MiddlewareCache(r *Request) {
+	defaultMiddlewareCache(r)
+}
+
+// MiddlewareCacheWithOption creates and returns a response caching middleware with custom option.
+// See MiddlewareCache.
+func MiddlewareCacheWithOption(option CacheOption) HandlerFunc {
+	if option.KeyPrefix == "" {
+		option.KeyPrefix = defaultCacheKeyPrefix
+	}
+	if option.CacheSize <= 0 {
+		option.CacheSize = defaultCacheSize
+	}
+	// The default memory cache is created in the first use,
+	// as the middleware with default option is created in package initialization.
+	var (
+		storageOnce sync.Once
+		storage     = option.Cache
+	)
+	return func(r *Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			r.Middleware.Next()
+			return
+		}
+		var (
+			ctx         = r.Context()
+			handler     = r.GetServeHandler()
+			etagMode    = strings.ToLower(handler.GetMetaTag(gtag.ETag))
+			ttl         = option.TTL
+			varyHeaders = option.VaryHeaders
+		)
+		if etagMode == "" {
+			etagMode = etagModeStrong
+			if option.WeakETag {
+				etagMode = etagModeWeak
+			}
+		}
+		if v := handler.GetMetaTag(gtag.Cache); v != "" {
+			if d, err := gtime.ParseDuration(v); err != nil {
+				r.Server.Logger().Warningf(ctx, `invalid cache duration "%s" for route: %+v`, v, err)
+			} else {
+				ttl = d
+			}
+		}
+		if v := handler.GetMetaTag(gtag.CacheVary); v != "" {
+			varyHeaders = append(gstr.SplitAndTrim(v, ","), varyHeaders...)
+		}
+
+		// Serving the stored response if it exists.
+		var (
+			cacheKey     string
+			noCache      = gstr.ContainsI(r.Header.Get("Cache-Control"), "no-cache")
+			storeEnabled = ttl > 0
+		)
+		if storeEnabled {
+			storageOnce.Do(func() {
+				if storage == nil {
+					storage = gcache.New(option.CacheSize)
+				}
+			})
+			cacheKey = option.KeyPrefix + makeResponseCacheKey(r, varyHeaders)
+			if !noCache {
+				if v, err := storage.Get(ctx, cacheKey); err != nil {
+					r.Server.Logger().Warningf(ctx, `get response cache failed: %+v`, err)
+				} else if !v.IsNil() {
+					var cached *cachedResponse
+					if err = v.Scan(&cached); err == nil && cached != nil {
+						writeCachedResponse(r, cached)
+						return
+					}
+				}
+			}
+		}
+
+		r.Middleware.Next()
+
+		// It only handles successful and buffered responses.
+		if r.Response.BytesWritten() > 0 || r.Response.BufferLength() == 0 {
+			return
+		}
+		if r.Response.Status != 0 && r.Response.Status != http.StatusOK {
+			return
+		}
+		header := r.Response.Header()
+		if header.Get("Content-Encoding") != "" {
+			return
+		}
+		etag := header.Get("ETag")
+		if etag == "" && etagMode != etagModeOff {
+			etag = makeETag(r.Response.Buffer(), etagMode == etagModeWeak)
+			header.Set("ETag", etag)
+		}
+		if len(varyHeaders) > 0 {
+			header.Set("Vary", strings.Join(varyHeaders, ", "))
+		}
+		cached := &cachedResponse{
+			Status:       http.StatusOK,
+			Header:       make(map[string][]string),
+			Body:         r.Response.Buffer(),
+			ETag:         etag,
+			LastModified: header.Get("Last-Modified"),
+		}
+		if storeEnabled && isResponseStorable(header) {
+			if cached.LastModified == "" {
+				cached.LastModified = time.Now().UTC().Format(http.TimeFormat)
+				header.Set("Last-Modified", cached.LastModified)
+			}
+			for _, name := range cacheStoredHeaders {
+				if values := header.Values(name); len(values) > 0 {
+					cached.Header[name] = values
+				}
+			}
+			cached.Body = make([]byte, r.Response.BufferLength())
+			copy(cached.Body, r.Response.Buffer())
+			if err := storage.Set(ctx, cacheKey, cached, ttl); err != nil {
+				r.Server.Logger().Warningf(ctx, `set response cache failed: %+v`, err)
+			}
+		}
+		if isNotModified(r.Request, cached.ETag, cached.LastModified) {
+			r.Response.ClearBuffer()
+			r.Response.WriteHeader(http.StatusNotModified)
+		}
+	}
+}
+
+// writeCachedResponse writes the cached response to client,
+// or status 304 if the client's cached version is still valid.
+func writeCachedResponse(r *Request, cached *cachedResponse) {
+	header := r.Response.Header()
+	for name, values := range cached.Header {
+		header[name] = values
+	}
+	if cached.ETag != "" {
+		header.Set("ETag", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		header.Set("Last-Modified", cached.LastModified)
+	}
+	if isNotModified(r.Request, cached.ETag, cached.LastModified) {
+		r.Response.ClearBuffer()
+		r.Response.WriteHeader(http.StatusNotModified)
+		return
+	}
+	r.Response.WriteHeader(cached.Status)
+	r.Response.SetBuffer(cached.Body)
+}
+
+// isNotModified checks whether the client's cached version matches the given `etag` and `lastModified`.
+// Note that `If-None-Match` has precedence over `If-Modified-Since` if both present.
+func isNotModified(r *http.Request, etag, lastModified string) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if etag == "" {
+			return false
+		}
+		for _, v := range gstr.SplitAndTrim(ifNoneMatch, ",") {
+			if v == "*" || trimWeakETag(v) == trimWeakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && lastModified != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		modified, err := http.ParseTime(lastModified)
+		if err != nil {
+			return false
+		}
+		return !modified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// isResponseStorable checks the Cache-Control header of response whether it allows storing.
+func isResponseStorable(header http.Header) bool {
+	cacheControl := strings.ToLower(header.Get("Cache-Control"))
+	return !strings.Contains(cacheControl, "no-store") && !strings.Contains(cacheControl, "private")
+}
+
+// makeETag creates and returns the ETag for given content.
+func makeETag(content []byte, weak bool) string {
+	sum := sha1.Sum(content)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	if weak {
+		return "W/" + etag
+	}
+	return etag
+}
+
+// trimWeakETag removes the weak prefix of ETag for weak comparison.
+func trimWeakETag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
+
+// makeResponseCacheKey creates cache key for the request using route, path, sorted query and vary headers.
+func makeResponseCacheKey(r *Request, varyHeaders []string) string {
+	var builder strings.Builder
+	if handler := r.GetServeHandler(); handler != nil && handler.Handler != nil && handler.Handler.Router != nil {
+		builder.WriteString(handler.Handler.Router.Uri)
+		builder.WriteString("@")
+	}
+	builder.WriteString(r.GetHost())
+	builder.WriteString(r.URL.Path)
+	builder.WriteString("?")
+	builder.WriteString(r.URL.Query().Encode())
+	if len(varyHeaders) > 0 {
+		names := make([]string, len(varyHeaders))
+		for i, name := range varyHeaders {
+			names[i] = http.CanonicalHeaderKey(name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			builder.WriteString("|")
+			builder.WriteString(name)
+			builder.WriteString("=")
+			builder.WriteString(r.Header.Get(name))
+		}
+	}
+	return builder.String()
+}
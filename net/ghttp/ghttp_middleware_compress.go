@@ -0,0 +1,421 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp/internal/response"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// CompressOption is the option for compression middleware.
+type CompressOption struct {
+	// Encodings are the supported content encodings in server preference order,
+	// which is used when client accepts more than one encoding with the same q-value.
+	// It is `br, zstd, gzip, deflate` in default.
+	//
+	// Note that the encoding is ignored if it is not registered. The gzip and deflate encodings
+	// are registered in default, and the others can be registered by RegisterCompressEncoder,
+	// like importing package "github.com/gogf/gf/contrib/compress/brotli/v2" for br.
+	Encodings []string
+
+	// MinLength is the minimum length of buffered response body to be compressed.
+	// It is 1024 in default. It does not take effect for streaming responses.
+	MinLength int
+
+	// ContentTypes are the allowed media types for compression.
+	// The item ending with "/" matches any media type with the prefix, eg: "text/".
+	// It uses the default list of textual media types if empty.
+	ContentTypes []string
+
+	// Streaming enables compressing streaming responses on the fly,
+	// which are written using Response.Flush, like Server-Sent Events.
+	// The streaming responses are not compressed if it is false.
+	Streaming bool
+}
+
+const (
+	EncodingBrotli  = "br"
+	EncodingZstd    = "zstd"
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+)
+
+const (
+	encodingIdentity         = "identity"
+	defaultCompressMinLength = 1024
+)
+
+var (
+	// defaultCompressEncodings are the default supported encodings in server preference order.
+	defaultCompressEncodings = []string{EncodingBrotli, EncodingZstd, EncodingGzip, EncodingDeflate}
+
+	// defaultCompressContentTypes are the default media types that can be compressed.
+	defaultCompressContentTypes = []string{
+		"text/",
+		"application/json",
+		"application/javascript",
+		"application/x-javascript",
+		"application/xml",
+		"application/wasm",
+		"image/svg+xml",
+	}
+
+	// defaultMiddlewareCompress is the compression middleware using default options.
+	defaultMiddlewareCompress = MiddlewareCompressWithOption(CompressOption{})
+)
+
+// CompressEncoder is the writer compressing data into the underlying writer.
+type CompressEncoder interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// CompressEncoderFunc creates and returns an encoder compressing data into `writer`.
+type CompressEncoderFunc func(writer io.Writer) (CompressEncoder, error)
+
+var (
+	// compressEncoders is the registered encoder creating functions, the key is content encoding.
+	compressEncoders = map[string]CompressEncoderFunc{
+		EncodingGzip: func(writer io.Writer) (CompressEncoder, error) {
+			return gzip.NewWriter(writer), nil
+		},
+		EncodingDeflate: func(writer io.Writer) (CompressEncoder, error) {
+			// The "deflate" content encoding is actually the zlib format, see RFC 9110.
+			return zlib.NewWriter(writer), nil
+		},
+	}
+	compressEncodersMu sync.RWMutex
+)
+
+// RegisterCompressEncoder registers encoder creating function `f` for content encoding `encoding`,
+// which is used by the compression middleware. It overwrites the existing one of `encoding`.
+func RegisterCompressEncoder(encoding string, f CompressEncoderFunc) {
+	compressEncodersMu.Lock()
+	defer compressEncodersMu.Unlock()
+	compressEncoders[strings.ToLower(encoding)] = f
+}
+
+// getCompressEncoderFunc returns the registered encoder creating function of `encoding`.
+func getCompressEncoderFunc(encoding string) CompressEncoderFunc {
+	compressEncodersMu.RLock()
+	defer compressEncodersMu.RUnlock()
+	return compressEncoders[encoding]
+}
+
+// MiddlewareCompress is a middleware that compresses HTTP response using content encoding negotiated
+// with the `Accept-Encoding` header of request, which supports gzip, deflate and the registered
+// encodings like br and zstd, see RegisterCompressEncoder.
+//
+// It respects the q-values in `Accept-Encoding`, and sets `Vary: Accept-Encoding` for responses
+// that the negotiation result might affect. Note that it does not compress responses if:
+// 1. The response is already compressed (Content-Encoding header is set)
+// 2. The client does not accept any supported encoding
+// 3. The response body length is too small (less than 1KB)
+// 4. The media type of the response is not textual
+//
+// Use MiddlewareCompressWithOption for custom options, like compressing streaming responses.
+func MiddlewareCompress(r *Request) {
+	defaultMiddlewareCompress(r)
+}
+
+// MiddlewareCompressWithOption creates and returns a compression middleware with custom option.
+// See MiddlewareCompress.
+func MiddlewareCompressWithOption(option CompressOption) HandlerFunc {
+	if len(option.Encodings) == 0 {
+		option.Encodings = defaultCompressEncodings
+	}
+	if option.MinLength <= 0 {
+		option.MinLength = defaultCompressMinLength
+	}
+	if len(option.ContentTypes) == 0 {
+		option.ContentTypes = defaultCompressContentTypes
+	}
+	return func(r *Request) {
+		// It does not compress websocket connections.
+		if r.Header.Get("Upgrade") != "" {
+			r.Middleware.Next()
+			return
+		}
+		encoding := negotiateContentEncoding(r.Header.Get("Accept-Encoding"), option.Encodings)
+		if encoding == "" {
+			r.Middleware.Next()
+			addVaryHeader(r.Response.Header(), "Accept-Encoding")
+			return
+		}
+
+		// Streaming responses are compressed on the fly by replacing the underlying writer.
+		var streamWriter *compressResponseWriter
+		if option.Streaming {
+			streamWriter = &compressResponseWriter{
+				ResponseWriter: r.Response.Writer.ResponseWriter,
+				encoding:       encoding,
+				option:         &option,
+			}
+			r.Response.Writer.ResponseWriter = streamWriter
+		}
+
+		r.Middleware.Next()
+
+		if streamWriter != nil {
+			if streamWriter.decided {
+				// The response has been partly sent, it sends the rest and closes the encoder.
+				r.Response.Flush()
+				if err := streamWriter.Close(); err != nil {
+					r.Server.Logger().Warningf(r.Context(), `%s compression failed: %+v`, encoding, err)
+				}
+				return
+			}
+			r.Response.Writer.ResponseWriter = streamWriter.ResponseWriter
+		}
+
+		header := r.Response.Header()
+		addVaryHeader(header, "Accept-Encoding")
+		if r.Response.BytesWritten() > 0 || !response.BodyAllowedForStatus(r.Response.Status) {
+			return
+		}
+		if header.Get("Content-Encoding") != "" {
+			return
+		}
+		buffer := r.Response.Buffer()
+		if len(buffer) < option.MinLength {
+			return
+		}
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", http.DetectContentType(buffer))
+		}
+		if !isCompressibleContentType(header.Get("Content-Type"), option.ContentTypes) {
+			return
+		}
+		compressed, err := compressBytes(encoding, buffer)
+		if err != nil {
+			r.Server.Logger().Warningf(r.Context(), `%s compression failed: %+v`, encoding, err)
+			return
+		}
+		setCompressedHeaders(header, encoding)
+		r.Response.SetBuffer(compressed)
+	}
+}
+
+// compressResponseWriter wraps http.ResponseWriter for compressing streaming responses.
+// It decides whether compressing the response when the header is written.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	option   *CompressOption
+	encoder  CompressEncoder
+	decided  bool
+}
+
+// WriteHeader implements the interface of http.ResponseWriter.WriteHeader.
+func (w *compressResponseWriter) WriteHeader(status int) {
+	w.decide(status)
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements the interface of http.ResponseWriter.Write.
+func (w *compressResponseWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decide(http.StatusOK)
+	}
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.encoder.Write(data)
+}
+
+// Flush implements the interface of http.Flusher.
+func (w *compressResponseWriter) Flush() {
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements the interface of http.Hijacker.
+func (w *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, gerror.New(`underlying response writer does not implement http.Hijacker`)
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the underlying http.ResponseWriter, which is used by http.ResponseController.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close flushes and closes the encoder if the response is being compressed.
+func (w *compressResponseWriter) Close() error {
+	if w.encoder == nil {
+		return nil
+	}
+	return w.encoder.Close()
+}
+
+// decide checks whether compressing the response according to its status and headers.
+func (w *compressResponseWriter) decide(status int) {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	header := w.Header()
+	addVaryHeader(header, "Accept-Encoding")
+	if !response.BodyAllowedForStatus(status) || header.Get("Content-Encoding") != "" {
+		return
+	}
+	if !isCompressibleContentType(header.Get("Content-Type"), w.option.ContentTypes) {
+		return
+	}
+	encoder, err := newCompressEncoder(w.encoding, w.ResponseWriter)
+	if err != nil {
+		return
+	}
+	w.encoder = encoder
+	setCompressedHeaders(header, w.encoding)
+}
+
+// negotiateContentEncoding parses `acceptEncoding` with q-values and returns the best registered
+// encoding in `supported`. It returns empty string if none is acceptable or identity is preferred.
+func negotiateContentEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	var (
+		qValues  = make(map[string]float64)
+		wildcard = -1.0
+	)
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		var (
+			parts  = strings.Split(item, ";")
+			name   = strings.ToLower(strings.TrimSpace(parts[0]))
+			qValue = 1.0
+		)
+		if name == "" {
+			continue
+		}
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if len(param) > 2 && (param[0] == 'q' || param[0] == 'Q') && param[1] == '=' {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					qValue = v
+				}
+			}
+		}
+		if name == "*" {
+			wildcard = qValue
+			continue
+		}
+		qValues[name] = qValue
+	}
+	var (
+		bestEncoding string
+		bestQValue   float64
+	)
+	for _, encoding := range supported {
+		if getCompressEncoderFunc(encoding) == nil {
+			continue
+		}
+		qValue, ok := qValues[encoding]
+		if !ok {
+			qValue = wildcard
+		}
+		if qValue > bestQValue {
+			bestEncoding, bestQValue = encoding, qValue
+		}
+	}
+	if v, ok := qValues[encodingIdentity]; ok && v > bestQValue {
+		return ""
+	}
+	return bestEncoding
+}
+
+// isCompressibleContentType checks whether the media type of `contentType` is allowed by `allowed`.
+func isCompressibleContentType(contentType string, allowed []string) bool {
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, item := range allowed {
+		if strings.HasSuffix(item, "/") {
+			if strings.HasPrefix(mediaType, item) {
+				return true
+			}
+		} else if mediaType == item {
+			return true
+		}
+	}
+	// Structured syntax suffixes, eg: application/problem+json.
+	return strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml")
+}
+
+// setCompressedHeaders sets the response headers for compressed content.
+func setCompressedHeaders(header http.Header, encoding string) {
+	header.Set("Content-Encoding", encoding)
+	header.Del("Content-Length")
+	// The compressed representation is not byte-for-byte identical any more.
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+}
+
+// addVaryHeader adds `name` to header `Vary` if it does not exist.
+func addVaryHeader(header http.Header, name string) {
+	for _, value := range header.Values("Vary") {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "*" || gstr.Equal(item, name) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", name)
+}
+
+// newCompressEncoder creates and returns an encoder of `encoding` writing into `writer`.
+func newCompressEncoder(encoding string, writer io.Writer) (CompressEncoder, error) {
+	f := getCompressEncoderFunc(encoding)
+	if f == nil {
+		return nil, gerror.Newf(`unsupported content encoding "%s"`, encoding)
+	}
+	return f(writer)
+}
+
+// compressBytes compresses `data` using `encoding`.
+func compressBytes(encoding string, data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder, err := newCompressEncoder(encoding, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = encoder.Write(data); err != nil {
+		_ = encoder.Close()
+		return nil, gerror.Wrapf(err, `%s encoder write failed`, encoding)
+	}
+	if err = encoder.Close(); err != nil {
+		return nil, gerror.Wrapf(err, `%s encoder close failed`, encoding)
+	}
+	return buffer.Bytes(), nil
+}
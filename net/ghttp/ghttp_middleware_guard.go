@@ -0,0 +1,160 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+// GuardOption is the option for request guarding middleware.
+// The options are the defaults for all the routes that the middleware is bound to,
+// which can be overwritten by meta tags of each route.
+type GuardOption struct {
+	// MaxBodySize is the max request body size in bytes, which overwrites ClientMaxBodySize of the server.
+	// It can be overwritten by meta tag `maxBody`, eg: `maxBody:"50MB"`.
+	MaxBodySize int64
+
+	// Timeout is the max duration for handling the request, the request context is canceled after timeout.
+	// It can be overwritten by meta tag `timeout`, eg: `timeout:"3s"`.
+	Timeout time.Duration
+
+	// TimeoutStatus is the HTTP status for timeout requests, which is 504 in default.
+	TimeoutStatus int
+
+	// MaxConcurrent is the max number of requests that each route handles concurrently,
+	// the exceeding requests are rejected immediately.
+	// It can be overwritten by meta tag `maxConcurrent`, eg: `maxConcurrent:"100"`.
+	MaxConcurrent int
+
+	// OverloadStatus is the HTTP status for requests rejected by MaxConcurrent, which is 503 in default.
+	OverloadStatus int
+}
+
+var (
+	// defaultMiddlewareGuard is the request guarding middleware using default options.
+	defaultMiddlewareGuard = MiddlewareGuardWithOption(GuardOption{})
+)
+
+// MiddlewareGuard is a middleware that limits the request body size, handling duration
+// and concurrency for each route, which are configured using meta tags of its request struct, for example:
+//
+//	type UploadReq struct {
+//	    g.Meta `path:"/upload" method:"post" maxBody:"50MB" timeout:"30s" maxConcurrent:"10"`
+//	    File   *ghttp.UploadFile
+//	}
+//
+// Note that the timeout cancels the request context, which requires the handler respecting the context.
+// It should be registered after MiddlewareHandlerResponse, so that the guarding errors
+// are responded by MiddlewareHandlerResponse:
+//
+//	s.Use(ghttp.MiddlewareHandlerResponse, ghttp.MiddlewareGuard)
+func MiddlewareGuard(r *Request) {
+	defaultMiddlewareGuard(r)
+}
+
+// MiddlewareGuardWithOption creates and returns a request guarding middleware with custom option,
+// which is usually used as group middleware for the default limits of the group.
+// See MiddlewareGuard.
+func MiddlewareGuardWithOption(option GuardOption) HandlerFunc {
+	if option.TimeoutStatus == 0 {
+		option.TimeoutStatus = http.StatusGatewayTimeout
+	}
+	if option.OverloadStatus == 0 {
+		option.OverloadStatus = http.StatusServiceUnavailable
+	}
+	// Concurrent request counters for routes, which uses handler item id as key.
+	var counters = gmap.NewKVMap[int, *gtype.Int](true)
+	return func(r *Request) {
+		var (
+			ctx           = r.Context()
+			handler       = r.GetServeHandler()
+			maxBodySize   = option.MaxBodySize
+			timeout       = option.Timeout
+			maxConcurrent = option.MaxConcurrent
+		)
+		if handler == nil || handler.Handler == nil {
+			r.Middleware.Next()
+			return
+		}
+		if v := handler.GetMetaTag(gtag.MaxBody); v != "" {
+			maxBodySize = gfile.StrToSize(v)
+		}
+		if v := handler.GetMetaTag(gtag.Timeout); v != "" {
+			if d, err := gtime.ParseDuration(v); err != nil {
+				r.Server.Logger().Warningf(ctx, `invalid timeout "%s" for route: %+v`, v, err)
+			} else {
+				timeout = d
+			}
+		}
+		if v := handler.GetMetaTag(gtag.MaxConcurrent); v != "" {
+			maxConcurrent = gconv.Int(v)
+		}
+
+		// Concurrency limit.
+		if maxConcurrent > 0 {
+			counter := counters.GetOrSetFuncLock(handler.Handler.Id, func() *gtype.Int {
+				return gtype.NewInt()
+			})
+			defer counter.Add(-1)
+			if counter.Add(1) > maxConcurrent {
+				r.Response.WriteHeader(option.OverloadStatus)
+				r.SetError(gerror.NewCode(gcode.CodeServerBusy, `too many concurrent requests`))
+				return
+			}
+		}
+
+		// Body size limit, which replaces the server-wide limit if the body is not read yet.
+		if maxBodySize > 0 && r.originBody != nil && r.bodyContent == nil {
+			r.Body = http.MaxBytesReader(r.Response.RawWriter(), r.originBody, maxBodySize)
+		}
+
+		// Timeout.
+		var timeoutCtx context.Context
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			timeoutCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+			r.SetCtx(timeoutCtx)
+		}
+
+		r.Middleware.Next()
+
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(r.GetError(), &maxBytesErr):
+			r.Response.ClearBuffer()
+			r.Response.WriteHeader(http.StatusRequestEntityTooLarge)
+			r.SetError(gerror.WrapCodef(
+				gcode.CodeInvalidRequest, r.GetError(), `request body exceeds the limit %d bytes`, maxBytesErr.Limit,
+			))
+
+		case timeoutCtx != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded):
+			if r.Response.BytesWritten() == 0 {
+				r.Response.ClearBuffer()
+				r.Response.WriteHeader(option.TimeoutStatus)
+				r.SetError(gerror.NewCodef(gcode.CodeServerBusy, `request timeout after %s`, timeout))
+			}
+		}
+		if timeoutCtx != nil {
+			// The following middlewares should not be affected by the timeout.
+			r.SetCtx(gctx.NeverDone(r.Context()))
+		}
+	}
+}
@@ -24,6 +24,8 @@ import (
 //	group.Group("/api", func(group *ghttp.RouterGroup) {
 //	    group.Middleware(ghttp.MiddlewareGzip) // Enable GZIP for /api routes
 //	})
+//
+// See MiddlewareCompress for more content encodings and streaming response compression.
 func MiddlewareGzip(r *Request) {
 	// Skip compression if client doesn't accept gzip
 	if !acceptsGzip(r.Request) {
@@ -0,0 +1,169 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/goai"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// OpenApiValidationOption is the option for OpenAPI validation middleware.
+type OpenApiValidationOption struct {
+	// Document is the file path or content of the OpenAPI document in json or yaml format.
+	// It uses the OpenAPI document generated from the routes of the server if it's empty.
+	Document string
+
+	// Strict rejects the query parameters and body fields that are not defined in the document.
+	Strict bool
+
+	// ValidateResponse validates the response data against the document, which is usually enabled in development.
+	// The invalid response is replaced with an internal error, and the validation error is logged.
+	ValidateResponse bool
+}
+
+var (
+	// defaultMiddlewareOpenApiValidation is the OpenAPI validation middleware using default options.
+	defaultMiddlewareOpenApiValidation = MiddlewareOpenApiValidationWithOption(OpenApiValidationOption{})
+)
+
+// MiddlewareOpenApiValidation is a middleware that validates the path, query, header, cookie parameters
+// and body of requests against the OpenAPI document generated from the routes of the server.
+// The request failing the validation is rejected with status 400 and a gvalid.Error,
+// whose Maps function returns the errors of all invalid fields.
+//
+// It should be registered after MiddlewareHandlerResponse, so that the validation errors
+// are responded by MiddlewareHandlerResponse:
+//
+//	s.Use(ghttp.MiddlewareHandlerResponse, ghttp.MiddlewareOpenApiValidation)
+func MiddlewareOpenApiValidation(r *Request) {
+	defaultMiddlewareOpenApiValidation(r)
+}
+
+// MiddlewareOpenApiValidationWithOption creates and returns an OpenAPI validation middleware with custom option,
+// which is usually used for validating requests of handlers against an existing OpenAPI document.
+// See MiddlewareOpenApiValidation.
+func MiddlewareOpenApiValidationWithOption(option OpenApiValidationOption) HandlerFunc {
+	var (
+		once      sync.Once
+		validator *openApiValidator
+		loadErr   error
+	)
+	return func(r *Request) {
+		// The document is loaded in the first request, as the server routes are ready then.
+		once.Do(func() {
+			validator, loadErr = loadOpenApiValidator(r.Server, option)
+			if loadErr != nil {
+				loadErr = gerror.WrapCode(gcode.CodeInvalidConfiguration, loadErr, `load OpenAPI document failed`)
+				r.Server.Logger().Errorf(r.Context(), `%+v`, loadErr)
+			}
+		})
+		if loadErr != nil {
+			r.Response.WriteHeader(http.StatusInternalServerError)
+			r.SetError(loadErr)
+			return
+		}
+		route, pathParams := validator.Match(r.Method, r.URL.Path)
+		if route == nil {
+			r.Middleware.Next()
+			return
+		}
+		if err := validator.ValidateRequest(r, route, pathParams); err != nil {
+			r.Response.WriteHeader(http.StatusBadRequest)
+			r.SetError(err)
+			return
+		}
+
+		r.Middleware.Next()
+
+		if !option.ValidateResponse || r.GetError() != nil {
+			return
+		}
+		data, ok := openApiResponseData(r)
+		if !ok {
+			return
+		}
+		status := r.Response.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if err := validator.ValidateResponse(r.Context(), route, status, data); err != nil {
+			err = gerror.WrapCode(gcode.CodeInternalError, err, `response validation failed`)
+			r.Server.Logger().Warningf(r.Context(), `%+v`, err)
+			r.Response.ClearBuffer()
+			r.Response.WriteHeader(http.StatusInternalServerError)
+			r.SetError(err)
+		}
+	}
+}
+
+// loadOpenApiValidator loads the OpenAPI document by option and creates the validator.
+func loadOpenApiValidator(s *Server, option OpenApiValidationOption) (*openApiValidator, error) {
+	var (
+		document *gjson.Json
+		content  []byte
+		err      error
+	)
+	switch {
+	case option.Document == "":
+		oai := s.GetOpenApi()
+		if s.config.OpenApiPath == "" {
+			// The server does not generate the document, it generates a private one.
+			oai = goai.New()
+			if err = s.addOpenApiRoutes(oai); err != nil {
+				return nil, err
+			}
+		}
+		if content, err = json.Marshal(oai); err != nil {
+			return nil, err
+		}
+		document, err = gjson.LoadContent(content)
+
+	case gfile.Exists(option.Document):
+		document, err = gjson.Load(option.Document)
+
+	default:
+		document, err = gjson.LoadContent([]byte(option.Document))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newOpenApiValidator(document.Map(), option.Strict)
+}
+
+// openApiResponseData retrieves the response data for validation,
+// which is the handler response object or the json content of the response buffer.
+func openApiResponseData(r *Request) (data any, ok bool) {
+	var content []byte
+	if res := r.GetHandlerResponse(); res != nil {
+		var err error
+		if content, err = json.Marshal(res); err != nil {
+			return nil, false
+		}
+	} else {
+		mediaType, _, _ := mime.ParseMediaType(r.Response.Header().Get("Content-Type"))
+		if !gstr.Contains(mediaType, "json") || r.Response.BufferLength() == 0 {
+			return nil, false
+		}
+		content = r.Response.Buffer()
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, false
+	}
+	return data, true
+}
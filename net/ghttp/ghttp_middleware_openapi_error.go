@@ -0,0 +1,145 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gvalid"
+)
+
+// openApiValidationError is the gvalid.Error of OpenAPI validation,
+// which reports the fields by their names instead of aliases in validation.
+type openApiValidationError struct {
+	code   gcode.Code
+	fields []openApiErrorField         // Invalid fields in sequence.
+	errors map[string]map[string]error // Error map: map[field]map[rule]message
+}
+
+// openApiErrorField is a validated field and its rules in sequence.
+type openApiErrorField struct {
+	Name  string   // Field name, eg: X-Trace-Id, tags.1
+	Alias string   // Alias name in validation.
+	Rules []string // Rules of the field.
+}
+
+var _ gvalid.Error = (*openApiValidationError)(nil)
+
+// newOpenApiValidationError converts the validation error `err` of aliases to the one of field names.
+func newOpenApiValidationError(err gvalid.Error, fields []openApiErrorField) *openApiValidationError {
+	var (
+		maps = err.Maps()
+		verr = &openApiValidationError{
+			code:   err.Code(),
+			fields: make([]openApiErrorField, 0),
+			errors: make(map[string]map[string]error),
+		}
+	)
+	for _, field := range fields {
+		ruleErrorMap, ok := maps[field.Alias]
+		if !ok {
+			continue
+		}
+		errorMap := make(map[string]error, len(ruleErrorMap))
+		for rule, ruleErr := range ruleErrorMap {
+			errorMap[rule] = gerror.NewWithOption(gerror.Option{
+				Text: strings.ReplaceAll(ruleErr.Error(), field.Alias, field.Name),
+				Code: gerror.Code(ruleErr),
+			})
+		}
+		verr.fields = append(verr.fields, field)
+		verr.errors[field.Name] = errorMap
+	}
+	return verr
+}
+
+// Code returns the error code of current validation error.
+func (e *openApiValidationError) Code() gcode.Code {
+	return e.code
+}
+
+// Current is alias of FirstError, which implements interface gerror.iCurrent.
+func (e *openApiValidationError) Current() error {
+	return e.FirstError()
+}
+
+// Error implements interface of error.Error.
+func (e *openApiValidationError) Error() string {
+	return e.String()
+}
+
+// FirstItem returns the field name and error messages for the first invalid field.
+func (e *openApiValidationError) FirstItem() (key string, messages map[string]error) {
+	if len(e.fields) == 0 {
+		return "", nil
+	}
+	return e.fields[0].Name, e.errors[e.fields[0].Name]
+}
+
+// FirstRule returns the first error rule and message.
+func (e *openApiValidationError) FirstRule() (rule string, err error) {
+	if len(e.fields) == 0 {
+		return "", nil
+	}
+	var errorMap = e.errors[e.fields[0].Name]
+	for _, rule = range e.fields[0].Rules {
+		rule = strings.TrimSpace(strings.Split(rule, ":")[0])
+		if err = errorMap[rule]; err != nil {
+			return rule, err
+		}
+	}
+	return "", nil
+}
+
+// FirstError returns the first error message.
+func (e *openApiValidationError) FirstError() (err error) {
+	_, err = e.FirstRule()
+	return
+}
+
+// Items returns the error items of invalid fields in sequence.
+func (e *openApiValidationError) Items() (items []map[string]map[string]error) {
+	items = make([]map[string]map[string]error, 0, len(e.fields))
+	for _, field := range e.fields {
+		items = append(items, map[string]map[string]error{
+			field.Name: e.errors[field.Name],
+		})
+	}
+	return
+}
+
+// Map returns the error messages of the first invalid field.
+func (e *openApiValidationError) Map() map[string]error {
+	_, m := e.FirstItem()
+	return m
+}
+
+// Maps returns all error messages as map.
+func (e *openApiValidationError) Maps() map[string]map[string]error {
+	return e.errors
+}
+
+// String returns all error messages as string, multiple error messages joined using char ';'.
+func (e *openApiValidationError) String() string {
+	return strings.Join(e.Strings(), "; ")
+}
+
+// Strings returns all error messages in sequence.
+func (e *openApiValidationError) Strings() (errs []string) {
+	errs = make([]string, 0)
+	for _, field := range e.fields {
+		for _, rule := range field.Rules {
+			rule = strings.TrimSpace(strings.Split(rule, ":")[0])
+			if err, ok := e.errors[field.Name][rule]; ok {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,887 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gvalid"
+)
+
+const (
+	openApiRuleType      = "oai-type"       // Type rule for json values, eg: oai-type:integer,null
+	openApiRuleParamType = "oai-param-type" // Type rule for string values from parameters and forms.
+	openApiRuleUnknown   = "oai-unknown"    // Rule rejecting unknown fields.
+	openApiRuleGT        = "oai-gt"         // Exclusive minimum rule, eg: oai-gt:0
+	openApiRuleLT        = "oai-lt"         // Exclusive maximum rule, eg: oai-lt:100
+	openApiRuleItems     = "oai-items"      // Array size rule, eg: oai-items:1,10
+	openApiRuleDateTime  = "oai-date-time"  // RFC3339 date-time rule.
+	openApiBodyFieldName = "body"           // Field name for the request body itself.
+	openApiMaxRefDepth   = 32               // Max depth resolving the schema references.
+)
+
+var (
+	// openApiMethods is the operation method names of path item.
+	openApiMethods = gset.NewStrSetFrom([]string{
+		"get", "put", "post", "delete", "options", "head", "patch", "trace",
+	})
+
+	// openApiFormatRules maps the string formats to builtin validation rules.
+	openApiFormatRules = map[string]string{
+		"email":     "email",
+		"uri":       "url",
+		"url":       "url",
+		"ipv4":      "ipv4",
+		"ipv6":      "ipv6",
+		"date":      "date",
+		"date-time": openApiRuleDateTime,
+		"uuid":      `regex:^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+	}
+
+	// openApiRuleFuncs is the custom validation rules for OpenAPI schema constraints
+	// that builtin rules do not support.
+	openApiRuleFuncs = map[string]gvalid.RuleFunc{
+		openApiRuleType:      openApiRuleFuncType,
+		openApiRuleParamType: openApiRuleFuncType,
+		openApiRuleUnknown:   openApiRuleFuncUnknown,
+		openApiRuleGT:        openApiRuleFuncRange,
+		openApiRuleLT:        openApiRuleFuncRange,
+		openApiRuleItems:     openApiRuleFuncItems,
+		openApiRuleDateTime:  openApiRuleFuncDateTime,
+	}
+)
+
+// openApiValidator validates requests and responses against OpenAPI document.
+type openApiValidator struct {
+	root         map[string]any           // The OpenAPI document.
+	strict       bool                     // Rejects the unknown parameters and fields.
+	staticRoutes map[string]*openApiRoute // Routes without path parameters, method:path => route.
+	routes       []*openApiRoute          // Routes with path parameters.
+}
+
+// openApiRoute is an operation of the OpenAPI document.
+type openApiRoute struct {
+	Method     string         // Upper case method.
+	Path       string         // Path template.
+	Pattern    *regexp.Regexp // Pattern matching the request path, nil for static route.
+	Names      []string       // Path parameter names in pattern.
+	Operation  map[string]any // Operation object.
+	Parameters []map[string]any
+}
+
+// openApiRules collects the validation rules and data of fields in order.
+type openApiRules struct {
+	rules      *gmap.ListKVMap[string, []string]
+	data       map[string]any
+	strict     bool
+	fromString bool // Values are from parameters or forms, which are strings.
+}
+
+func newOpenApiValidator(root map[string]any, strict bool) (*openApiValidator, error) {
+	paths, _ := root["paths"].(map[string]any)
+	if len(paths) == 0 {
+		return nil, gerror.New(`no paths defined in OpenAPI document`)
+	}
+	v := &openApiValidator{
+		root:         root,
+		strict:       strict,
+		staticRoutes: make(map[string]*openApiRoute),
+	}
+	// The path prefix of the first server url, eg: /api/v1.
+	var basePath string
+	if servers, _ := root["servers"].([]any); len(servers) > 0 {
+		if server, ok := servers[0].(map[string]any); ok {
+			if u, err := url.Parse(gconv.String(server["url"])); err == nil && !gstr.Contains(u.Path, "{") {
+				basePath = strings.TrimRight(u.Path, "/")
+			}
+		}
+	}
+	for _, path := range openApiSortedKeys(paths) {
+		pathItem := v.resolve(paths[path])
+		if pathItem == nil {
+			continue
+		}
+		fullPath := basePath + path
+		if fullPath != "/" {
+			fullPath = strings.TrimRight(fullPath, "/")
+		}
+		pattern, names := openApiPathPattern(fullPath)
+		for method, item := range pathItem {
+			operation, ok := item.(map[string]any)
+			if !ok || !openApiMethods.Contains(strings.ToLower(method)) {
+				continue
+			}
+			route := &openApiRoute{
+				Method:     strings.ToUpper(method),
+				Path:       fullPath,
+				Pattern:    pattern,
+				Names:      names,
+				Operation:  operation,
+				Parameters: v.parameters(pathItem["parameters"], operation["parameters"]),
+			}
+			if pattern == nil {
+				v.staticRoutes[route.Method+":"+fullPath] = route
+			} else {
+				v.routes = append(v.routes, route)
+			}
+		}
+	}
+	return v, nil
+}
+
+// Match returns the route and path parameters matching the request.
+// It returns nil if no route matches.
+func (v *openApiValidator) Match(method, path string) (*openApiRoute, map[string]string) {
+	method = strings.ToUpper(method)
+	if route, ok := v.staticRoutes[method+":"+path]; ok {
+		return route, nil
+	}
+	for _, route := range v.routes {
+		if route.Method != method {
+			continue
+		}
+		if match := route.Pattern.FindStringSubmatch(path); match != nil {
+			params := make(map[string]string, len(route.Names))
+			for i, name := range route.Names {
+				params[name], _ = url.PathUnescape(match[i+1])
+			}
+			return route, params
+		}
+	}
+	return nil, nil
+}
+
+// ValidateRequest validates the parameters and body of request against the route.
+func (v *openApiValidator) ValidateRequest(r *Request, route *openApiRoute, pathParams map[string]string) error {
+	var (
+		rules       = v.newRules(true)
+		query       = r.URL.Query()
+		queryParams = gset.NewStrSet()
+	)
+	for _, parameter := range route.Parameters {
+		var (
+			name     = gconv.String(parameter["name"])
+			in       = gconv.String(parameter["in"])
+			schema   = v.parameterSchema(parameter)
+			required = in == "path" || gconv.Bool(parameter["required"])
+			value    any
+			present  bool
+		)
+		switch in {
+		case "path":
+			value, present = pathParams[name]
+		case "query":
+			queryParams.Add(name)
+			var values []string
+			if values, present = query[name]; present {
+				if v.kind(schema) == "array" {
+					value = values
+				} else {
+					value = values[0]
+				}
+			}
+		case "header":
+			if present = r.Header.Get(name) != ""; present {
+				value = r.Header.Get(name)
+			}
+		case "cookie":
+			if present = r.Cookie.Contains(name); present {
+				value = r.Cookie.Get(name).String()
+			}
+		default:
+			continue
+		}
+		rules.collect(v, name, value, present, schema, required)
+	}
+	if v.strict {
+		for _, name := range openApiSortedKeys(query) {
+			if !queryParams.Contains(name) {
+				rules.add(name, query[name][0], openApiRuleUnknown)
+			}
+		}
+	}
+	v.collectRequestBody(r, route, rules)
+	return rules.validate(r.Context())
+}
+
+// ValidateResponse validates the response data against the response schema of `status`.
+func (v *openApiValidator) ValidateResponse(ctx context.Context, route *openApiRoute, status int, data any) error {
+	responses, _ := route.Operation["responses"].(map[string]any)
+	if responses == nil {
+		return nil
+	}
+	var (
+		code     = strconv.Itoa(status)
+		response map[string]any
+	)
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		if response = v.resolve(responses[key]); response != nil {
+			break
+		}
+	}
+	_, media := openApiSelectMedia(response, "")
+	schema := v.resolve(media["schema"])
+	if schema == nil {
+		return nil
+	}
+	rules := v.newRules(false)
+	rules.collect(v, v.rootName(schema), data, true, schema, false)
+	return rules.validate(ctx)
+}
+
+// collectRequestBody collects the rules of request body.
+func (v *openApiValidator) collectRequestBody(r *Request, route *openApiRoute, rules *openApiRules) {
+	requestBody := v.resolve(route.Operation["requestBody"])
+	if requestBody == nil {
+		return
+	}
+	var (
+		data         any
+		present      bool
+		mediaType, _ = openApiMediaType(r.Header.Get("Content-Type"))
+		_, media     = openApiSelectMedia(requestBody, mediaType)
+		schema       = v.resolve(media["schema"])
+	)
+	switch {
+	case gstr.Contains(mediaType, "json"):
+		body := r.GetBody()
+		if present = len(body) > 0; present {
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			if err := decoder.Decode(&data); err != nil {
+				rules.add(openApiBodyFieldName, string(body), "json")
+				return
+			}
+		}
+		// Values of json body are typed, which are not parsed from strings.
+		rules.fromString = false
+
+	case mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data":
+		form := make(map[string]any)
+		for key, value := range r.GetFormMap() {
+			form[key] = value
+		}
+		if r.MultipartForm != nil {
+			for key, files := range r.MultipartForm.File {
+				if len(files) > 0 {
+					form[key] = files[0].Filename
+				}
+			}
+		}
+		data, present = form, len(form) > 0
+		rules.fromString = true
+
+	default:
+		// Other media types are not validated.
+		return
+	}
+	if !present {
+		if gconv.Bool(requestBody["required"]) {
+			rules.add(openApiBodyFieldName, nil, "required")
+		}
+		return
+	}
+	rules.collect(v, v.rootName(schema), data, true, schema, false)
+}
+
+// parameters merges the path item parameters and operation parameters.
+func (v *openApiValidator) parameters(pathParameters, operationParameters any) []map[string]any {
+	var (
+		parameters = gmap.NewListKVMap[string, map[string]any]()
+		items      = make([]any, 0)
+	)
+	if array, ok := pathParameters.([]any); ok {
+		items = append(items, array...)
+	}
+	if array, ok := operationParameters.([]any); ok {
+		items = append(items, array...)
+	}
+	for _, item := range items {
+		if parameter := v.resolve(item); parameter != nil {
+			parameters.Set(gconv.String(parameter["in"])+":"+gconv.String(parameter["name"]), parameter)
+		}
+	}
+	return parameters.Values()
+}
+
+// parameterSchema returns the schema of parameter, which might be defined in its content.
+func (v *openApiValidator) parameterSchema(parameter map[string]any) map[string]any {
+	if schema := v.resolve(parameter["schema"]); schema != nil {
+		return schema
+	}
+	_, media := openApiSelectMedia(parameter, "")
+	return v.resolve(media["schema"])
+}
+
+// resolve resolves the reference object and returns the target object.
+func (v *openApiValidator) resolve(value any) map[string]any {
+	object, _ := value.(map[string]any)
+	for i := 0; object != nil && i < openApiMaxRefDepth; i++ {
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object
+		}
+		object = v.resolvePointer(ref)
+	}
+	return object
+}
+
+// resolvePointer resolves the local json pointer like "#/components/schemas/User".
+func (v *openApiValidator) resolvePointer(pointer string) map[string]any {
+	if !gstr.HasPrefix(pointer, "#/") {
+		return nil
+	}
+	var current any = v.root
+	for _, token := range strings.Split(pointer[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = object[token]
+	}
+	object, _ := current.(map[string]any)
+	return object
+}
+
+// types returns the types of schema, which supports type array of OpenAPI 3.1 and nullable of OpenAPI 3.0.
+func (v *openApiValidator) types(schema map[string]any) []string {
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		types = gconv.Strings(t)
+	}
+	if len(types) > 0 && gconv.Bool(schema["nullable"]) {
+		types = append(types, "null")
+	}
+	return types
+}
+
+// kind returns the non-null type of schema.
+func (v *openApiValidator) kind(schema map[string]any) string {
+	for _, t := range v.types(schema) {
+		if t != "null" {
+			return t
+		}
+	}
+	switch {
+	case schema["properties"] != nil || schema["additionalProperties"] != nil:
+		return "object"
+	case schema["items"] != nil:
+		return "array"
+	}
+	return ""
+}
+
+// rootName returns the field name for the root value, which is empty for object
+// so that its properties are named by themselves.
+func (v *openApiValidator) rootName(schema map[string]any) string {
+	if v.kind(schema) == "object" || schema["allOf"] != nil {
+		return ""
+	}
+	return openApiBodyFieldName
+}
+
+// properties returns the properties and required property names of object schema,
+// which includes the properties of `allOf` schemas.
+func (v *openApiValidator) properties(schema map[string]any, depth int) (map[string]any, *gset.StrSet) {
+	var (
+		properties = make(map[string]any)
+		required   = gset.NewStrSet()
+	)
+	if depth > openApiMaxRefDepth {
+		return properties, required
+	}
+	if items, ok := schema["properties"].(map[string]any); ok {
+		for key, value := range items {
+			properties[key] = value
+		}
+	}
+	if items, ok := schema["required"].([]any); ok {
+		required.Add(gconv.Strings(items)...)
+	}
+	if items, ok := schema["allOf"].([]any); ok {
+		for _, item := range items {
+			if itemSchema := v.resolve(item); itemSchema != nil {
+				itemProperties, itemRequired := v.properties(itemSchema, depth+1)
+				for key, value := range itemProperties {
+					properties[key] = value
+				}
+				required.Merge(itemRequired)
+			}
+		}
+	}
+	return properties, required
+}
+
+func (v *openApiValidator) newRules(fromString bool) *openApiRules {
+	return &openApiRules{
+		rules:      gmap.NewListKVMap[string, []string](),
+		data:       make(map[string]any),
+		strict:     v.strict,
+		fromString: fromString,
+	}
+}
+
+// add adds the rules for field `name` with its value.
+func (c *openApiRules) add(name string, value any, rules ...string) {
+	c.data[name] = value
+	c.rules.Set(name, append(c.rules.Get(name), rules...))
+}
+
+// collect collects the rules converted from schema constraints for the value and its children.
+func (c *openApiRules) collect(
+	v *openApiValidator, name string, value any, present bool, schema map[string]any, required bool,
+) {
+	schema = v.resolve(schema)
+	var rules = make([]string, 0)
+	if required {
+		rules = append(rules, "required")
+	}
+	if schema == nil || !present || value == nil {
+		if len(rules) > 0 {
+			c.add(name, value, rules...)
+		}
+		return
+	}
+	var (
+		types = v.types(schema)
+		kind  = v.kind(schema)
+	)
+	if len(types) > 0 {
+		// The other constraints are meaningless if the value is of invalid type.
+		rules = append(rules, "bail")
+		if c.fromString {
+			rules = append(rules, openApiRuleParamType+":"+strings.Join(types, ","))
+		} else {
+			rules = append(rules, openApiRuleType+":"+strings.Join(types, ","))
+		}
+	}
+	switch kind {
+	case "string":
+		var (
+			minLength, hasMinLength = schema["minLength"]
+			maxLength, hasMaxLength = schema["maxLength"]
+		)
+		switch {
+		case hasMinLength && hasMaxLength:
+			rules = append(rules, fmt.Sprintf("length:%v,%v", minLength, maxLength))
+		case hasMinLength:
+			rules = append(rules, fmt.Sprintf("min-length:%v", minLength))
+		case hasMaxLength:
+			rules = append(rules, fmt.Sprintf("max-length:%v", maxLength))
+		}
+		if rule, ok := openApiFormatRules[gconv.String(schema["format"])]; ok {
+			rules = append(rules, rule)
+		}
+
+	case "integer", "number":
+		rules = append(rules, openApiRangeRules(schema)...)
+
+	case "array":
+		if schema["minItems"] != nil || schema["maxItems"] != nil {
+			rules = append(rules, fmt.Sprintf(
+				"%s:%s,%s", openApiRuleItems, gconv.String(schema["minItems"]), gconv.String(schema["maxItems"]),
+			))
+		}
+	}
+	var enums []string
+	if values, ok := schema["enum"].([]any); ok {
+		enums = gconv.Strings(values)
+	}
+	if constValue, ok := schema["const"]; ok {
+		enums = []string{gconv.String(constValue)}
+	}
+	if len(enums) > 0 && kind != "object" && kind != "array" {
+		values := strings.Join(enums, ",")
+		if len(strings.Split(values, ",")) == len(enums) && !strings.ContainsAny(values, "|#") {
+			rules = append(rules, "in:"+values)
+		}
+	}
+	// The regex rule should be the last one as its pattern might contain rule separator.
+	if pattern := gconv.String(schema["pattern"]); pattern != "" && !strings.Contains(pattern, "#") {
+		rules = append(rules, "regex:"+pattern)
+	}
+	if name != "" || len(rules) > 0 {
+		c.add(openApiFieldName(name), value, rules...)
+	}
+
+	// Children of array and object.
+	switch children := value.(type) {
+	case []any:
+		c.collectItems(v, name, children, schema)
+	case []string:
+		c.collectItems(v, name, gconv.Interfaces(children), schema)
+	case map[string]any:
+		if kind == "object" || schema["allOf"] != nil {
+			c.collectProperties(v, name, children, schema)
+		}
+	}
+}
+
+func (c *openApiRules) collectItems(v *openApiValidator, name string, items []any, schema map[string]any) {
+	itemSchema := v.resolve(schema["items"])
+	if itemSchema == nil {
+		return
+	}
+	for i, item := range items {
+		c.collect(v, openApiJoinName(name, strconv.Itoa(i)), item, true, itemSchema, false)
+	}
+}
+
+func (c *openApiRules) collectProperties(v *openApiValidator, name string, object map[string]any, schema map[string]any) {
+	properties, required := v.properties(schema, 0)
+	for _, key := range openApiSortedKeys(properties) {
+		value, present := object[key]
+		c.collect(v, openApiJoinName(name, key), value, present, v.resolve(properties[key]), required.Contains(key))
+	}
+	var additional = schema["additionalProperties"]
+	for _, key := range openApiSortedKeys(object) {
+		if _, ok := properties[key]; ok {
+			continue
+		}
+		switch additionalSchema := additional.(type) {
+		case map[string]any:
+			c.collect(v, openApiJoinName(name, key), object[key], true, additionalSchema, false)
+		case bool:
+			if !additionalSchema {
+				c.add(openApiJoinName(name, key), object[key], openApiRuleUnknown)
+			}
+		default:
+			if c.strict && len(properties) > 0 {
+				c.add(openApiJoinName(name, key), object[key], openApiRuleUnknown)
+			}
+		}
+	}
+}
+
+// validate validates the collected data with rules, it returns gvalid.Error if validation fails.
+func (c *openApiRules) validate(ctx context.Context) error {
+	if c.rules.Size() == 0 {
+		return nil
+	}
+	// The field names like "X-Trace-Id" and "tags.1" are not valid in sequence rules,
+	// so the fields are validated by aliases, which are mapped back in the returned error.
+	var (
+		sequenceRules = make([]string, 0, c.rules.Size())
+		data          = make(map[string]any, len(c.data))
+		fields        = make([]openApiErrorField, 0, c.rules.Size())
+	)
+	c.rules.Iterator(func(name string, rules []string) bool {
+		if len(rules) > 0 {
+			alias := openApiFieldAlias(len(fields))
+			data[alias] = c.data[name]
+			sequenceRules = append(sequenceRules, alias+"@"+strings.Join(rules, "|"))
+			fields = append(fields, openApiErrorField{Name: name, Alias: alias, Rules: rules})
+		}
+		return true
+	})
+	if err := gvalid.New().Data(data).Rules(sequenceRules).RuleFuncMap(openApiRuleFuncs).Run(ctx); err != nil {
+		return newOpenApiValidationError(err, fields)
+	}
+	return nil
+}
+
+// openApiRangeRules converts the numeric range constraints into rules,
+// which supports both boolean exclusive constraints of OpenAPI 3.0 and numeric ones of OpenAPI 3.1.
+func openApiRangeRules(schema map[string]any) []string {
+	var (
+		rules        = make([]string, 0)
+		minimum      = schema["minimum"]
+		maximum      = schema["maximum"]
+		exclusiveMin = schema["exclusiveMinimum"]
+		exclusiveMax = schema["exclusiveMaximum"]
+	)
+	switch value := exclusiveMin.(type) {
+	case bool:
+		if value && minimum != nil {
+			rules = append(rules, fmt.Sprintf("%s:%v", openApiRuleGT, minimum))
+			minimum = nil
+		}
+	case nil:
+	default:
+		rules = append(rules, fmt.Sprintf("%s:%v", openApiRuleGT, value))
+	}
+	switch value := exclusiveMax.(type) {
+	case bool:
+		if value && maximum != nil {
+			rules = append(rules, fmt.Sprintf("%s:%v", openApiRuleLT, maximum))
+			maximum = nil
+		}
+	case nil:
+	default:
+		rules = append(rules, fmt.Sprintf("%s:%v", openApiRuleLT, value))
+	}
+	switch {
+	case minimum != nil && maximum != nil:
+		rules = append(rules, fmt.Sprintf("between:%v,%v", minimum, maximum))
+	case minimum != nil:
+		rules = append(rules, fmt.Sprintf("min:%v", minimum))
+	case maximum != nil:
+		rules = append(rules, fmt.Sprintf("max:%v", maximum))
+	}
+	return rules
+}
+
+// openApiPathPattern converts the path template into regular expression,
+// which supports both "{name}" of OpenAPI and ":name", "*name" of ghttp.
+// It returns nil pattern if the path has no parameters.
+func openApiPathPattern(path string) (*regexp.Regexp, []string) {
+	var (
+		names    []string
+		segments = strings.Split(path, "/")
+	)
+	for i, segment := range segments {
+		switch {
+		case gstr.HasPrefix(segment, ":") && len(segment) > 1:
+			names = append(names, segment[1:])
+			segments[i] = `([^/]+)`
+		case gstr.HasPrefix(segment, "*") && len(segment) > 1:
+			names = append(names, segment[1:])
+			segments[i] = `(.*)`
+		default:
+			var (
+				builder strings.Builder
+				rest    = segment
+			)
+			for {
+				start := strings.Index(rest, "{")
+				end := strings.Index(rest, "}")
+				if start < 0 || end < start {
+					builder.WriteString(regexp.QuoteMeta(rest))
+					break
+				}
+				builder.WriteString(regexp.QuoteMeta(rest[:start]))
+				builder.WriteString(`([^/]+)`)
+				names = append(names, rest[start+1:end])
+				rest = rest[end+1:]
+			}
+			segments[i] = builder.String()
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	return regexp.MustCompile(`^` + strings.Join(segments, "/") + `$`), names
+}
+
+// openApiSelectMedia selects the media of content in `object` by media type.
+// It prefers the exact media type, then the wildcard media type, then the json media type.
+func openApiSelectMedia(object map[string]any, mediaType string) (string, map[string]any) {
+	content, _ := object["content"].(map[string]any)
+	if len(content) == 0 {
+		return "", nil
+	}
+	var candidates []string
+	if mediaType != "" {
+		candidates = append(candidates, mediaType, mediaType[:strings.Index(mediaType+"/", "/")]+"/*")
+	}
+	candidates = append(candidates, "application/json", "*/*")
+	for _, candidate := range candidates {
+		if media, ok := content[candidate].(map[string]any); ok {
+			return candidate, media
+		}
+	}
+	for _, key := range openApiSortedKeys(content) {
+		if gstr.Contains(key, "json") {
+			media, _ := content[key].(map[string]any)
+			return key, media
+		}
+	}
+	return "", nil
+}
+
+// openApiMediaType parses the media type of Content-Type header, eg: application/json.
+func openApiMediaType(contentType string) (string, error) {
+	if contentType == "" {
+		return "application/json", nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return strings.ToLower(mediaType), err
+}
+
+// openApiSortedKeys returns the sorted keys of map for stable validation order.
+func openApiSortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func openApiJoinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// openApiFieldAlias returns the alias of the field at `index` in validation,
+// the trailing '_' keeps an alias from being a prefix of another one.
+func openApiFieldAlias(index int) string {
+	return "oai_field_" + strconv.Itoa(index) + "_"
+}
+
+func openApiFieldName(name string) string {
+	if name == "" {
+		return openApiBodyFieldName
+	}
+	return name
+}
+
+func openApiRulePattern(rule string) string {
+	if index := strings.Index(rule, ":"); index >= 0 {
+		return rule[index+1:]
+	}
+	return ""
+}
+
+// openApiRuleFuncType implements the type checking rules.
+func openApiRuleFuncType(ctx context.Context, in gvalid.RuleFuncInput) error {
+	var (
+		types      = strings.Split(openApiRulePattern(in.Rule), ",")
+		fromString = gstr.HasPrefix(in.Rule, openApiRuleParamType)
+		value      = in.Value.Val()
+	)
+	for _, t := range types {
+		if openApiCheckType(t, value, fromString) {
+			return nil
+		}
+	}
+	return gerror.Newf(`The %s value must be of type %s`, in.Field, strings.Join(types, " or "))
+}
+
+func openApiCheckType(t string, value any, fromString bool) bool {
+	if s, ok := value.(string); ok && fromString {
+		switch t {
+		case "string":
+			return true
+		case "integer":
+			_, err := strconv.ParseInt(s, 10, 64)
+			return err == nil
+		case "number":
+			_, err := strconv.ParseFloat(s, 64)
+			return err == nil
+		case "boolean":
+			_, err := strconv.ParseBool(s)
+			return err == nil
+		case "array":
+			return true
+		}
+		return false
+	}
+	switch t {
+	case "null":
+		return value == nil
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer":
+		switch n := value.(type) {
+		case json.Number:
+			f, err := n.Float64()
+			return err == nil && f == float64(int64(f))
+		case int, int64, int32, uint, uint64, uint32:
+			return true
+		case float64:
+			return n == float64(int64(n))
+		}
+		return false
+	case "number":
+		switch value.(type) {
+		case json.Number, int, int64, int32, uint, uint64, uint32, float32, float64:
+			return true
+		}
+		return false
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		switch value.(type) {
+		case []any, []string:
+			return true
+		}
+		return false
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	}
+	return true
+}
+
+// openApiRuleFuncUnknown implements the rule rejecting unknown fields.
+func openApiRuleFuncUnknown(ctx context.Context, in gvalid.RuleFuncInput) error {
+	return gerror.Newf(`The %s field is not allowed`, in.Field)
+}
+
+// openApiRuleFuncRange implements the exclusive minimum and maximum rules.
+func openApiRuleFuncRange(ctx context.Context, in gvalid.RuleFuncInput) error {
+	var (
+		limit    = gconv.Float64(openApiRulePattern(in.Rule))
+		value, e = strconv.ParseFloat(in.Value.String(), 64)
+	)
+	if e != nil {
+		return nil
+	}
+	if gstr.HasPrefix(in.Rule, openApiRuleGT) && value <= limit {
+		return gerror.Newf(`The %s value %v must be greater than %v`, in.Field, in.Value, openApiRulePattern(in.Rule))
+	}
+	if gstr.HasPrefix(in.Rule, openApiRuleLT) && value >= limit {
+		return gerror.Newf(`The %s value %v must be less than %v`, in.Field, in.Value, openApiRulePattern(in.Rule))
+	}
+	return nil
+}
+
+// openApiRuleFuncItems implements the array size rule.
+func openApiRuleFuncItems(ctx context.Context, in gvalid.RuleFuncInput) error {
+	var (
+		array      = strings.Split(openApiRulePattern(in.Rule), ",")
+		size       = len(in.Value.Interfaces())
+		minSize    = -1
+		maxSize    = -1
+		minSizeStr = array[0]
+	)
+	if minSizeStr != "" {
+		minSize = gconv.Int(minSizeStr)
+	}
+	if len(array) > 1 && array[1] != "" {
+		maxSize = gconv.Int(array[1])
+	}
+	if minSize >= 0 && size < minSize {
+		return gerror.Newf(`The %s field must contain at least %d items`, in.Field, minSize)
+	}
+	if maxSize >= 0 && size > maxSize {
+		return gerror.Newf(`The %s field must contain at most %d items`, in.Field, maxSize)
+	}
+	return nil
+}
+
+// openApiRuleFuncDateTime implements the RFC3339 date-time rule.
+func openApiRuleFuncDateTime(ctx context.Context, in gvalid.RuleFuncInput) error {
+	if _, err := time.Parse(time.RFC3339, in.Value.String()); err != nil {
+		return gerror.Newf(`The %s value %s is not a valid RFC3339 date-time`, in.Field, in.Value)
+	}
+	return nil
+}
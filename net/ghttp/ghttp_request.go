@@ -8,6 +8,7 @@ package ghttp
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -61,6 +62,7 @@ type Request struct {
 	viewObject      *gview.View          // Custom template view engine object for this response.
 	viewParams      gview.Params         // Custom template view variables for this response.
 	originUrlPath   string               // Original URL path that passed from client.
+	originBody      io.ReadCloser        // Original request body without the server-wide body size limit.
 }
 
 // staticFile is the file struct for static file service.
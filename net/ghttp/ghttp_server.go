@@ -200,6 +200,10 @@ func (s *Server) Start() error {
 			`there's no route set or static feature enabled, did you forget import the router?`,
 		)
 	}
+	// Initialize the certificates before creating HTTPS listeners.
+	if err := s.initCertificates(ctx); err != nil {
+		return err
+	}
 	// ================================================================================================
 	// Start the HTTP server.
 	// ================================================================================================
@@ -538,6 +542,12 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 			s.servers[len(s.servers)-1].SetIsHttps(true)
 		}
 	}
+	// HTTP/3
+	if httpsEnabled && s.config.HTTP3Enabled {
+		if err := s.createHTTP3Servers(fdMap); err != nil {
+			s.Logger().Fatalf(ctx, `%+v`, err)
+		}
+	}
 	// HTTP
 	if !httpsEnabled && len(s.config.Address) == 0 {
 		s.config.Address = defaultHttpAddr
@@ -581,15 +591,60 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 	wg.Wait()
 }
 
+// createHTTP3Servers creates the HTTP/3 servers and their UDP listeners,
+// and then generates the "Alt-Svc" header value advertising them.
+//
+// Note that it creates the listeners synchronously before any server serving,
+// as the TLS configuration might be changed when creating HTTPS listeners.
+func (s *Server) createHTTP3Servers(fdMap listenerFdMap) error {
+	if http3ServerCreator == nil {
+		return gerror.NewCode(
+			gcode.CodeInvalidConfiguration,
+			`HTTP/3 is enabled but no implementation is set, did you forget importing the HTTP/3 contrib package?`,
+		)
+	}
+	address := s.config.HTTP3Addr
+	if address == "" {
+		address = s.config.HTTPSAddr
+	}
+	if v, ok := fdMap["http3"]; ok && len(v) > 0 {
+		address = v
+	}
+	var altSvcArray []string
+	for _, v := range gstr.SplitAndTrim(address, ",") {
+		var (
+			fd        = 0
+			addrAndFd = strings.Split(v, "#")
+		)
+		// The Windows OS does not support socket file descriptor passing
+		// from the parent process.
+		if len(addrAndFd) > 1 && runtime.GOOS != "windows" {
+			fd = gconv.Int(addrAndFd[1])
+		}
+		server := s.newGracefulHTTP3Server(addrAndFd[0], fd, http3ServerCreator())
+		err := server.CreateListenerHTTP3(s.config.HTTPSCertPath, s.config.HTTPSKeyPath, s.config.TLSConfig)
+		if err != nil {
+			return err
+		}
+		s.servers = append(s.servers, server)
+		altSvcArray = append(altSvcArray, fmt.Sprintf(`h3=":%d"; ma=%d`, server.GetListenedPort(), http3AltSvcMaxAge))
+	}
+	s.http3AltSvc = strings.Join(altSvcArray, ", ")
+	return nil
+}
+
 func (s *Server) startGracefulServer(ctx context.Context, wg *sync.WaitGroup, server *graceful.Server) {
 	s.serverCount.Add(1)
 	var err error
 	// Create listener.
-	if server.IsHttps() {
+	switch {
+	case server.IsHTTP3():
+		// The UDP listener is already created in createHTTP3Servers.
+	case server.IsHttps():
 		err = server.CreateListenerTLS(
 			s.config.HTTPSCertPath, s.config.HTTPSKeyPath, s.config.TLSConfig,
 		)
-	} else {
+	default:
 		err = server.CreateListener()
 	}
 	if err != nil {
@@ -627,15 +682,21 @@ func (s *Server) Status() ServerStatus {
 }
 
 // getListenerFdMap retrieves and returns the socket file descriptors.
-// The key of the returned map is "http" and "https".
+// The key of the returned map is "http", "https" and "http3".
 func (s *Server) getListenerFdMap() map[string]string {
 	m := map[string]string{
 		"https": "",
 		"http":  "",
+		"http3": "",
 	}
 	for _, v := range s.servers {
 		str := v.GetAddress() + "#" + gconv.String(v.Fd()) + ","
-		if v.IsHttps() {
+		if v.IsHTTP3() {
+			if len(m["http3"]) > 0 {
+				m["http3"] += ","
+			}
+			m["http3"] += str
+		} else if v.IsHttps() {
 			if len(m["https"]) > 0 {
 				m["https"] += ","
 			}
@@ -659,7 +720,17 @@ func (s *Server) GetListenedPort() int {
 		}
 	}
 	for _, server := range s.servers {
-		if server.IsHttps() {
+		if server.IsHttps() && !server.IsHTTP3() {
+			return server.GetListenedPort()
+		}
+	}
+	return -1
+}
+
+// GetListenedHTTP3Port retrieves and returns one UDP port which is listened for HTTP/3 by current server.
+func (s *Server) GetListenedHTTP3Port() int {
+	for _, server := range s.servers {
+		if server.IsHTTP3() {
 			return server.GetListenedPort()
 		}
 	}
@@ -669,7 +740,7 @@ func (s *Server) GetListenedPort() int {
 // GetListenedHTTPSPort retrieves and returns one port which is listened using TLS by current server.
 func (s *Server) GetListenedHTTPSPort() int {
 	for _, server := range s.servers {
-		if server.IsHttps() {
+		if server.IsHttps() && !server.IsHTTP3() {
 			return server.GetListenedPort()
 		}
 	}
@@ -110,6 +110,7 @@ func (s *Server) Shutdown() error {
 	for _, v := range s.servers {
 		v.Shutdown(ctx)
 	}
+	s.closeCertificates()
 	s.Logger().Infof(ctx, "pid[%d]: all servers shutdown", gproc.Pid())
 	return nil
 }
@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/os/gcron"
+)
+
+// utilCronAdmin is the controller for cron jobs administration.
+type utilCronAdmin struct {
+	cron *gcron.Cron // It uses the default cron if it is nil.
+}
+
+// cronAdminEntry is the status of cron entry in administration.
+type cronAdminEntry struct {
+	Name     string             `json:"name"`
+	Pattern  string             `json:"pattern"`
+	Status   string             `json:"status"`
+	NextRun  *time.Time         `json:"nextRun"`
+	LastRun  *gcron.RunRecord   `json:"lastRun"`
+	History  []*gcron.RunRecord `json:"history,omitempty"`
+	Register time.Time          `json:"registerTime"`
+}
+
+var cronAdminStatusNames = map[int]string{
+	gcron.StatusReady:   "ready",
+	gcron.StatusRunning: "running",
+	gcron.StatusStopped: "stopped",
+	gcron.StatusClosed:  "closed",
+}
+
+// Index lists the cron entries with their next and last run status.
+// The query parameter `history` specifies the number of latest run records returned for each entry.
+func (p *utilCronAdmin) Index(r *Request) {
+	var (
+		ctx     = r.Context()
+		history = r.GetQuery("history").Int()
+		entries []*gcron.Entry
+	)
+	if p.cron != nil {
+		entries = p.cron.Entries()
+	} else {
+		entries = gcron.Entries()
+	}
+	items := make([]cronAdminEntry, 0, len(entries))
+	for _, entry := range entries {
+		item := cronAdminEntry{
+			Name:     entry.Name,
+			Pattern:  entry.Pattern(),
+			Status:   cronAdminStatusNames[entry.Status()],
+			LastRun:  entry.LastRun(ctx),
+			Register: entry.RegisterTime,
+		}
+		if nextRun := entry.NextRun(1); len(nextRun) > 0 {
+			item.NextRun = &nextRun[0]
+		}
+		if history > 0 {
+			item.History, _ = entry.History(ctx, history)
+		}
+		items = append(items, item)
+	}
+	r.Response.WriteJson(items)
+}
+
+// EnableCronAdmin enables the administration endpoint for cron jobs, which lists the entries of `cron`
+// with their next and last run status in JSON. The default cron is used if `cron` is nil.
+// The optional parameter `pattern` specifies the URI for the endpoint, which is "/debug/cron" in default.
+func (s *Server) EnableCronAdmin(cron *gcron.Cron, pattern ...string) {
+	p := "/debug/cron"
+	if len(pattern) > 0 {
+		p = pattern[0]
+	}
+	s.BindObject(p, &utilCronAdmin{cron: cron})
+}
@@ -0,0 +1,262 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gfsnotify"
+	"github.com/gogf/gf/v2/os/gres"
+)
+
+const (
+	// autoCertChallengePathPrefix is the URI path prefix for ACME HTTP-01 challenges.
+	autoCertChallengePathPrefix = "/.well-known/acme-challenge/"
+	// autoCertCacheKeyPrefix is the key prefix for certificates stored in gcache.
+	autoCertCacheKeyPrefix = "ghttp.autocert:"
+)
+
+// autoCertCache implements autocert.Cache using gcache.Cache,
+// which makes certificates shared among nodes using distributed cache adapter, like redis.
+type autoCertCache struct {
+	cache *gcache.Cache
+}
+
+// certReloader holds the certificate loaded from files, and reloads it when the files change.
+type certReloader struct {
+	mu          sync.Mutex
+	server      *Server
+	certFile    string
+	keyFile     string
+	targets     string // Resolved paths of the certificate files when they are loaded, which might be symlinks.
+	certificate atomic.Pointer[tls.Certificate]
+	callbacks   []*gfsnotify.Callback
+}
+
+// initCertificates initializes the automatic certificate management and certificate hot reloading,
+// which replaces the TLS configuration of the server using dynamic certificate retrieving.
+func (s *Server) initCertificates(ctx context.Context) error {
+	switch {
+	case s.config.AutoCertEnabled:
+		manager, err := s.newAutoCertManager()
+		if err != nil {
+			return err
+		}
+		s.autoCertHandler = manager.HTTPHandler(nil)
+		s.config.TLSConfig = s.newDynamicCertTLSConfig(manager.GetCertificate, acme.ALPNProto)
+
+	case s.config.HTTPSCertAutoReload && s.config.HTTPSCertPath != "" && s.config.HTTPSKeyPath != "":
+		if gres.Contains(s.config.HTTPSCertPath) {
+			s.Logger().Warningf(
+				ctx, `certificate auto reload is ignored for resource file "%s"`, s.config.HTTPSCertPath,
+			)
+			return nil
+		}
+		reloader := &certReloader{
+			server:   s,
+			certFile: s.config.HTTPSCertPath,
+			keyFile:  s.config.HTTPSKeyPath,
+		}
+		if err := reloader.Load(); err != nil {
+			return err
+		}
+		if err := reloader.Watch(); err != nil {
+			return err
+		}
+		s.certReloader = reloader
+		s.config.TLSConfig = s.newDynamicCertTLSConfig(reloader.GetCertificate)
+	}
+	return nil
+}
+
+// closeCertificates releases the resources of certificate hot reloading.
+func (s *Server) closeCertificates() {
+	if s.certReloader != nil {
+		s.certReloader.Close()
+		s.certReloader = nil
+	}
+}
+
+// newDynamicCertTLSConfig clones the TLS configuration of the server, and replaces its certificates
+// with `getCertificate` that retrieves certificate for each TLS handshake.
+// The optional parameter `protos` specifies the extra ALPN protocols to be supported.
+func (s *Server) newDynamicCertTLSConfig(
+	getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error), protos ...string,
+) *tls.Config {
+	var config *tls.Config
+	if s.config.TLSConfig != nil {
+		config = s.config.TLSConfig.Clone()
+	} else {
+		config = &tls.Config{}
+	}
+	if len(protos) > 0 {
+		if len(config.NextProtos) == 0 {
+			config.NextProtos = []string{"http/1.1"}
+		}
+		config.NextProtos = append(config.NextProtos, protos...)
+	}
+	config.Certificates = nil
+	config.GetCertificate = getCertificate
+	return config
+}
+
+// newAutoCertManager creates and returns the ACME certificate manager using configuration of the server.
+func (s *Server) newAutoCertManager() (*autocert.Manager, error) {
+	if s.config.AutoCertManager != nil {
+		return s.config.AutoCertManager, nil
+	}
+	if len(s.config.AutoCertDomains) == 0 {
+		return nil, gerror.NewCode(
+			gcode.CodeInvalidConfiguration,
+			`automatic certificate management is enabled but no domain is configured`,
+		)
+	}
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(s.config.AutoCertDomains...),
+		Email:      s.config.AutoCertEmail,
+	}
+	if s.config.AutoCertDirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: s.config.AutoCertDirectoryURL}
+	}
+	// The certificates and account key must be persistent, or else they are issued again after restarting,
+	// which might hit the rate limits of the ACME server.
+	switch {
+	case s.config.AutoCertCache != nil:
+		manager.Cache = &autoCertCache{cache: s.config.AutoCertCache}
+	case s.config.AutoCertCacheDir != "":
+		manager.Cache = autocert.DirCache(s.config.AutoCertCacheDir)
+	default:
+		return nil, gerror.NewCode(
+			gcode.CodeInvalidConfiguration,
+			`automatic certificate management is enabled but neither AutoCertCacheDir nor AutoCertCache is configured`,
+		)
+	}
+	return manager, nil
+}
+
+// serveAutoCertChallenge serves the ACME HTTP-01 challenge request, it returns false if `r` is not a challenge.
+func (s *Server) serveAutoCertChallenge(w http.ResponseWriter, r *http.Request) bool {
+	if s.autoCertHandler == nil || r.TLS != nil || !strings.HasPrefix(r.URL.Path, autoCertChallengePathPrefix) {
+		return false
+	}
+	s.autoCertHandler.ServeHTTP(w, r)
+	return true
+}
+
+// Get implements autocert.Cache interface.
+func (c *autoCertCache) Get(ctx context.Context, key string) ([]byte, error) {
+	v, err := c.cache.Get(ctx, autoCertCacheKeyPrefix+key)
+	if err != nil {
+		return nil, err
+	}
+	if v.IsNil() {
+		return nil, autocert.ErrCacheMiss
+	}
+	return v.Bytes(), nil
+}
+
+// Put implements autocert.Cache interface.
+func (c *autoCertCache) Put(ctx context.Context, key string, data []byte) error {
+	return c.cache.Set(ctx, autoCertCacheKeyPrefix+key, data, 0)
+}
+
+// Delete implements autocert.Cache interface.
+func (c *autoCertCache) Delete(ctx context.Context, key string) error {
+	_, err := c.cache.Remove(ctx, autoCertCacheKeyPrefix+key)
+	return err
+}
+
+// Load loads the certificate from files.
+func (r *certReloader) Load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.load()
+}
+
+// load loads the certificate from files, it requires r.mu locked.
+func (r *certReloader) load() error {
+	targets := r.resolveTargets()
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, r.certFile, r.keyFile)
+	}
+	r.certificate.Store(&certificate)
+	r.targets = targets
+	return nil
+}
+
+// Watch watches the directories of certificate files, and reloads the certificate if any of the files changes.
+//
+// The directories rather than the files are watched, as the watching of files is dropped when they are
+// replaced by atomic renaming or symlink swapping, eg by certbot or Kubernetes secret volume.
+// It keeps using the previous certificate if reloading fails, which commonly happens
+// when the certificate file is updated but the key file is not yet.
+func (r *certReloader) Watch() error {
+	var dirs = []string{filepath.Dir(r.certFile)}
+	if keyDir := filepath.Dir(r.keyFile); keyDir != dirs[0] {
+		dirs = append(dirs, keyDir)
+	}
+	for _, dir := range dirs {
+		callback, err := gfsnotify.Add(dir, r.onEvent, gfsnotify.WatchOption{NoRecursive: true})
+		if err != nil {
+			return err
+		}
+		r.callbacks = append(r.callbacks, callback)
+	}
+	return nil
+}
+
+// onEvent reloads the certificate if the event changes the certificate files.
+func (r *certReloader) onEvent(event *gfsnotify.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := filepath.Base(event.Path)
+	if name != filepath.Base(r.certFile) && name != filepath.Base(r.keyFile) && r.resolveTargets() == r.targets {
+		// Other files in the directories, which do not change the targets of certificate files.
+		return
+	}
+	ctx := context.Background()
+	if err := r.load(); err != nil {
+		r.server.Logger().Warningf(ctx, `reload certificate failed: %+v`, err)
+		return
+	}
+	r.server.Logger().Infof(ctx, `certificate reloaded from "%s"`, r.certFile)
+}
+
+// resolveTargets returns the resolved paths of certificate files, which changes if the files are symlinks
+// and any symlink in their paths is swapped, like the "..data" symlink of Kubernetes secret volume.
+func (r *certReloader) resolveTargets() string {
+	certTarget, _ := filepath.EvalSymlinks(r.certFile)
+	keyTarget, _ := filepath.EvalSymlinks(r.keyFile)
+	return certTarget + "\n" + keyTarget
+}
+
+// GetCertificate returns the latest loaded certificate, which is used as tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.certificate.Load(), nil
+}
+
+// Close stops watching the certificate files.
+func (r *certReloader) Close() {
+	for _, callback := range r.callbacks {
+		_ = gfsnotify.RemoveCallback(callback.Id)
+	}
+	r.callbacks = nil
+}
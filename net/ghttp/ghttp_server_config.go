@@ -16,10 +16,13 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/crypto/acme/autocert"
+
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/os/gcache"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gres"
@@ -80,6 +83,50 @@ type ServerConfig struct {
 	// instead.
 	TLSConfig *tls.Config `json:"tlsConfig"`
 
+	// HTTPSCertAutoReload enables reloading the certificate from HTTPSCertPath and HTTPSKeyPath
+	// when the files change on disk, which takes effect for new connections without restarting the server.
+	HTTPSCertAutoReload bool `json:"httpsCertAutoReload"`
+
+	// AutoCertEnabled enables automatic certificate management using ACME protocol, like Let's Encrypt.
+	// The certificates are obtained and renewed automatically for AutoCertDomains, and the ACME challenges
+	// are answered using both HTTP-01 on HTTP addresses and TLS-ALPN-01 on HTTPS addresses.
+	// Note that the HTTP-01 challenges require the server listening on port 80.
+	AutoCertEnabled bool `json:"autoCertEnabled"`
+
+	// AutoCertDomains specifies the domains allowed to obtain certificates for.
+	AutoCertDomains []string `json:"autoCertDomains"`
+
+	// AutoCertEmail specifies the contact email of the ACME account, which is optional.
+	AutoCertEmail string `json:"autoCertEmail"`
+
+	// AutoCertDirectoryURL specifies the ACME directory URL, which is Let's Encrypt production in default.
+	AutoCertDirectoryURL string `json:"autoCertDirectoryURL"`
+
+	// AutoCertCacheDir specifies the persistent directory storing certificates and account key.
+	// Either AutoCertCacheDir or AutoCertCache is required if AutoCertEnabled is true.
+	AutoCertCacheDir string `json:"autoCertCacheDir"`
+
+	// AutoCertCache specifies the cache storing certificates and account key, which has priority over AutoCertCacheDir.
+	// It is usually a redis cache for sharing certificates among multiple nodes.
+	AutoCertCache *gcache.Cache `json:"-"`
+
+	// AutoCertManager specifies the custom ACME certificate manager, which has priority over other AutoCert options.
+	AutoCertManager *autocert.Manager `json:"-"`
+
+	// H2CEnabled enables HTTP/2 cleartext (h2c) on the HTTP addresses, which supports both
+	// HTTP/1.1 upgrading and prior knowledge connections. It is usually used for internal
+	// service-to-service traffic behind load balancers that terminate TLS.
+	H2CEnabled bool `json:"h2cEnabled"`
+
+	// HTTP3Enabled enables HTTP/3 over QUIC along with HTTPS, which shares the TLS configuration
+	// with HTTPS and advertises its endpoints using header "Alt-Svc" in HTTPS responses.
+	// Note that it requires an HTTP/3 implementation set by SetHTTP3ServerCreator.
+	HTTP3Enabled bool `json:"http3Enabled"`
+
+	// HTTP3Addr specifies the HTTP/3 UDP addresses, multiple addresses joined using char ','.
+	// It uses HTTPSAddr if it is empty.
+	HTTP3Addr string `json:"http3Addr"`
+
 	// Handler the handler for HTTP request.
 	Handler func(w http.ResponseWriter, r *http.Request) `json:"-"`
 
@@ -501,6 +548,43 @@ func (s *Server) SetTLSConfig(tlsConfig *tls.Config) {
 	s.config.TLSConfig = tlsConfig
 }
 
+// SetHTTPSCertAutoReload enables or disables reloading certificate files automatically when they change.
+func (s *Server) SetHTTPSCertAutoReload(enabled bool) {
+	s.config.HTTPSCertAutoReload = enabled
+}
+
+// EnableAutoCert enables automatic certificate management using ACME protocol for given `domains`,
+// which also enables HTTPS feature for the server.
+func (s *Server) EnableAutoCert(domains ...string) {
+	s.config.AutoCertEnabled = true
+	s.config.AutoCertDomains = append(s.config.AutoCertDomains, domains...)
+}
+
+// SetAutoCertCacheDir sets the persistent directory storing certificates for automatic certificate management.
+func (s *Server) SetAutoCertCacheDir(dir string) {
+	s.config.AutoCertCacheDir = dir
+}
+
+// SetAutoCertCache sets the cache storing certificates for automatic certificate management.
+func (s *Server) SetAutoCertCache(cache *gcache.Cache) {
+	s.config.AutoCertCache = cache
+}
+
+// SetH2CEnabled enables or disables HTTP/2 cleartext (h2c) for the HTTP addresses of the server.
+func (s *Server) SetH2CEnabled(enabled bool) {
+	s.config.H2CEnabled = enabled
+}
+
+// EnableHTTP3 enables HTTP/3 over QUIC for the server, which requires HTTPS enabled.
+// The optional parameter `address` specifies the HTTP/3 UDP addresses, it uses HTTPSAddr if not given.
+// Note that an HTTP/3 implementation should be imported, eg: `import _ "github.com/gogf/gf/contrib/net/http3/v2"`.
+func (s *Server) EnableHTTP3(address ...string) {
+	s.config.HTTP3Enabled = true
+	if len(address) > 0 {
+		s.config.HTTP3Addr = strings.Join(address, ",")
+	}
+}
+
 // SetReadTimeout sets the ReadTimeout for the server.
 func (s *Server) SetReadTimeout(t time.Duration) {
 	s.config.ReadTimeout = t
@@ -8,6 +8,23 @@ package ghttp
 
 import "github.com/gogf/gf/v2/net/ghttp/internal/graceful"
 
+// HTTP3Server is the interface for HTTP/3 server implementation over QUIC.
+// As HTTP/3 relies on third-party QUIC implementation, it is implemented in the contrib package
+// and set to ghttp using SetHTTP3ServerCreator.
+type HTTP3Server = graceful.HTTP3Server
+
+// HTTP3ServerCreator is the function that creates a new HTTP3Server for each HTTP/3 address.
+type HTTP3ServerCreator func() HTTP3Server
+
+// http3ServerCreator is the creator for HTTP/3 servers, which is nil in default.
+var http3ServerCreator HTTP3ServerCreator
+
+// SetHTTP3ServerCreator sets the creator for HTTP/3 servers.
+// It is usually called in the init function of HTTP/3 implementation package.
+func SetHTTP3ServerCreator(creator HTTP3ServerCreator) {
+	http3ServerCreator = creator
+}
+
 // newGracefulServer creates and returns a graceful http server with a given address.
 // The optional parameter `fd` specifies the file descriptor which is passed from parent server.
 func (s *Server) newGracefulServer(address string, fd int) *graceful.Server {
@@ -22,8 +39,23 @@ func (s *Server) newGracefulServer(address string, fd int) *graceful.Server {
 			GracefulShutdownTimeout: s.config.GracefulTimeout,
 			MaxHeaderBytes:          s.config.MaxHeaderBytes,
 			KeepAlive:               s.config.KeepAlive,
+			H2CEnabled:              s.config.H2CEnabled,
 			Logger:                  s.config.Logger,
 		}
 	)
 	return graceful.New(address, fd, loggerWriter, serverConfig)
 }
+
+// newGracefulHTTP3Server creates and returns a graceful HTTP/3 server with a given UDP address.
+// The parameter `fd` specifies the file descriptor which is passed from parent server.
+func (s *Server) newGracefulHTTP3Server(address string, fd int, http3Server HTTP3Server) *graceful.Server {
+	var (
+		loggerWriter = &errorLogger{logger: s.config.Logger}
+		serverConfig = graceful.ServerConfig{
+			Handler:                 s.config.Handler,
+			GracefulShutdownTimeout: s.config.GracefulTimeout,
+			Logger:                  s.config.Logger,
+		}
+	)
+	return graceful.NewHTTP3(address, fd, http3Server, loggerWriter, serverConfig)
+}
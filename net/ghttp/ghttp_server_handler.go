@@ -29,7 +29,16 @@ import (
 //
 // This function also makes serve implementing the interface of http.Handler.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// ACME HTTP-01 challenges for automatic certificate management.
+	if s.serveAutoCertChallenge(w, r) {
+		return
+	}
+	// HTTP/3 endpoints advertisement for HTTPS requests.
+	if s.http3AltSvc != "" && r.TLS != nil && r.ProtoMajor < 3 {
+		w.Header().Set("Alt-Svc", s.http3AltSvc)
+	}
 	// Max body size limit.
+	var originBody = r.Body
 	if s.config.ClientMaxBodySize > 0 {
 		r.Body = http.MaxBytesReader(w, r.Body, s.config.ClientMaxBodySize)
 	}
@@ -44,6 +53,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		request   = newRequest(s, r, w)    // Create a new request object.
 		sessionId = request.GetSessionId() // Get sessionId before user handler
 	)
+	request.originBody = originBody
 	defer s.handleAfterRequestDone(request)
 
 	// ============================================================
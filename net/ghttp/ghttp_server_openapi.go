@@ -18,11 +18,14 @@ func (s *Server) initOpenApi() {
 	if s.config.OpenApiPath == "" {
 		return
 	}
-	var (
-		ctx     = context.TODO()
-		err     error
-		methods []string
-	)
+	if err := s.addOpenApiRoutes(s.openapi); err != nil {
+		s.Logger().Fatalf(context.TODO(), `%+v`, err)
+	}
+}
+
+// addOpenApiRoutes adds the strict routes of the server to the api specification.
+func (s *Server) addOpenApiRoutes(oai *goai.OpenApiV3) (err error) {
+	var methods []string
 	for _, item := range s.GetRoutes() {
 		switch item.Type {
 		case HandlerTypeMiddleware, HandlerTypeHook:
@@ -34,17 +37,18 @@ func (s *Server) initOpenApi() {
 				methods = SupportedMethods()
 			}
 			for _, method := range methods {
-				err = s.openapi.Add(goai.AddInput{
+				err = oai.Add(goai.AddInput{
 					Path:   item.Route,
 					Method: method,
 					Object: item.Handler.Info.Value.Interface(),
 				})
 				if err != nil {
-					s.Logger().Fatalf(ctx, `%+v`, err)
+					return err
 				}
 			}
 		}
 	}
+	return nil
 }
 
 // openapiSpec is a build-in handler automatic producing for openapi specification json file.
@@ -0,0 +1,47 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func TestServer_EnableCronAdmin(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		defer cron.Close()
+		_, err := cron.Add(ctx, "* * * * * *", func(ctx context.Context) {}, "admin-job")
+		t.AssertNil(err)
+
+		s := g.Server(guid.S())
+		s.EnableCronAdmin(cron)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(1200 * time.Millisecond)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		j, err := gjson.LoadContent(client.GetBytes(ctx, "/debug/cron?history=5"))
+		t.AssertNil(err)
+		t.Assert(j.Get("0.name"), "admin-job")
+		t.Assert(j.Get("0.pattern"), "* * * * * *")
+		t.Assert(j.Get("0.status"), "ready")
+		t.AssertNE(j.Get("0.nextRun").String(), "")
+		t.Assert(j.Get("0.lastRun.status"), "success")
+		t.AssertGE(len(j.Get("0.history").Array()), 1)
+	})
+}
@@ -0,0 +1,337 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// testACMEServer is a minimal ACME server like Pebble, which issues certificates after validating
+// HTTP-01 challenges against the HTTP address of the server under test.
+type testACMEServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	httpAddr   string // HTTP address that the challenges are validated against.
+	caKey      *ecdsa.PrivateKey
+	caCert     *x509.Certificate
+	thumbprint string // JWK thumbprint of the registered account.
+	authzs     []*testACMEAuthz
+	orders     []*testACMEOrder
+}
+
+type testACMEIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type testACMEChallenge struct {
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+	Token  string `json:"token"`
+	Status string `json:"status"`
+}
+
+type testACMEAuthz struct {
+	Status     string               `json:"status"`
+	Identifier testACMEIdentifier   `json:"identifier"`
+	Challenges []*testACMEChallenge `json:"challenges"`
+}
+
+type testACMEOrder struct {
+	Status         string               `json:"status"`
+	Identifiers    []testACMEIdentifier `json:"identifiers"`
+	Authorizations []string             `json:"authorizations"`
+	Finalize       string               `json:"finalize"`
+	Certificate    string               `json:"certificate,omitempty"`
+	authzIds       []int
+	chain          []byte
+}
+
+// testACMERequest is the JWS request of ACME.
+type testACMERequest struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+}
+
+func newTestACMEServer(t *gtest.T) *testACMEServer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.AssertNil(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test ACME CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	t.AssertNil(err)
+	caCert, err := x509.ParseCertificate(der)
+	t.AssertNil(err)
+	a := &testACMEServer{
+		caKey:  key,
+		caCert: caCert,
+	}
+	a.Server = httptest.NewServer(http.HandlerFunc(a.handle))
+	return a
+}
+
+// Roots returns the certificate pool containing the CA certificate.
+func (a *testACMEServer) Roots() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(a.caCert)
+	return pool
+}
+
+func (a *testACMEServer) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Replay-Nonce", guid.S())
+	if r.URL.Path == "/directory" {
+		a.writeJson(w, http.StatusOK, map[string]string{
+			"newNonce":   a.URL + "/nonce",
+			"newAccount": a.URL + "/account",
+			"newOrder":   a.URL + "/order",
+		})
+		return
+	}
+	if r.URL.Path == "/nonce" {
+		return
+	}
+	var (
+		request   testACMERequest
+		protected struct {
+			Jwk map[string]string `json:"jwk"`
+		}
+		payload []byte
+	)
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	header, _ := base64.RawURLEncoding.DecodeString(request.Protected)
+	_ = json.Unmarshal(header, &protected)
+	payload, _ = base64.RawURLEncoding.DecodeString(request.Payload)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var (
+		parts = strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		id    = -1
+	)
+	if len(parts) > 1 {
+		id, _ = strconv.Atoi(parts[1])
+	}
+	switch {
+	case parts[0] == "account":
+		jwk := protected.Jwk
+		sum := sha256.Sum256([]byte(fmt.Sprintf(
+			`{"crv":"%s","kty":"%s","x":"%s","y":"%s"}`, jwk["crv"], jwk["kty"], jwk["x"], jwk["y"],
+		)))
+		a.thumbprint = base64.RawURLEncoding.EncodeToString(sum[:])
+		w.Header().Set("Location", a.URL+"/account/1")
+		a.writeJson(w, http.StatusCreated, map[string]string{"status": "valid"})
+
+	case parts[0] == "order" && id < 0:
+		var req struct {
+			Identifiers []testACMEIdentifier `json:"identifiers"`
+		}
+		_ = json.Unmarshal(payload, &req)
+		order := &testACMEOrder{Status: "pending", Identifiers: req.Identifiers}
+		for _, identifier := range req.Identifiers {
+			authzId := len(a.authzs)
+			a.authzs = append(a.authzs, &testACMEAuthz{
+				Status:     "pending",
+				Identifier: identifier,
+				Challenges: []*testACMEChallenge{{
+					Type:   "http-01",
+					URL:    fmt.Sprintf("%s/challenge/%d", a.URL, authzId),
+					Token:  guid.S(),
+					Status: "pending",
+				}},
+			})
+			order.authzIds = append(order.authzIds, authzId)
+			order.Authorizations = append(order.Authorizations, fmt.Sprintf("%s/authz/%d", a.URL, authzId))
+		}
+		a.orders = append(a.orders, order)
+		w.Header().Set("Location", fmt.Sprintf("%s/order/%d", a.URL, len(a.orders)-1))
+		a.writeJson(w, http.StatusCreated, order)
+
+	case parts[0] == "order":
+		a.writeJson(w, http.StatusOK, a.updateOrder(id))
+
+	case parts[0] == "authz":
+		a.writeJson(w, http.StatusOK, a.authzs[id])
+
+	case parts[0] == "challenge":
+		var (
+			authz     = a.authzs[id]
+			challenge = authz.Challenges[0]
+		)
+		if err := a.validate(authz.Identifier.Value, challenge.Token); err != nil {
+			authz.Status, challenge.Status = "invalid", "invalid"
+		} else {
+			authz.Status, challenge.Status = "valid", "valid"
+		}
+		a.writeJson(w, http.StatusOK, challenge)
+
+	case parts[0] == "finalize":
+		var req struct {
+			Csr string `json:"csr"`
+		}
+		_ = json.Unmarshal(payload, &req)
+		der, _ := base64.RawURLEncoding.DecodeString(req.Csr)
+		order := a.updateOrder(id)
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil || order.Status != "ready" {
+			http.Error(w, "order is not ready", http.StatusForbidden)
+			return
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(id + 100)),
+			Subject:      pkix.Name{CommonName: csr.DNSNames[0]},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(24 * time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			DNSNames:     csr.DNSNames,
+		}
+		leaf, err := x509.CreateCertificate(rand.Reader, template, a.caCert, csr.PublicKey, a.caKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		order.chain = append(
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf}),
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: a.caCert.Raw})...,
+		)
+		order.Status = "valid"
+		order.Certificate = fmt.Sprintf("%s/cert/%d", a.URL, id)
+		a.writeJson(w, http.StatusOK, order)
+
+	case parts[0] == "cert":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write(a.orders[id].chain)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// updateOrder updates the status of order `id` using its authorizations and returns it.
+func (a *testACMEServer) updateOrder(id int) *testACMEOrder {
+	order := a.orders[id]
+	if order.Status != "pending" {
+		return order
+	}
+	valid := 0
+	for _, authzId := range order.authzIds {
+		switch a.authzs[authzId].Status {
+		case "valid":
+			valid++
+		case "invalid":
+			order.Status = "invalid"
+			return order
+		}
+	}
+	if valid == len(order.authzIds) {
+		order.Status = "ready"
+		order.Finalize = fmt.Sprintf("%s/finalize/%d", a.URL, id)
+	}
+	return order
+}
+
+// validate validates the HTTP-01 challenge of `domain` by requesting the HTTP address.
+func (a *testACMEServer) validate(domain, token string) error {
+	request, err := http.NewRequest(
+		http.MethodGet, fmt.Sprintf("http://%s/.well-known/acme-challenge/%s", a.httpAddr, token), nil,
+	)
+	if err != nil {
+		return err
+	}
+	request.Host = domain
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	if expect := token + "." + a.thumbprint; string(body) != expect {
+		return fmt.Errorf(`invalid key authorization "%s", expect "%s"`, body, expect)
+	}
+	return nil
+}
+
+func (a *testACMEServer) writeJson(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func Test_HTTPS_AutoCert_ACME(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		acme := newTestACMEServer(t)
+		defer acme.Close()
+
+		s := g.Server(guid.S())
+		s.BindHandler("/", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		config := ghttp.NewConfig()
+		config.AutoCertEnabled = true
+		config.AutoCertDomains = []string{"example.com"}
+		config.AutoCertDirectoryURL = acme.URL + "/directory"
+		config.AutoCertCache = gcache.New()
+		config.Address = ":0"
+		config.HTTPSAddr = ":0"
+		t.AssertNil(s.SetConfig(config))
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		acme.httpAddr = fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort())
+
+		// The certificate is obtained from ACME server in the first TLS handshake.
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				ServerName: "example.com",
+				RootCAs:    acme.Roots(),
+			},
+		}}
+		response, err := client.Get(fmt.Sprintf("https://127.0.0.1:%d/", s.GetListenedHTTPSPort()))
+		t.AssertNil(err)
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		t.Assert(string(body), "hello")
+		t.Assert(response.TLS.PeerCertificates[0].Issuer.CommonName, "Test ACME CA")
+		t.Assert(response.TLS.PeerCertificates[0].DNSNames, []string{"example.com"})
+	})
+}
@@ -0,0 +1,236 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// testWriteCertificate writes a self-signed certificate with `serial` to `certFile` and `keyFile`.
+func testWriteCertificate(t *gtest.T, certFile, keyFile string, serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.AssertNil(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	t.AssertNil(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	t.AssertNil(err)
+	t.AssertNil(gfile.PutBytes(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer})))
+	t.AssertNil(gfile.PutBytes(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})))
+}
+
+// testGetPeerCertificateSerial returns the serial number of certificate of the TLS server at `address`.
+func testGetPeerCertificateSerial(address, serverName string) (int64, error) {
+	conn, err := tls.Dial("tcp", address, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func Test_HTTPS_CertAutoReload(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir      = gfile.Temp(guid.S())
+			certFile = gfile.Join(dir, "server.crt")
+			keyFile  = gfile.Join(dir, "server.key")
+		)
+		defer gfile.Remove(dir)
+		testWriteCertificate(t, certFile, keyFile, 1)
+
+		s := g.Server(guid.S())
+		s.BindHandler("/", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		s.EnableHTTPS(certFile, keyFile)
+		s.SetHTTPSCertAutoReload(true)
+		s.SetHTTPSPort(0)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		address := fmt.Sprintf("127.0.0.1:%d", s.GetListenedHTTPSPort())
+		serial, err := testGetPeerCertificateSerial(address, "localhost")
+		t.AssertNil(err)
+		t.Assert(serial, 1)
+
+		testWriteCertificate(t, certFile, keyFile, 2)
+		time.Sleep(time.Second)
+		serial, err = testGetPeerCertificateSerial(address, "localhost")
+		t.AssertNil(err)
+		t.Assert(serial, 2)
+
+		// The files replaced by atomic renaming.
+		var (
+			tmpCertFile = gfile.Join(dir, "server.crt.tmp")
+			tmpKeyFile  = gfile.Join(dir, "server.key.tmp")
+		)
+		testWriteCertificate(t, tmpCertFile, tmpKeyFile, 3)
+		t.AssertNil(os.Rename(tmpKeyFile, keyFile))
+		t.AssertNil(os.Rename(tmpCertFile, certFile))
+		time.Sleep(time.Second)
+		serial, err = testGetPeerCertificateSerial(address, "localhost")
+		t.AssertNil(err)
+		t.Assert(serial, 3)
+
+		// It keeps the previous certificate if the files are invalid.
+		t.AssertNil(gfile.PutContents(keyFile, "invalid"))
+		time.Sleep(time.Second)
+		serial, err = testGetPeerCertificateSerial(address, "localhost")
+		t.AssertNil(err)
+		t.Assert(serial, 3)
+	})
+}
+
+// Test_HTTPS_CertAutoReload_Symlink tests the certificate files swapped by symlink like Kubernetes secret volume,
+// in which the files are symlinks to "..data/server.crt", and "..data" is a symlink to versioned directory.
+func Test_HTTPS_CertAutoReload_Symlink(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir      = gfile.Temp(guid.S())
+			certFile = gfile.Join(dir, "server.crt")
+			keyFile  = gfile.Join(dir, "server.key")
+			dataLink = gfile.Join(dir, "..data")
+		)
+		defer gfile.Remove(dir)
+		testWriteCertificate(t, gfile.Join(dir, "v1", "server.crt"), gfile.Join(dir, "v1", "server.key"), 1)
+		t.AssertNil(os.Symlink("v1", dataLink))
+		t.AssertNil(os.Symlink(gfile.Join("..data", "server.crt"), certFile))
+		t.AssertNil(os.Symlink(gfile.Join("..data", "server.key"), keyFile))
+
+		s := g.Server(guid.S())
+		s.BindHandler("/", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		s.EnableHTTPS(certFile, keyFile)
+		s.SetHTTPSCertAutoReload(true)
+		s.SetHTTPSPort(0)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		address := fmt.Sprintf("127.0.0.1:%d", s.GetListenedHTTPSPort())
+		serial, err := testGetPeerCertificateSerial(address, "localhost")
+		t.AssertNil(err)
+		t.Assert(serial, 1)
+
+		// Swaps the "..data" symlink atomically.
+		tmpLink := gfile.Join(dir, "..data_tmp")
+		testWriteCertificate(t, gfile.Join(dir, "v2", "server.crt"), gfile.Join(dir, "v2", "server.key"), 2)
+		t.AssertNil(os.Symlink("v2", tmpLink))
+		t.AssertNil(os.Rename(tmpLink, dataLink))
+		time.Sleep(time.Second)
+		serial, err = testGetPeerCertificateSerial(address, "localhost")
+		t.AssertNil(err)
+		t.Assert(serial, 2)
+	})
+}
+
+func Test_HTTPS_AutoCert(t *testing.T) {
+	var (
+		s     = g.Server(guid.S())
+		cache = gcache.New()
+	)
+	s.BindHandler("/*", func(r *ghttp.Request) {
+		r.Response.Write("app")
+	})
+	s.EnableAutoCert("example.com")
+	s.SetAutoCertCache(cache)
+	s.SetPort(0)
+	s.SetHTTPSPort(0)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// HTTP-01 challenges are answered using tokens in cache.
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(cache.Set(ctx, "ghttp.autocert:token+http-01", []byte("token.thumbprint"), 0))
+
+		request, err := http.NewRequest(
+			http.MethodGet,
+			fmt.Sprintf("http://127.0.0.1:%d/.well-known/acme-challenge/token", s.GetListenedPort()),
+			nil,
+		)
+		t.AssertNil(err)
+		request.Host = "example.com"
+		resp, err := http.DefaultClient.Do(request)
+		t.AssertNil(err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(string(body), "token.thumbprint")
+
+		// The domain is not allowed.
+		request.Host = "other.com"
+		resp2, err := http.DefaultClient.Do(request)
+		t.AssertNil(err)
+		defer resp2.Body.Close()
+		t.Assert(resp2.StatusCode, http.StatusForbidden)
+
+		// Other requests are served by the server as usual.
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/hello"), "app")
+	})
+
+	// The TLS handshake fails for domains not allowed.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := testGetPeerCertificateSerial(fmt.Sprintf("127.0.0.1:%d", s.GetListenedHTTPSPort()), "other.com")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_HTTPS_AutoCert_CacheRequired(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		s.BindHandler("/*", func(r *ghttp.Request) {
+			r.Response.Write("app")
+		})
+		s.EnableAutoCert("example.com")
+		s.SetPort(0)
+		s.SetHTTPSPort(0)
+		s.SetDumpRouterMap(false)
+		err := s.Start()
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidConfiguration)
+	})
+}
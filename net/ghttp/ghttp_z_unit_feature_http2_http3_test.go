@@ -0,0 +1,196 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/genv"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_H2C(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/proto", func(r *ghttp.Request) {
+		r.Response.Write(r.Proto)
+	})
+	s.SetH2CEnabled(true)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// HTTP/2 with prior knowledge.
+	gtest.C(t, func(t *gtest.T) {
+		client := &http.Client{
+			Transport: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, network, addr)
+				},
+			},
+		}
+		resp, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d/proto", s.GetListenedPort()))
+		t.AssertNil(err)
+		defer resp.Body.Close()
+		content, err := io.ReadAll(resp.Body)
+		t.AssertNil(err)
+		t.Assert(resp.ProtoMajor, 2)
+		t.Assert(string(content), "HTTP/2.0")
+	})
+	// HTTP/1.1 is still served.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/proto"), "HTTP/1.1")
+	})
+}
+
+func Test_Server_H2C_Disabled(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/proto", func(r *ghttp.Request) {
+		r.Response.Write(r.Proto)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// HTTP/2 with prior knowledge is not served.
+	gtest.C(t, func(t *gtest.T) {
+		client := &http.Client{
+			Timeout: time.Second,
+			Transport: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, network, addr)
+				},
+			},
+		}
+		_, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d/proto", s.GetListenedPort()))
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/proto"), "HTTP/1.1")
+	})
+}
+
+// testHTTP3Server is a fake HTTP3Server which only holds the UDP connection.
+type testHTTP3Server struct {
+	closed chan struct{}
+}
+
+func (s *testHTTP3Server) Serve(conn net.PacketConn, tlsConfig *tls.Config, handler http.Handler) error {
+	<-s.closed
+	return conn.Close()
+}
+
+func (s *testHTTP3Server) Shutdown(ctx context.Context) error {
+	return s.Close()
+}
+
+func (s *testHTTP3Server) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func Test_Server_HTTP3(t *testing.T) {
+	ghttp.SetHTTP3ServerCreator(func() ghttp.HTTP3Server {
+		return &testHTTP3Server{closed: make(chan struct{})}
+	})
+	defer ghttp.SetHTTP3ServerCreator(nil)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/test", func(r *ghttp.Request) {
+		r.Response.Write("test")
+	})
+	s.EnableHTTPS(
+		gtest.DataPath("https", "files", "server.crt"),
+		gtest.DataPath("https", "files", "server.key"),
+	)
+	s.EnableHTTP3()
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		http3Port := s.GetListenedHTTP3Port()
+		t.AssertGT(http3Port, 0)
+		t.AssertNE(s.GetListenedHTTPSPort(), -1)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", s.GetListenedHTTPSPort()))
+		resp, err := client.Get(ctx, "/test")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "test")
+		t.Assert(resp.Header.Get("Alt-Svc"), fmt.Sprintf(`h3=":%d"; ma=86400`, http3Port))
+	})
+}
+
+func Test_Server_HTTP3_Reload(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file descriptor passing is not supported on windows")
+	}
+	ghttp.SetHTTP3ServerCreator(func() ghttp.HTTP3Server {
+		return &testHTTP3Server{closed: make(chan struct{})}
+	})
+	defer ghttp.SetHTTP3ServerCreator(nil)
+
+	gtest.C(t, func(t *gtest.T) {
+		// The UDP connection passed from parent process.
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		t.AssertNil(err)
+		defer conn.Close()
+		file, err := conn.(*net.UDPConn).File()
+		t.AssertNil(err)
+		defer file.Close()
+
+		var (
+			name = guid.S()
+			port = conn.LocalAddr().(*net.UDPAddr).Port
+		)
+		t.AssertNil(genv.Set("GF_SERVER_RELOAD", fmt.Sprintf(
+			`{"%s":{"http3":"127.0.0.1:%d#%d"}}`, name, port, file.Fd(),
+		)))
+		defer genv.Remove("GF_SERVER_RELOAD")
+
+		s := g.Server(name)
+		s.BindHandler("/test", func(r *ghttp.Request) {
+			r.Response.Write("test")
+		})
+		s.EnableHTTPS(
+			gtest.DataPath("https", "files", "server.crt"),
+			gtest.DataPath("https", "files", "server.key"),
+		)
+		s.EnableHTTP3()
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		t.Assert(s.GetListenedHTTP3Port(), port)
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Cache_ETag(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCache)
+		group.GET("/etag", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		group.GET("/last-modified", func(r *ghttp.Request) {
+			r.Response.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			r.Response.Write("hello")
+		})
+		group.POST("/etag", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/etag")
+		t.AssertNil(err)
+		etag := resp.Header.Get("ETag")
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "hello")
+		t.AssertNE(etag, "")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": etag}).Get(ctx, "/etag")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotModified)
+		t.Assert(resp.ReadAllString(), "")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": `"mismatched"`}).Get(ctx, "/etag")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "hello")
+		resp.Close()
+
+		// It does not handle non GET/HEAD requests.
+		resp, err = client.Post(ctx, "/etag")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("ETag"), "")
+		resp.Close()
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Header(g.MapStrStr{
+			"If-Modified-Since": "Mon, 02 Jan 2006 15:04:05 GMT",
+		}).Get(ctx, "/last-modified")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotModified)
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{
+			"If-Modified-Since": "Sun, 01 Jan 2006 15:04:05 GMT",
+		}).Get(ctx, "/last-modified")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "hello")
+		resp.Close()
+	})
+}
+
+type testMiddlewareCacheReq struct {
+	g.Meta `path:"/cached" method:"get" cache:"1m" cacheVary:"Accept-Language" etag:"weak"`
+	Id     int
+}
+
+type testMiddlewareCacheRes struct {
+	Id    int
+	Count int
+}
+
+func Test_Middleware_Cache_Store(t *testing.T) {
+	var (
+		s     = g.Server(guid.S())
+		count = gtype.NewInt()
+	)
+	s.Use(ghttp.MiddlewareCache, ghttp.MiddlewareHandlerResponse)
+	s.BindHandler("/", func(ctx context.Context, req *testMiddlewareCacheReq) (res *testMiddlewareCacheRes, err error) {
+		return &testMiddlewareCacheRes{
+			Id:    req.Id,
+			Count: count.Add(1),
+		}, nil
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		expect := `{"code":0,"message":"OK","data":{"Id":1,"Count":1}}`
+		resp, err := client.Get(ctx, "/cached?id=1")
+		t.AssertNil(err)
+		etag := resp.Header.Get("ETag")
+		t.Assert(resp.ReadAllString(), expect)
+		t.Assert(etag[:2], "W/")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Language")
+		t.AssertNE(resp.Header.Get("Last-Modified"), "")
+		resp.Close()
+
+		// Served from cache.
+		t.Assert(client.GetContent(ctx, "/cached?id=1"), expect)
+		t.Assert(count.Val(), 1)
+
+		// Different parameters and vary headers.
+		t.Assert(client.GetContent(ctx, "/cached?id=2"), `{"code":0,"message":"OK","data":{"Id":2,"Count":2}}`)
+		t.Assert(
+			client.Header(g.MapStrStr{"Accept-Language": "en"}).GetContent(ctx, "/cached?id=1"),
+			`{"code":0,"message":"OK","data":{"Id":1,"Count":3}}`,
+		)
+
+		// Conditional request against the cached response.
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": etag}).Get(ctx, "/cached?id=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotModified)
+		resp.Close()
+		t.Assert(count.Val(), 3)
+
+		// Bypass cache using no-cache request.
+		t.Assert(
+			client.Header(g.MapStrStr{"Cache-Control": "no-cache"}).GetContent(ctx, "/cached?id=1"),
+			`{"code":0,"message":"OK","data":{"Id":1,"Count":4}}`,
+		)
+	})
+}
+
+func Test_Middleware_Cache_Size(t *testing.T) {
+	var (
+		s     = g.Server(guid.S())
+		count = gtype.NewInt()
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCacheWithOption(ghttp.CacheOption{
+			TTL:       time.Minute,
+			CacheSize: 1,
+		}))
+		group.GET("/size", func(r *ghttp.Request) {
+			r.Response.Writef("%s:%d", r.Get("id"), count.Add(1))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/size?id=1"), "1:1")
+		t.Assert(client.GetContent(ctx, "/size?id=1"), "1:1")
+		// The least recently used response is evicted.
+		t.Assert(client.GetContent(ctx, "/size?id=2"), "2:2")
+		t.Assert(client.GetContent(ctx, "/size?id=1"), "1:3")
+		t.Assert(client.GetContent(ctx, "/size?id=1"), "1:3")
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type testGuardUploadReq struct {
+	g.Meta  `path:"/upload" method:"post" maxBody:"1KB"`
+	Content string
+}
+
+type testGuardUploadRes struct {
+	Length int
+}
+
+type testGuardSlowReq struct {
+	g.Meta `path:"/slow" method:"get" timeout:"100ms"`
+}
+
+type testGuardSlowRes struct{}
+
+type testGuardLimitedReq struct {
+	g.Meta `path:"/limited" method:"get" maxConcurrent:"1"`
+}
+
+type testGuardLimitedRes struct{}
+
+type testGuard struct {
+	limitedEnter chan struct{}
+	limitedLeave chan struct{}
+}
+
+func (c *testGuard) Upload(ctx context.Context, req *testGuardUploadReq) (res *testGuardUploadRes, err error) {
+	return &testGuardUploadRes{Length: len(req.Content)}, nil
+}
+
+func (c *testGuard) Slow(ctx context.Context, req *testGuardSlowReq) (res *testGuardSlowRes, err error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(time.Second):
+		return &testGuardSlowRes{}, nil
+	}
+}
+
+func (c *testGuard) Limited(ctx context.Context, req *testGuardLimitedReq) (res *testGuardLimitedRes, err error) {
+	c.limitedEnter <- struct{}{}
+	<-c.limitedLeave
+	return &testGuardLimitedRes{}, nil
+}
+
+func Test_Middleware_Guard(t *testing.T) {
+	var (
+		s          = g.Server(guid.S())
+		controller = &testGuard{
+			limitedEnter: make(chan struct{}),
+			limitedLeave: make(chan struct{}),
+		}
+	)
+	s.SetClientMaxBodySize(100)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse, ghttp.MiddlewareGuard)
+		group.Bind(controller)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	// Body size limit overwriting the server-wide limit.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(
+			client.PostContent(ctx, "/upload", g.Map{"content": strings.Repeat("a", 500)}),
+			`{"code":0,"message":"OK","data":{"Length":500}}`,
+		)
+		resp, err := client.Post(ctx, "/upload", g.Map{"content": strings.Repeat("a", 2000)})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, http.StatusRequestEntityTooLarge)
+		t.Assert(strings.HasPrefix(resp.ReadAllString(), `{"code":66,`), true)
+	})
+
+	// Handler timeout.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		start := time.Now()
+		resp, err := client.Get(ctx, "/slow")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.AssertLT(time.Since(start), time.Second)
+		t.Assert(resp.StatusCode, http.StatusGatewayTimeout)
+		t.Assert(resp.ReadAllString(), `{"code":63,"message":"request timeout after 100ms","data":null}`)
+	})
+
+	// Concurrency limit.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetContent(ctx, "/limited")
+		}()
+		<-controller.limitedEnter
+
+		resp, err := client.Get(ctx, "/limited")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.Assert(resp.ReadAllString(), `{"code":63,"message":"too many concurrent requests","data":null}`)
+		resp.Close()
+
+		controller.limitedLeave <- struct{}{}
+		wg.Wait()
+	})
+}
+
+func Test_Middleware_Guard_GroupOption(t *testing.T) {
+	var (
+		s     = g.Server(guid.S())
+		count = gtype.NewInt()
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse, ghttp.MiddlewareGuardWithOption(ghttp.GuardOption{
+			Timeout:       50 * time.Millisecond,
+			TimeoutStatus: http.StatusServiceUnavailable,
+		}))
+		group.GET("/group-slow", func(r *ghttp.Request) {
+			count.Add(1)
+			<-r.Context().Done()
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/group-slow")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.Assert(resp.ReadAllString(), `{"code":63,"message":"request timeout after 50ms","data":null}`)
+		t.Assert(count.Val(), 1)
+	})
+}
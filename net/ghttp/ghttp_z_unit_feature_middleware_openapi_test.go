@@ -0,0 +1,261 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/gogf/gf/v2/util/gvalid"
+)
+
+const testOpenApiDocument = `
+openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+servers:
+  - url: /api
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        schema:
+          type: integer
+          minimum: 1
+    get:
+      parameters:
+        - name: fields
+          in: query
+          schema:
+            type: string
+            enum: [name, email]
+        - name: X-Trace-Id
+          in: header
+          schema:
+            type: string
+            pattern: ^[0-9a-f]+$
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, email]
+              properties:
+                name:
+                  type: string
+                  minLength: 2
+                email:
+                  type: string
+                  format: email
+                age:
+                  type: integer
+                  exclusiveMinimum: 0
+                tags:
+                  type: array
+                  maxItems: 2
+                  items:
+                    type: string
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+
+func Test_Middleware_OpenApiValidation_Document(t *testing.T) {
+	var (
+		s         = g.Server(guid.S())
+		lastError error
+	)
+	s.Group("/api", func(group *ghttp.RouterGroup) {
+		group.Middleware(
+			ghttp.MiddlewareHandlerResponse,
+			func(r *ghttp.Request) {
+				r.Middleware.Next()
+				lastError = r.GetError()
+			},
+			ghttp.MiddlewareOpenApiValidationWithOption(ghttp.OpenApiValidationOption{
+				Document:         testOpenApiDocument,
+				Strict:           true,
+				ValidateResponse: true,
+			}),
+		)
+		group.GET("/users/{id}", func(r *ghttp.Request) {
+			if r.Get("id").Int() == 2 {
+				// Invalid response missing required field.
+				r.Response.WriteJson(g.Map{"id": 2})
+				return
+			}
+			r.Response.WriteJson(g.Map{"id": r.Get("id").Int(), "name": "john"})
+		})
+		group.POST("/users", func(r *ghttp.Request) {
+			r.Response.Write("created")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Valid request and response.
+		t.Assert(client.GetContent(ctx, "/api/users/1?fields=name"), `{"id":1,"name":"john"}`)
+
+		// Invalid path parameter.
+		resp, err := client.Get(ctx, "/api/users/abc")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadRequest)
+		t.Assert(resp.ReadAllString(), `{"code":51,"message":"The id value must be of type integer","data":null}`)
+		resp.Close()
+
+		// Invalid and unknown query parameters.
+		resp, err = client.Get(ctx, "/api/users/1?fields=phone&debug=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadRequest)
+		resp.Close()
+		verr, ok := lastError.(gvalid.Error)
+		t.Assert(ok, true)
+		t.Assert(gerror.Code(verr), gcode.CodeValidationFailed)
+		t.Assert(len(verr.Maps()), 2)
+		t.AssertNE(verr.Maps()["fields"]["in"], nil)
+		t.AssertNE(verr.Maps()["debug"]["oai-unknown"], nil)
+
+		// Invalid header parameter, whose name is reported as it is.
+		resp, err = client.Header(g.MapStrStr{"X-Trace-Id": "xyz"}).Get(ctx, "/api/users/1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadRequest)
+		resp.Close()
+		verr, ok = lastError.(gvalid.Error)
+		t.Assert(ok, true)
+		t.AssertNE(verr.Maps()["X-Trace-Id"]["regex"], nil)
+		t.Assert(verr.FirstError().Error(), "The X-Trace-Id value `xyz` must be in regex of: ^[0-9a-f]+$")
+		t.Assert(client.Header(g.MapStrStr{"X-Trace-Id": "abc"}).GetContent(ctx, "/api/users/1"), `{"id":1,"name":"john"}`)
+
+		// Invalid response.
+		resp, err = client.Get(ctx, "/api/users/2")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusInternalServerError)
+		resp.Close()
+		t.Assert(gerror.Code(lastError), gcode.CodeInternalError)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().ContentJson()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.PostContent(ctx, "/api/users", g.Map{"name": "john", "email": "john@example.com"}), "created")
+
+		// Missing body.
+		resp, err := client.Post(ctx, "/api/users")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadRequest)
+		t.Assert(resp.ReadAllString(), `{"code":51,"message":"The body field is required","data":null}`)
+		resp.Close()
+
+		// Invalid fields.
+		resp, err = client.Post(ctx, "/api/users", g.Map{
+			"name":    "j",
+			"email":   "john",
+			"age":     0,
+			"tags":    g.Slice{"a", 1, "c"},
+			"unknown": true,
+		})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadRequest)
+		resp.Close()
+		verr, ok := lastError.(gvalid.Error)
+		t.Assert(ok, true)
+		maps := verr.Maps()
+		t.AssertNE(maps["name"]["min-length"], nil)
+		t.AssertNE(maps["email"]["email"], nil)
+		t.AssertNE(maps["age"]["oai-gt"], nil)
+		t.AssertNE(maps["tags"]["oai-items"], nil)
+		t.AssertNE(maps["tags.1"]["oai-type"], nil)
+		t.Assert(maps["tags.1"]["oai-type"].Error(), "The tags.1 value must be of type string")
+		t.AssertNE(maps["unknown"]["oai-unknown"], nil)
+		t.Assert(verr.FirstError().Error(), "The age value 0 must be greater than 0")
+	})
+}
+
+type testOpenApiValidationReq struct {
+	g.Meta `path:"/validation" method:"get"`
+	Page   int `json:"page" in:"query" v:"min:1"`
+}
+
+type testOpenApiValidationRes struct {
+	Page int `json:"page"`
+}
+
+type testOpenApiValidation struct{}
+
+func (c *testOpenApiValidation) Get(ctx context.Context, req *testOpenApiValidationReq) (res *testOpenApiValidationRes, err error) {
+	return &testOpenApiValidationRes{Page: req.Page}, nil
+}
+
+func Test_Middleware_OpenApiValidation_Generated(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse, ghttp.MiddlewareOpenApiValidation)
+		group.Bind(new(testOpenApiValidation))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/validation?page=2"), `{"code":0,"message":"OK","data":{"page":2}}`)
+
+		resp, err := client.Get(ctx, "/validation?page=a")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadRequest)
+		t.Assert(resp.ReadAllString(), `{"code":51,"message":"The page value must be of type integer","data":null}`)
+		resp.Close()
+
+		// The constraints not in document are validated by the handler.
+		resp, err = client.Get(ctx, "/validation?page=0")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "{\"code\":51,\"message\":\"The page value `0` must be equal or greater than 1\",\"data\":null}")
+		resp.Close()
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Compress(t *testing.T) {
+	var (
+		s        = g.Server(guid.S())
+		expected = strings.Repeat("Hello World! ", 1000)
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompress)
+		group.ALL("/", func(r *ghttp.Request) {
+			r.Response.Write(expected)
+		})
+		group.ALL("/small", func(r *ghttp.Request) {
+			r.Response.Write("Small response")
+		})
+		group.ALL("/binary", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "image/png")
+			r.Response.Write(expected)
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Server preference order, the br and zstd encodings are not registered.
+		resp, err := client.Header(g.MapStrStr{"Accept-Encoding": "deflate, br, zstd, gzip"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		content, err := gcompress.UnGzip(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(string(content), expected)
+		resp.Close()
+
+		// Q-values.
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "br, gzip;q=0.5, deflate;q=0.8"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "deflate")
+		content, err = gcompress.UnZlib(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(string(content), expected)
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "*;q=0.5, deflate;q=0"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		content, err = gcompress.UnGzip(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(string(content), expected)
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "deflate"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "deflate")
+		content, err = gcompress.UnZlib(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(string(content), expected)
+		resp.Close()
+
+		// Not acceptable encodings.
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "gzip;q=0, compress"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), expected)
+		resp.Close()
+
+		// Too small or not compressible.
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "gzip"}).Get(ctx, "/small")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), "Small response")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "gzip"}).Get(ctx, "/binary")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		resp.Close()
+	})
+}
+
+func Test_Middleware_Compress_Streaming(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompressWithOption(ghttp.CompressOption{
+			Streaming: true,
+		}))
+		group.ALL("/sse", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "text/event-stream")
+			for i := 0; i < 3; i++ {
+				r.Response.Writef("data: %d\n\n", i)
+				r.Response.Flush()
+			}
+		})
+		group.ALL("/buffered", func(r *ghttp.Request) {
+			r.Response.Write(strings.Repeat("Hello World! ", 1000))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		url := fmt.Sprintf("http://127.0.0.1:%d/sse", s.GetListenedPort())
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		t.AssertNil(err)
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp, err := http.DefaultClient.Do(req)
+		t.AssertNil(err)
+		defer resp.Body.Close()
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.Header.Get("Content-Type"), "text/event-stream")
+
+		reader, err := gzip.NewReader(resp.Body)
+		t.AssertNil(err)
+		content, err := io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(string(content), "data: 0\n\ndata: 1\n\ndata: 2\n\n")
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		resp, err := client.Header(g.MapStrStr{"Accept-Encoding": "deflate"}).Get(ctx, "/buffered")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "deflate")
+		content, err := gcompress.UnZlib(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(string(content), strings.Repeat("Hello World! ", 1000))
+		resp.Close()
+	})
+}
+
+func Test_Middleware_Compress_RegisterEncoder(t *testing.T) {
+	ghttp.RegisterCompressEncoder("X-Test", func(writer io.Writer) (ghttp.CompressEncoder, error) {
+		return gzip.NewWriter(writer), nil
+	})
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompressWithOption(ghttp.CompressOption{
+			Encodings: []string{"x-test", ghttp.EncodingGzip},
+		}))
+		group.ALL("/", func(r *ghttp.Request) {
+			r.Response.Write(strings.Repeat("Hello World! ", 1000))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		resp, err := client.Header(g.MapStrStr{"Accept-Encoding": "gzip, x-test"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "x-test")
+		content, err := gcompress.UnGzip(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(string(content), strings.Repeat("Hello World! ", 1000))
+		resp.Close()
+	})
+}
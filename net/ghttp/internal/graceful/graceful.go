@@ -30,6 +30,9 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
 	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -53,15 +56,30 @@ const (
 
 // Server wraps the net/http.Server with graceful reload/restart feature.
 type Server struct {
-	fd          uintptr      // File descriptor for passing to the child process when graceful reload.
-	address     string       // Listening address like ":80", ":8080".
-	httpServer  *http.Server // Underlying http.Server.
-	rawListener net.Listener // Underlying net.Listener.
-	rawLnMu     sync.RWMutex // Concurrent safety mutex for rawListener.
-	listener    net.Listener // Wrapped net.Listener with TLS support if necessary.
-	isHttps     bool         // Whether server is running in HTTPS mode.
-	status      *gtype.Int   // Server status using gtype for concurrent safety.
-	config      ServerConfig // Server configuration.
+	fd          uintptr        // File descriptor for passing to the child process when graceful reload.
+	address     string         // Listening address like ":80", ":8080".
+	httpServer  *http.Server   // Underlying http.Server.
+	rawListener net.Listener   // Underlying net.Listener.
+	rawLnMu     sync.RWMutex   // Concurrent safety mutex for rawListener.
+	listener    net.Listener   // Wrapped net.Listener with TLS support if necessary.
+	isHttps     bool           // Whether server is running in HTTPS mode.
+	http3Server HTTP3Server    // Underlying HTTP/3 server, which is not nil only if the server serves HTTP/3.
+	packetConn  net.PacketConn // Underlying UDP connection for HTTP/3.
+	tlsConfig   *tls.Config    // TLS configuration for HTTP/3.
+	status      *gtype.Int     // Server status using gtype for concurrent safety.
+	config      ServerConfig   // Server configuration.
+}
+
+// HTTP3Server is the interface for HTTP/3 server implementation over QUIC.
+type HTTP3Server interface {
+	// Serve serves HTTP/3 requests on `conn` using `handler` in blocking way.
+	Serve(conn net.PacketConn, tlsConfig *tls.Config, handler http.Handler) error
+
+	// Shutdown gracefully shuts down the server without interrupting any active requests.
+	Shutdown(ctx context.Context) error
+
+	// Close closes the server immediately.
+	Close() error
 }
 
 // ServerConfig is the graceful Server configuration manager.
@@ -108,6 +126,9 @@ type ServerConfig struct {
 	// KeepAlive enables HTTP keep-alive.
 	KeepAlive bool `json:"keepAlive"`
 
+	// H2CEnabled enables HTTP/2 cleartext (h2c) for the non-TLS server.
+	H2CEnabled bool `json:"h2cEnabled"`
+
 	// Logger specifies the logger for server.
 	Logger *glog.Logger `json:"logger"`
 }
@@ -148,6 +169,25 @@ func New(
 	return gs
 }
 
+// NewHTTP3 creates and returns a graceful HTTP/3 server with a given UDP address.
+// The parameter `fd` specifies the file descriptor of UDP connection which is passed from parent server.
+//
+// Note that the UDP connection is shared by the parent and child processes during graceful reload,
+// the QUIC connections that the parent is still serving might be interrupted by the packets received by the child.
+func NewHTTP3(
+	address string,
+	fd int,
+	http3Server HTTP3Server,
+	loggerWriter io.Writer,
+	config ServerConfig,
+) *Server {
+	gs := New(address, fd, loggerWriter, config)
+	gs.http3Server = http3Server
+	gs.rawListener = nil
+	gs.isHttps = true
+	return gs
+}
+
 // newHttpServer creates and returns an underlying http.Server with a given address.
 func newHttpServer(
 	address string,
@@ -170,6 +210,13 @@ func newHttpServer(
 // Fd retrieves and returns the file descriptor of the current server.
 // It is available ony in *nix like operating systems like linux, unix, darwin.
 func (s *Server) Fd() uintptr {
+	if s.packetConn != nil {
+		file, err := s.packetConn.(*net.UDPConn).File()
+		if err == nil {
+			return file.Fd()
+		}
+		return 0
+	}
 	if ln := s.getRawListener(); ln != nil {
 		file, err := ln.(*net.TCPListener).File()
 		if err == nil {
@@ -185,16 +232,58 @@ func (s *Server) CreateListener() error {
 	if err != nil {
 		return err
 	}
+	if s.config.H2CEnabled {
+		// It configures the http2.Server for h2c connections, which also
+		// sends GOAWAY frames to these connections when the server shuts down.
+		h2s := &http2.Server{IdleTimeout: s.config.IdleTimeout}
+		if err = http2.ConfigureServer(s.httpServer, h2s); err != nil {
+			return gerror.Wrap(err, `http2.ConfigureServer failed`)
+		}
+		s.httpServer.Handler = h2c.NewHandler(s.httpServer.Handler, h2s)
+	}
 	s.listener = ln
 	s.setRawListener(ln)
 	return nil
 }
 
+// CreateListenerHTTP3 creates UDP listener on configured address for HTTP/3.
+// The parameter `certFile` and `keyFile` specify the necessary certification and key files for TLS.
+// The optional parameter `tlsConfig` specifies the custom TLS configuration.
+func (s *Server) CreateListenerHTTP3(certFile, keyFile string, tlsConfig ...*tls.Config) error {
+	var config *tls.Config
+	if len(tlsConfig) > 0 && tlsConfig[0] != nil {
+		config = tlsConfig[0].Clone()
+	} else {
+		config = &tls.Config{}
+	}
+	// HTTP/3 requires its own ALPN protocol, the implementation sets it.
+	config.NextProtos = nil
+	if len(config.Certificates) == 0 && config.GetCertificate == nil {
+		certificate, err := loadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	conn, err := s.getPacketConn()
+	if err != nil {
+		return err
+	}
+	s.packetConn = conn
+	s.tlsConfig = config
+	return nil
+}
+
 // IsHttps returns whether the server is running in HTTPS mode.
 func (s *Server) IsHttps() bool {
 	return s.isHttps
 }
 
+// IsHTTP3 returns whether the server is running in HTTP/3 mode.
+func (s *Server) IsHTTP3() bool {
+	return s.http3Server != nil
+}
+
 // GetAddress returns the server's configured address.
 func (s *Server) GetAddress() string {
 	return s.address
@@ -221,20 +310,12 @@ func (s *Server) CreateListenerTLS(certFile, keyFile string, tlsConfig ...*tls.C
 	if config.NextProtos == nil {
 		config.NextProtos = []string{"http/1.1"}
 	}
-	var err error
 	if len(config.Certificates) == 0 && config.GetCertificate == nil {
-		config.Certificates = make([]tls.Certificate, 1)
-		if gres.Contains(certFile) {
-			config.Certificates[0], err = tls.X509KeyPair(
-				gres.GetContent(certFile),
-				gres.GetContent(keyFile),
-			)
-		} else {
-			config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
+		certificate, err := loadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
 		}
-	}
-	if err != nil {
-		return gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
+		config.Certificates = []tls.Certificate{certificate}
 	}
 	ln, err := s.getNetListener()
 	if err != nil {
@@ -246,8 +327,28 @@ func (s *Server) CreateListenerTLS(certFile, keyFile string, tlsConfig ...*tls.C
 	return nil
 }
 
+// loadX509KeyPair loads the certificate from `certFile` and `keyFile`,
+// which can be either files or resources.
+func loadX509KeyPair(certFile, keyFile string) (certificate tls.Certificate, err error) {
+	if gres.Contains(certFile) {
+		certificate, err = tls.X509KeyPair(
+			gres.GetContent(certFile),
+			gres.GetContent(keyFile),
+		)
+	} else {
+		certificate, err = tls.LoadX509KeyPair(certFile, keyFile)
+	}
+	if err != nil {
+		err = gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
+	}
+	return
+}
+
 // Serve starts the serving with blocking way.
 func (s *Server) Serve(ctx context.Context) error {
+	if s.http3Server != nil {
+		return s.serveHTTP3(ctx)
+	}
 	if s.rawListener == nil {
 		return gerror.NewCode(gcode.CodeInvalidOperation, `call CreateListener/CreateListenerTLS before Serve`)
 	}
@@ -267,6 +368,26 @@ func (s *Server) Serve(ctx context.Context) error {
 	return err
 }
 
+// serveHTTP3 starts the HTTP/3 serving with blocking way.
+func (s *Server) serveHTTP3(ctx context.Context) error {
+	if s.packetConn == nil {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `call CreateListenerHTTP3 before Serve`)
+	}
+	var action = "started"
+	if s.fd != 0 {
+		action = "reloaded"
+	}
+	s.config.Logger.Infof(
+		ctx,
+		`pid[%d]: %s server %s listening on [%s]`,
+		gproc.Pid(), s.getProto(), action, s.GetListenedAddress(),
+	)
+	s.status.Set(ServerStatusRunning)
+	err := s.http3Server.Serve(s.packetConn, s.tlsConfig, s.httpServer.Handler)
+	s.status.Set(ServerStatusStopped)
+	return err
+}
+
 // GetListenedAddress retrieves and returns the address string which are listened by current server.
 func (s *Server) GetListenedAddress() string {
 	if !gstr.Contains(s.address, FreePortAddress) {
@@ -283,6 +404,9 @@ func (s *Server) GetListenedAddress() string {
 // GetListenedPort retrieves and returns one port which is listened to by current server.
 // Note that this method is only available if the server is listening on one port.
 func (s *Server) GetListenedPort() int {
+	if s.packetConn != nil {
+		return s.packetConn.LocalAddr().(*net.UDPAddr).Port
+	}
 	if ln := s.getRawListener(); ln != nil {
 		return ln.Addr().(*net.TCPAddr).Port
 	}
@@ -298,7 +422,9 @@ func (s *Server) Status() ServerStatus {
 // getProto retrieves and returns the proto string of current server.
 func (s *Server) getProto() string {
 	proto := "http"
-	if s.isHttps {
+	if s.http3Server != nil {
+		proto = "http3"
+	} else if s.isHttps {
 		proto = "https"
 	}
 	return proto
@@ -329,6 +455,23 @@ func (s *Server) getNetListener() (net.Listener, error) {
 	return ln, err
 }
 
+// getPacketConn retrieves and returns the UDP connection for HTTP/3.
+func (s *Server) getPacketConn() (net.PacketConn, error) {
+	if s.fd > 0 {
+		f := os.NewFile(s.fd, "")
+		conn, err := net.FilePacketConn(f)
+		if err != nil {
+			return nil, gerror.Wrap(err, "net.FilePacketConn failed")
+		}
+		return conn, nil
+	}
+	conn, err := net.ListenPacket("udp", s.address)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `net.ListenPacket address "%s" failed`, s.address)
+	}
+	return conn, nil
+}
+
 // Shutdown shuts down the server gracefully.
 func (s *Server) Shutdown(ctx context.Context) {
 	if s.status.Val() == ServerStatusStopped {
@@ -339,6 +482,16 @@ func (s *Server) Shutdown(ctx context.Context) {
 		time.Duration(s.config.GracefulShutdownTimeout)*time.Second,
 	)
 	defer cancelFunc()
+	if s.http3Server != nil {
+		if err := s.http3Server.Shutdown(timeoutCtx); err != nil {
+			s.config.Logger.Errorf(
+				ctx,
+				"%d: %s server [%s] shutdown error: %v",
+				gproc.Pid(), s.getProto(), s.address, err,
+			)
+		}
+		return
+	}
 	if err := s.httpServer.Shutdown(timeoutCtx); err != nil {
 		s.config.Logger.Errorf(
 			ctx,
@@ -368,6 +521,16 @@ func (s *Server) Close(ctx context.Context) {
 	if s.status.Val() == ServerStatusStopped {
 		return
 	}
+	if s.http3Server != nil {
+		if err := s.http3Server.Close(); err != nil {
+			s.config.Logger.Errorf(
+				ctx,
+				"%d: %s server [%s] closed error: %v",
+				gproc.Pid(), s.getProto(), s.address, err,
+			)
+		}
+		return
+	}
 	if err := s.httpServer.Close(); err != nil {
 		s.config.Logger.Errorf(
 			ctx,
@@ -0,0 +1,37 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package graceful
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_CreateListener_H2C(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	// The handler chain is not changed if h2c is disabled.
+	gtest.C(t, func(t *gtest.T) {
+		s := New("127.0.0.1:0", 0, io.Discard, ServerConfig{Handler: handler})
+		t.AssertNil(s.CreateListener())
+		defer s.listener.Close()
+		_, ok := s.httpServer.Handler.(http.HandlerFunc)
+		t.Assert(ok, true)
+		t.Assert(len(s.httpServer.TLSNextProto), 0)
+	})
+	// The handler is wrapped for h2c connections if h2c is enabled.
+	gtest.C(t, func(t *gtest.T) {
+		s := New("127.0.0.1:0", 0, io.Discard, ServerConfig{Handler: handler, H2CEnabled: true})
+		t.AssertNil(s.CreateListener())
+		defer s.listener.Close()
+		_, ok := s.httpServer.Handler.(http.HandlerFunc)
+		t.Assert(ok, false)
+		t.AssertNE(s.httpServer.TLSNextProto["h2"], nil)
+	})
+}
@@ -82,7 +82,8 @@ func (w *BufferWriter) Flush() {
 		w.Writer.WriteHeader(w.Status)
 	}
 	// Default status text output.
-	if w.Status != http.StatusOK && w.buffer.Len() == 0 && w.BytesWritten() == 0 {
+	// Note that the status 1xx, 204 and 304 do not allow response body.
+	if w.Status != http.StatusOK && w.buffer.Len() == 0 && w.BytesWritten() == 0 && BodyAllowedForStatus(w.Status) {
 		w.buffer.WriteString(http.StatusText(w.Status))
 	}
 	if w.buffer.Len() > 0 {
@@ -93,3 +94,17 @@ func (w *BufferWriter) Flush() {
 		}
 	}
 }
+
+// BodyAllowedForStatus reports whether a given response status code permits a body.
+// See RFC 7230, section 3.3.
+func BodyAllowedForStatus(status int) bool {
+	switch {
+	case status >= 100 && status <= 199:
+		return false
+	case status == http.StatusNoContent:
+		return false
+	case status == http.StatusNotModified:
+		return false
+	}
+	return true
+}
@@ -35,8 +35,26 @@ type OpenApiV3 struct {
 	Servers      *Servers              `json:"servers,omitempty"`
 	Tags         *Tags                 `json:"tags,omitempty"`
 	ExternalDocs *ExternalDocs         `json:"externalDocs,omitempty"`
+
+	// Webhooks are the incoming webhooks that may be received as part of this API,
+	// which is only available in OpenAPI 3.1.
+	Webhooks Paths `json:"webhooks,omitempty"`
+
+	// JSONSchemaDialect is the default value for the `$schema` keyword within Schema Objects,
+	// which is only available in OpenAPI 3.1 and defaults to JSONSchemaDialectOAS31.
+	JSONSchemaDialect string `json:"jsonSchemaDialect,omitempty"`
 }
 
+const (
+	OpenAPIVersion30 = `3.0.0` // OpenAPI 3.0, which is the default version.
+	OpenAPIVersion31 = `3.1.0` // OpenAPI 3.1, which uses JSON Schema 2020-12 for schemas.
+)
+
+const (
+	JSONSchemaDialectOAS31       = `https://spec.openapis.org/oas/3.1/dialect/base` // Default JSON Schema dialect of OpenAPI 3.1.
+	JSONSchemaDialectDraft202012 = `https://json-schema.org/draft/2020-12/schema`   // JSON Schema 2020-12 dialect.
+)
+
 const (
 	TypeInteger    = `integer`
 	TypeNumber     = `number`
@@ -97,10 +115,11 @@ func New() *OpenApiV3 {
 
 // AddInput is the structured parameter for function OpenApiV3.Add.
 type AddInput struct {
-	Path   string // Path specifies the custom path if this is not configured in Meta of struct tag.
-	Prefix string // Prefix specifies the custom route path prefix, which will be added with the path tag in Meta of struct tag.
-	Method string // Method specifies the custom HTTP method if this is not configured in Meta of struct tag.
-	Object any    // Object can be an instance of struct or a route function.
+	Path    string // Path specifies the custom path if this is not configured in Meta of struct tag.
+	Prefix  string // Prefix specifies the custom route path prefix, which will be added with the path tag in Meta of struct tag.
+	Method  string // Method specifies the custom HTTP method if this is not configured in Meta of struct tag.
+	Object  any    // Object can be an instance of struct or a route function.
+	Webhook string // Webhook specifies the webhook name, which adds the route function as webhook instead of path (OpenAPI 3.1 only).
 }
 
 // Add adds an instance of struct or a route function to OpenApiV3 definition implements.
@@ -120,6 +139,7 @@ func (oai *OpenApiV3) Add(in AddInput) error {
 			Path:     in.Path,
 			Prefix:   in.Prefix,
 			Method:   in.Method,
+			Webhook:  in.Webhook,
 			Function: in.Object,
 		})
 
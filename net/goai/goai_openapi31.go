@@ -0,0 +1,354 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"bytes"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// jsonField is a field of JSON object, which keeps the field order when decoding and encoding JSON object.
+type jsonField struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// jsonFields is an ordered JSON object.
+type jsonFields []jsonField
+
+// jsonNodeConverter converts a JSON node of OpenAPI document.
+type jsonNodeConverter func(data json.RawMessage) (json.RawMessage, error)
+
+var (
+	// pathItemOperationKeys are the operation field names of Path Item object.
+	pathItemOperationKeys = []string{
+		"get", "put", "post", "delete", "options", "head", "patch", "trace", "connect",
+	}
+	// schemaKeysForSingleSchema are the schema keywords that have a single sub-schema as value.
+	schemaKeysForSingleSchema = []string{
+		"items", "not", "additionalProperties", "contains", "if", "then", "else",
+		"propertyNames", "unevaluatedItems", "unevaluatedProperties",
+	}
+	// schemaKeysForSchemaArray are the schema keywords that have a sub-schema array as value.
+	schemaKeysForSchemaArray = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+	// schemaKeysForSchemaMap are the schema keywords that have a sub-schema map as value.
+	schemaKeysForSchemaMap = []string{"properties", "patternProperties", "$defs", "dependentSchemas"}
+)
+
+// IsOpenAPI31 checks and returns whether the document is OpenAPI 3.1,
+// which outputs JSON Schema 2020-12 compatible schemas.
+func (oai *OpenApiV3) IsOpenAPI31() bool {
+	return gstr.HasPrefix(oai.OpenAPI, "3.1")
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+// It converts the OpenAPI 3.0 structures to OpenAPI 3.1 if the document version is 3.1.
+func (oai OpenApiV3) MarshalJSON() ([]byte, error) {
+	type tempOpenApiV3 OpenApiV3 // To prevent JSON marshal recursion error.
+	if !oai.IsOpenAPI31() {
+		// Webhooks and JSON Schema dialect are not supported in OpenAPI 3.0.
+		oai.Webhooks = nil
+		oai.JSONSchemaDialect = ""
+		return json.Marshal(tempOpenApiV3(oai))
+	}
+	if oai.JSONSchemaDialect == "" {
+		oai.JSONSchemaDialect = JSONSchemaDialectOAS31
+	}
+	b, err := json.Marshal(tempOpenApiV3(oai))
+	if err != nil {
+		return nil, err
+	}
+	return convertV31Object(b, map[string]jsonNodeConverter{
+		"components": convertV31Components,
+		"paths":      convertV31Paths,
+		"webhooks":   convertV31Paths,
+	})
+}
+
+// convertV31Components converts the Components object to OpenAPI 3.1.
+func convertV31Components(data json.RawMessage) (json.RawMessage, error) {
+	return convertV31Object(data, map[string]jsonNodeConverter{
+		"schemas":       convertV31MapValues(convertV31Schema),
+		"parameters":    convertV31MapValues(convertV31Parameter),
+		"headers":       convertV31MapValues(convertV31Parameter),
+		"requestBodies": convertV31MapValues(convertV31RequestBody),
+		"responses":     convertV31MapValues(convertV31Response),
+		"callbacks":     convertV31MapValues(convertV31Paths),
+	})
+}
+
+// convertV31Paths converts the Paths object, which is also used by webhooks and callbacks.
+func convertV31Paths(data json.RawMessage) (json.RawMessage, error) {
+	return convertV31MapValues(convertV31PathItem)(data)
+}
+
+// convertV31PathItem converts the Path Item object.
+func convertV31PathItem(data json.RawMessage) (json.RawMessage, error) {
+	converters := map[string]jsonNodeConverter{
+		"parameters": convertV31ArrayItems(convertV31Parameter),
+	}
+	for _, key := range pathItemOperationKeys {
+		converters[key] = convertV31Operation
+	}
+	return convertV31Object(data, converters)
+}
+
+// convertV31Operation converts the Operation object.
+func convertV31Operation(data json.RawMessage) (json.RawMessage, error) {
+	return convertV31Object(data, map[string]jsonNodeConverter{
+		"parameters":  convertV31ArrayItems(convertV31Parameter),
+		"requestBody": convertV31RequestBody,
+		"responses":   convertV31MapValues(convertV31Response),
+		"callbacks":   convertV31MapValues(convertV31Paths),
+	})
+}
+
+// convertV31Parameter converts the Parameter or Header object.
+func convertV31Parameter(data json.RawMessage) (json.RawMessage, error) {
+	return convertV31Object(data, map[string]jsonNodeConverter{
+		"schema":  convertV31Schema,
+		"content": convertV31MapValues(convertV31MediaType),
+	})
+}
+
+// convertV31RequestBody converts the Request Body object.
+func convertV31RequestBody(data json.RawMessage) (json.RawMessage, error) {
+	return convertV31Object(data, map[string]jsonNodeConverter{
+		"content": convertV31MapValues(convertV31MediaType),
+	})
+}
+
+// convertV31Response converts the Response object.
+func convertV31Response(data json.RawMessage) (json.RawMessage, error) {
+	return convertV31Object(data, map[string]jsonNodeConverter{
+		"headers": convertV31MapValues(convertV31Parameter),
+		"content": convertV31MapValues(convertV31MediaType),
+	})
+}
+
+// convertV31MediaType converts the Media Type object.
+func convertV31MediaType(data json.RawMessage) (json.RawMessage, error) {
+	return convertV31Object(data, map[string]jsonNodeConverter{
+		"schema": convertV31Schema,
+	})
+}
+
+// convertV31Schema converts the OpenAPI 3.0 Schema object to JSON Schema 2020-12:
+// 1. `nullable` is converted to type array containing "null", or `anyOf` with "null" type schema
+// if the schema has no type, eg: the schema of `$ref`;
+// 2. `example` is converted to `examples` array;
+// 3. boolean `exclusiveMinimum/exclusiveMaximum` are converted to numbers from `minimum/maximum`;
+// 4. the custom type "file" is converted to binary string;
+// 5. single-value `enum` is converted to `const`.
+func convertV31Schema(data json.RawMessage) (json.RawMessage, error) {
+	fields, err := decodeJSONFields(data)
+	if err != nil || fields == nil {
+		// It might be boolean schema.
+		return data, err
+	}
+	converters := make(map[string]jsonNodeConverter)
+	for _, key := range schemaKeysForSingleSchema {
+		converters[key] = convertV31Schema
+	}
+	for _, key := range schemaKeysForSchemaArray {
+		converters[key] = convertV31ArrayItems(convertV31Schema)
+	}
+	for _, key := range schemaKeysForSchemaMap {
+		converters[key] = convertV31MapValues(convertV31Schema)
+	}
+	if fields, err = fields.Convert(converters); err != nil {
+		return nil, err
+	}
+
+	var (
+		schemaType = fields.Get("type")
+		nullable   = bytes.Equal(fields.Get("nullable"), []byte("true"))
+	)
+	fields = fields.Remove("nullable")
+	if bytes.Equal(schemaType, []byte(`"`+TypeFile+`"`)) {
+		schemaType = []byte(`"` + TypeString + `"`)
+		fields = fields.Set("type", schemaType).Set("format", []byte(`"`+FormatBinary+`"`))
+	}
+	if nullable && len(schemaType) > 0 && schemaType[0] == '"' {
+		fields = fields.Set("type", []byte(`[`+string(schemaType)+`,"null"]`))
+	}
+	if example := fields.Get("example"); example != nil {
+		fields = fields.Remove("example")
+		if fields.Get("examples") == nil {
+			fields = fields.Set("examples", []byte(`[`+string(example)+`]`))
+		}
+	}
+	for _, item := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		exclusive := fields.Get(item[0])
+		if exclusive == nil || (exclusive[0] != 't' && exclusive[0] != 'f') {
+			continue
+		}
+		fields = fields.Remove(item[0])
+		if limit := fields.Get(item[1]); limit != nil && exclusive[0] == 't' {
+			fields = fields.Remove(item[1]).Set(item[0], limit)
+		}
+	}
+	if enum := fields.Get("enum"); enum != nil && fields.Get("const") == nil {
+		var values []json.RawMessage
+		if err = json.Unmarshal(enum, &values); err == nil && len(values) == 1 {
+			fields = fields.Remove("enum").Set("const", values[0])
+		}
+	}
+	if nullable && len(schemaType) == 0 {
+		// The schema without type, eg: {"$ref": "..."}, is combined with the "null" type schema.
+		content, err := fields.Encode()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(`{"anyOf":[` + string(content) + `,{"type":"null"}]}`), nil
+	}
+	return fields.Encode()
+}
+
+// convertV31Object converts the values of given fields in JSON object `data` using `converters`.
+func convertV31Object(data json.RawMessage, converters map[string]jsonNodeConverter) (json.RawMessage, error) {
+	fields, err := decodeJSONFields(data)
+	if err != nil || fields == nil {
+		return data, err
+	}
+	if fields, err = fields.Convert(converters); err != nil {
+		return nil, err
+	}
+	return fields.Encode()
+}
+
+// convertV31MapValues creates and returns a converter converting all values of JSON object using `converter`.
+func convertV31MapValues(converter jsonNodeConverter) jsonNodeConverter {
+	return func(data json.RawMessage) (json.RawMessage, error) {
+		fields, err := decodeJSONFields(data)
+		if err != nil || fields == nil {
+			return data, err
+		}
+		for i, field := range fields {
+			if fields[i].Value, err = converter(field.Value); err != nil {
+				return nil, err
+			}
+		}
+		return fields.Encode()
+	}
+}
+
+// convertV31ArrayItems creates and returns a converter converting all items of JSON array using `converter`.
+func convertV31ArrayItems(converter jsonNodeConverter) jsonNodeConverter {
+	return func(data json.RawMessage) (json.RawMessage, error) {
+		var items []json.RawMessage
+		if len(data) == 0 || data[0] != '[' {
+			return data, nil
+		}
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, gerror.Wrap(err, `decode JSON array failed`)
+		}
+		var err error
+		for i, item := range items {
+			if items[i], err = converter(item); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(items)
+	}
+}
+
+// decodeJSONFields decodes JSON object `data` into ordered fields.
+// It returns nil fields if `data` is not a JSON object.
+func decodeJSONFields(data json.RawMessage) (jsonFields, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil, nil
+	}
+	var (
+		fields  = make(jsonFields, 0)
+		decoder = json.NewDecoder(bytes.NewReader(data))
+	)
+	// The beginning delimiter `{`.
+	if _, err := decoder.Token(); err != nil {
+		return nil, gerror.Wrap(err, `decode JSON object failed`)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, gerror.Wrap(err, `decode JSON object key failed`)
+		}
+		var field = jsonField{Key: token.(string)}
+		if err = decoder.Decode(&field.Value); err != nil {
+			return nil, gerror.Wrapf(err, `decode JSON object value for key "%s" failed`, field.Key)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// Get returns the value of `key`, or nil if it does not exist.
+func (fields jsonFields) Get(key string) json.RawMessage {
+	for _, field := range fields {
+		if field.Key == key {
+			return field.Value
+		}
+	}
+	return nil
+}
+
+// Set sets the value of `key`, which is appended if it does not exist.
+func (fields jsonFields) Set(key string, value json.RawMessage) jsonFields {
+	for i, field := range fields {
+		if field.Key == key {
+			fields[i].Value = value
+			return fields
+		}
+	}
+	return append(fields, jsonField{Key: key, Value: value})
+}
+
+// Remove removes the field of `key`.
+func (fields jsonFields) Remove(key string) jsonFields {
+	for i, field := range fields {
+		if field.Key == key {
+			return append(fields[:i], fields[i+1:]...)
+		}
+	}
+	return fields
+}
+
+// Convert converts the values of fields using `converters` which uses field key as map key.
+func (fields jsonFields) Convert(converters map[string]jsonNodeConverter) (jsonFields, error) {
+	var err error
+	for i, field := range fields {
+		if converter, ok := converters[field.Key]; ok {
+			if fields[i].Value, err = converter(field.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fields, nil
+}
+
+// Encode encodes the fields as JSON object in order.
+func (fields jsonFields) Encode() (json.RawMessage, error) {
+	var buffer = bytes.NewBuffer(nil)
+	buffer.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(field.Value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
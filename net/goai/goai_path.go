@@ -52,6 +52,7 @@ type addPathInput struct {
 	Path     string // Precise route path.
 	Prefix   string // Route path prefix.
 	Method   string // Route method.
+	Webhook  string // Webhook name, which adds the route as webhook if it is not empty.
 	Function any    // Uniformed function.
 }
 
@@ -98,13 +99,13 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 		seRequirement = SecurityRequirement{}
 	)
 	// Path check.
-	if in.Path == "" {
+	if in.Path == "" && in.Webhook == "" {
 		in.Path = gmeta.Get(inputObject.Interface(), gtag.Path).String()
 		if in.Prefix != "" {
 			in.Path = gstr.TrimRight(in.Prefix, "/") + "/" + gstr.TrimLeft(in.Path, "/")
 		}
 	}
-	if in.Path == "" {
+	if in.Path == "" && in.Webhook == "" {
 		return gerror.NewCodef(
 			gcode.CodeMissingParameter,
 			`missing necessary path parameter "%s" for input struct "%s", missing tag in attribute Meta?`,
@@ -112,7 +113,19 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 		)
 	}
 
-	if v, ok := oai.Paths[in.Path]; ok {
+	// Webhooks are keyed by webhook name instead of path.
+	var (
+		paths   = oai.Paths
+		pathKey = in.Path
+	)
+	if in.Webhook != "" {
+		if oai.Webhooks == nil {
+			oai.Webhooks = map[string]Path{}
+		}
+		paths = oai.Webhooks
+		pathKey = in.Webhook
+	}
+	if v, ok := paths[pathKey]; ok {
 		path = v
 	}
 
@@ -313,7 +326,7 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 	default:
 		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid method "%s"`, in.Method)
 	}
-	oai.Paths[in.Path] = path
+	paths[pathKey] = path
 	return nil
 }
 
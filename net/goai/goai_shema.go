@@ -60,6 +60,7 @@ type Schema struct {
 	MaxProps             *uint64        `json:"maxProperties,omitempty"`
 	AdditionalProperties *SchemaRef     `json:"additionalProperties,omitempty"`
 	Discriminator        *Discriminator `json:"discriminator,omitempty"`
+	Const                any            `json:"const,omitempty"` // Const value, which is written as single-value enum in OpenAPI 3.0.
 	XExtensions          XExtensions    `json:"-"`
 	ValidationRules      string         `json:"-"`
 }
@@ -93,6 +94,14 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 		}
 		m[k] = b
 	}
+	// The const value is written as single-value enum which is also available in OpenAPI 3.0,
+	// and it is converted back to const when the document is written as OpenAPI 3.1.
+	if v, ok := m["const"]; ok {
+		delete(m, "const")
+		if _, ok = m["enum"]; !ok {
+			m["enum"] = append(append([]byte{'['}, v...), ']')
+		}
+	}
 	return json.Marshal(m)
 }
 
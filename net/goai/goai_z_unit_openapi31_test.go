@@ -0,0 +1,130 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/goai"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_OpenAPI31(t *testing.T) {
+	type Item struct {
+		Id     int     `json:"id" example:"1"`
+		Name   string  `json:"name" nullable:"true"`
+		Score  float64 `json:"score" minimum:"0" exclusiveMinimum:"true"`
+		Status string  `json:"status" const:"enabled"`
+	}
+	type CreateReq struct {
+		g.Meta `path:"/item" method:"post"`
+		Item
+		Tags []string `json:"tags"`
+	}
+	type CreateRes struct {
+		Items []Item `json:"items"`
+	}
+	type EventReq struct {
+		g.Meta `method:"post" summary:"Item created event"`
+		Id     int `json:"id"`
+	}
+	type EventRes struct{}
+
+	f := func(ctx context.Context, req *CreateReq) (res *CreateRes, err error) {
+		return
+	}
+	event := func(ctx context.Context, req *EventReq) (res *EventRes, err error) {
+		return
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.Config.IgnorePkgPath = true
+		oai.OpenAPI = goai.OpenAPIVersion31
+		t.AssertNil(oai.Add(goai.AddInput{Object: f}))
+		t.AssertNil(oai.Add(goai.AddInput{Object: event, Webhook: "itemCreated"}))
+		t.Assert(oai.IsOpenAPI31(), true)
+
+		j, err := gjson.LoadContent([]byte(oai.String()))
+		t.AssertNil(err)
+		t.Assert(j.Get("openapi"), goai.OpenAPIVersion31)
+		t.Assert(j.Get("jsonSchemaDialect"), goai.JSONSchemaDialectOAS31)
+		t.Assert(j.Get("webhooks.itemCreated.post.summary"), "Item created event")
+		t.Assert(
+			j.Get(`webhooks.itemCreated.post.requestBody.content.application/json.schema.$ref`),
+			"#/components/schemas/goai_test.EventReq",
+		)
+
+		item := gjson.New(j.Get("components.schemas").MapStrAny()["goai_test.Item"]).GetJson("properties")
+		t.Assert(item.Get("name.type"), g.Slice{"string", "null"})
+		t.Assert(item.Get("name.nullable"), nil)
+		t.Assert(item.Get("id.examples"), g.Slice{1})
+		t.Assert(item.Get("id.example"), nil)
+		t.Assert(item.Get("score.exclusiveMinimum"), 0)
+		t.Assert(item.Get("score.minimum"), nil)
+		t.Assert(item.Get("status.const"), "enabled")
+		t.Assert(item.Get("status.enum"), nil)
+
+		// The property order is kept.
+		content := oai.String()
+		t.AssertLT(strings.Index(content, `"id":{`), strings.Index(content, `"name":{`))
+		t.AssertLT(strings.Index(content, `"name":{`), strings.Index(content, `"score":{`))
+	})
+
+	// OpenAPI 3.0 output is not changed.
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.Config.IgnorePkgPath = true
+		t.AssertNil(oai.Add(goai.AddInput{Object: f}))
+		t.AssertNil(oai.Add(goai.AddInput{Object: event, Webhook: "itemCreated"}))
+		t.Assert(oai.IsOpenAPI31(), false)
+
+		j, err := gjson.LoadContent([]byte(oai.String()))
+		t.AssertNil(err)
+		t.Assert(j.Get("openapi"), goai.OpenAPIVersion30)
+		t.Assert(j.Get("jsonSchemaDialect"), nil)
+		t.Assert(j.Get("webhooks"), nil)
+
+		item := gjson.New(j.Get("components.schemas").MapStrAny()["goai_test.Item"]).GetJson("properties")
+		t.Assert(item.Get("name.type"), "string")
+		t.Assert(item.Get("name.nullable"), true)
+		t.Assert(item.Get("id.example"), 1)
+		t.Assert(item.Get("score.exclusiveMinimum"), true)
+		t.Assert(item.Get("score.minimum"), 0)
+		t.Assert(item.Get("status.enum"), g.Slice{"enabled"})
+		t.Assert(item.Get("status.const"), nil)
+	})
+}
+
+func Test_OpenAPI31_NullableWithoutType(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.OpenAPI = goai.OpenAPIVersion31
+		oai.Components.Schemas.Set("Item", goai.SchemaRef{
+			Value: &goai.Schema{Type: goai.TypeObject},
+		})
+		oai.Components.Schemas.Set("NullableItem", goai.SchemaRef{
+			Value: &goai.Schema{
+				Nullable:    true,
+				Description: "nullable item",
+				AllOf:       goai.SchemaRefs{{Ref: "Item"}},
+			},
+		})
+		j, err := gjson.LoadContent([]byte(oai.String()))
+		t.AssertNil(err)
+		schema := gjson.New(j.Get("components.schemas").MapStrAny()["NullableItem"])
+		t.Assert(schema.Get("nullable"), nil)
+		t.Assert(schema.Get("anyOf.0.allOf.0.$ref"), "#/components/schemas/Item")
+		t.Assert(schema.Get("anyOf.0.description"), "nullable item")
+		t.Assert(schema.Get("anyOf.1.type"), "null")
+		t.Assert(len(schema.Get("anyOf").Array()), 2)
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// NodeFilter reports whether the node is available for picking.
+type NodeFilter func(ctx context.Context, node Node) bool
+
+type selectorFilter struct {
+	mu       sync.RWMutex
+	nodes    Nodes
+	selector Selector
+	filter   NodeFilter
+}
+
+// NewSelectorWithFilter creates and returns a selector that picks nodes using `selector`,
+// but skips the nodes that are filtered out by `filter`, eg: nodes with open circuit breakers.
+// It returns error with code gcode.CodeServerBusy in picking if all nodes are filtered out.
+func NewSelectorWithFilter(selector Selector, filter NodeFilter) Selector {
+	return &selectorFilter{
+		nodes:    make(Nodes, 0),
+		selector: selector,
+		filter:   filter,
+	}
+}
+
+func (s *selectorFilter) Update(ctx context.Context, nodes Nodes) error {
+	s.mu.Lock()
+	s.nodes = nodes
+	s.mu.Unlock()
+	return s.selector.Update(ctx, nodes)
+}
+
+func (s *selectorFilter) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	s.mu.RLock()
+	nodes := s.nodes
+	s.mu.RUnlock()
+	if len(nodes) == 0 {
+		return s.selector.Pick(ctx)
+	}
+	// It tries picking using the underlying selector to keep its balancing strategy.
+	for i := 0; i < len(nodes); i++ {
+		if node, done, err = s.selector.Pick(ctx); err != nil || node == nil {
+			return
+		}
+		if s.filter(ctx, node) {
+			return
+		}
+		intlog.Printf(ctx, `Filtered node: %s`, node.Address())
+		if done != nil {
+			done(ctx, DoneInfo{})
+		}
+	}
+	// The underlying selector might pick the same nodes repeatedly, eg: random selector.
+	for _, item := range nodes {
+		if s.filter(ctx, item) {
+			return item, nil, nil
+		}
+	}
+	return nil, nil, gerror.NewCodef(
+		gcode.CodeServerBusy, `no available node in nodes: %s`, nodes.String(),
+	)
+}
@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type testNode struct {
+	address string
+}
+
+func (n *testNode) Service() gsvc.Service {
+	return nil
+}
+
+func (n *testNode) Address() string {
+	return n.address
+}
+
+var testNodes = gsel.Nodes{
+	&testNode{address: "127.0.0.1:8001"},
+	&testNode{address: "127.0.0.1:8002"},
+	&testNode{address: "127.0.0.1:8003"},
+}
+
+func Test_SelectorWithFilter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = context.Background()
+			selector = gsel.NewSelectorWithFilter(
+				gsel.NewSelectorRoundRobin(),
+				func(ctx context.Context, node gsel.Node) bool {
+					return node.Address() != "127.0.0.1:8002"
+				},
+			)
+		)
+		t.AssertNil(selector.Update(ctx, testNodes))
+		var addresses []string
+		for i := 0; i < 4; i++ {
+			node, _, err := selector.Pick(ctx)
+			t.AssertNil(err)
+			addresses = append(addresses, node.Address())
+		}
+		t.Assert(addresses, []string{"127.0.0.1:8001", "127.0.0.1:8003", "127.0.0.1:8001", "127.0.0.1:8003"})
+	})
+}
+
+func Test_SelectorWithFilter_Random(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = context.Background()
+			selector = gsel.NewSelectorWithFilter(
+				gsel.NewSelectorRandom(),
+				func(ctx context.Context, node gsel.Node) bool {
+					return node.Address() == "127.0.0.1:8003"
+				},
+			)
+		)
+		t.AssertNil(selector.Update(ctx, testNodes))
+		// The only available node is picked even if the underlying selector does not pick it.
+		for i := 0; i < 20; i++ {
+			node, _, err := selector.Pick(ctx)
+			t.AssertNil(err)
+			t.Assert(node.Address(), "127.0.0.1:8003")
+		}
+	})
+}
+
+func Test_SelectorWithFilter_NoAvailable(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = context.Background()
+			selector = gsel.NewSelectorWithFilter(
+				gsel.NewSelectorRoundRobin(),
+				func(ctx context.Context, node gsel.Node) bool {
+					return false
+				},
+			)
+		)
+		t.AssertNil(selector.Update(ctx, testNodes))
+		node, _, err := selector.Pick(ctx)
+		t.Assert(node, nil)
+		t.Assert(gerror.Code(err), gcode.CodeServerBusy)
+	})
+}
+
+func Test_SelectorWithFilter_Context(t *testing.T) {
+	const ctxKeyExcluded gctx.StrKey = "excluded"
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = context.Background()
+			selector = gsel.NewSelectorWithFilter(
+				gsel.NewSelectorRoundRobin(),
+				func(ctx context.Context, node gsel.Node) bool {
+					return node.Address() != ctx.Value(ctxKeyExcluded)
+				},
+			)
+		)
+		t.AssertNil(selector.Update(ctx, testNodes))
+		// The filter depends on the context of picking, so different callers can share the selector.
+		excludedCtx := context.WithValue(ctx, ctxKeyExcluded, "127.0.0.1:8001")
+		for i := 0; i < 3; i++ {
+			node, _, err := selector.Pick(excludedCtx)
+			t.AssertNil(err)
+			t.AssertNE(node.Address(), "127.0.0.1:8001")
+		}
+		var addresses []string
+		for i := 0; i < 3; i++ {
+			node, _, err := selector.Pick(ctx)
+			t.AssertNil(err)
+			addresses = append(addresses, node.Address())
+		}
+		t.AssertIN("127.0.0.1:8001", addresses)
+	})
+}
@@ -37,6 +37,11 @@ func GetLogger() glog.ILogger {
 	return defaultCron.GetLogger()
 }
 
+// SetCluster enables cluster mode for default cron object.
+func SetCluster(option ClusterOption) error {
+	return defaultCron.SetCluster(option)
+}
+
 // Add adds a timed task to default cron object.
 // A unique `name` can be bound with the timed task.
 // It returns and error if the `name` is already used.
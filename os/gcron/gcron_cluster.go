@@ -0,0 +1,171 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// ClusterMode is the mode how jobs are dispatched among cron instances in cluster.
+type ClusterMode int
+
+const (
+	// ClusterModeTick locks every scheduled tick of every entry, the instance acquiring the lock runs the tick.
+	ClusterModeTick ClusterMode = iota
+
+	// ClusterModeLeader elects a leader instance holding a lease lock, only the leader runs jobs.
+	ClusterModeLeader
+)
+
+// ClusterMisfirePolicy specifies what to do with a tick if the Locker fails.
+type ClusterMisfirePolicy int
+
+const (
+	// ClusterMisfireSkip skips the tick if the lock cannot be determined, which never runs a tick twice.
+	ClusterMisfireSkip ClusterMisfirePolicy = iota
+
+	// ClusterMisfireRun runs the tick locally if the lock cannot be determined, which never misses a tick.
+	ClusterMisfireRun
+)
+
+const (
+	defaultClusterName       = "gcron"
+	defaultClusterTickTTL    = time.Minute
+	defaultClusterLeaderTTL  = 10 * time.Second
+	clusterLeaderLockKeyName = "leader"
+)
+
+// ClusterOption is the option for cron cluster mode.
+type ClusterOption struct {
+	// Locker is the distributed lock backend shared by all cron instances, which is required.
+	Locker Locker
+
+	// Mode is the dispatching mode, it is ClusterModeTick in default.
+	Mode ClusterMode
+
+	// Name is the cluster name which is used as the prefix of lock keys, it is "gcron" in default.
+	// Crons of different services sharing the same Locker should use different names.
+	Name string
+
+	// Owner identifies current cron instance, it is a unique id in default.
+	Owner string
+
+	// LockTTL is the expiration of locks.
+	// In ClusterModeTick, it is how long the lock of a scheduled slot is kept, so that the instances reaching
+	// the slot later, eg for the timer delay or misfire handling, skip it. It is 1 minute in default.
+	// Note that the slot is identified by the clock of each instance, so the clocks should be synchronized.
+	// In ClusterModeLeader, it is the lease of leader, it is 10 seconds in default.
+	LockTTL time.Duration
+
+	// Misfire is the policy if the Locker fails, it is ClusterMisfireSkip in default.
+	Misfire ClusterMisfirePolicy
+}
+
+// SetCluster enables cluster mode for cron, in which each scheduled tick runs exactly once
+// across all cron instances sharing the same Locker.
+//
+// Entries are identified by name across instances, so entries in cluster should be added with unique names.
+func (c *Cron) SetCluster(option ClusterOption) error {
+	if option.Locker == nil {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `Locker is required for cron cluster`)
+	}
+	if option.Name == "" {
+		option.Name = defaultClusterName
+	}
+	if option.Owner == "" {
+		option.Owner = guid.S()
+	}
+	if option.LockTTL <= 0 {
+		option.LockTTL = defaultClusterTickTTL
+		if option.Mode == ClusterModeLeader {
+			option.LockTTL = defaultClusterLeaderTTL
+		}
+	}
+	c.clusterMu.Lock()
+	defer c.clusterMu.Unlock()
+	c.cluster = &option
+	return nil
+}
+
+// GetCluster returns the cluster option of cron, it returns nil if cluster mode is not enabled.
+func (c *Cron) GetCluster() *ClusterOption {
+	c.clusterMu.RLock()
+	defer c.clusterMu.RUnlock()
+	return c.cluster
+}
+
+// IsLeader checks and returns whether current cron instance is the leader in ClusterModeLeader.
+// It always returns true if cluster mode is not enabled, or it is in ClusterModeTick.
+func (c *Cron) IsLeader(ctx context.Context) bool {
+	cluster := c.GetCluster()
+	if cluster == nil || cluster.Mode != ClusterModeLeader {
+		return true
+	}
+	ok, err := cluster.Locker.Lock(ctx, cluster.leaderKey(), cluster.Owner, cluster.LockTTL)
+	return err == nil && ok
+}
+
+// releaseCluster releases the leader lease of current instance, so that other instances can take over immediately.
+func (c *Cron) releaseCluster(ctx context.Context) {
+	cluster := c.GetCluster()
+	if cluster == nil || cluster.Mode != ClusterModeLeader {
+		return
+	}
+	_ = cluster.Locker.Unlock(ctx, cluster.leaderKey(), cluster.Owner)
+}
+
+// acquireClusterLock checks whether current instance should run the tick `t` of `entry`.
+func (c *Cron) acquireClusterLock(ctx context.Context, entry *Entry, t time.Time) bool {
+	cluster := c.GetCluster()
+	if cluster == nil {
+		return true
+	}
+	var key string
+	switch cluster.Mode {
+	case ClusterModeLeader:
+		key = cluster.leaderKey()
+	default:
+		key = cluster.tickKey(entry, t)
+	}
+	ok, err := cluster.Locker.Lock(ctx, key, cluster.Owner, cluster.LockTTL)
+	if err != nil {
+		entry.logErrorf(ctx, `cron job "%s" acquires cluster lock failed: %+v`, entry.getJobNameWithPattern(), err)
+		return cluster.Misfire == ClusterMisfireRun
+	}
+	if !ok {
+		entry.logDebugf(ctx, `cron job "%s" is running by other instance`, entry.getJobNameWithPattern())
+	}
+	return ok
+}
+
+func (o *ClusterOption) leaderKey() string {
+	return fmt.Sprintf(`%s:%s`, o.Name, clusterLeaderLockKeyName)
+}
+
+// tickKey returns the lock key for the tick, which is the same among instances for the same scheduled slot.
+//
+// The slot of "#" pattern is the minute of tick, as the pattern runs at any second of the minute.
+// The slot of interval pattern is the interval from epoch containing the tick, and the slot of
+// other patterns is the second of tick.
+func (o *ClusterOption) tickKey(entry *Entry, t time.Time) string {
+	var slot int64
+	switch {
+	case entry.schedule.everySeconds > 0:
+		slot = t.Unix() - t.Unix()%entry.schedule.everySeconds
+	case entry.schedule.ignoreSeconds:
+		slot = t.Truncate(time.Minute).Unix()
+	default:
+		slot = t.Unix()
+	}
+	return fmt.Sprintf(`%s:%s:%d`, o.Name, entry.Name, slot)
+}
@@ -28,6 +28,10 @@ type Cron struct {
 	jobWaiter   sync.WaitGroup // Graceful shutdown when cron jobs are stopped.
 	running     bool
 	runningLock sync.Mutex
+	cluster     *ClusterOption // Cluster option, it is nil if cluster mode is not enabled.
+	clusterMu   sync.RWMutex
+	history     HistoryStore // Storage for run records of jobs.
+	historyMu   sync.RWMutex
 }
 
 // New returns a new Cron object with default settings.
@@ -37,6 +41,7 @@ func New() *Cron {
 		status:  gtype.NewInt(StatusRunning),
 		entries: gmap.NewStrAnyMap(true),
 		running: true,
+		history: NewHistoryStoreMemory(),
 	}
 }
 
@@ -237,6 +242,7 @@ func (c *Cron) Close() {
 	defer c.runningLock.Unlock()
 	c.status.Set(StatusClosed)
 	c.running = false
+	c.releaseCluster(context.Background())
 }
 
 // Size returns the size of the timed tasks.
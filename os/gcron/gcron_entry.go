@@ -13,12 +13,18 @@ import (
 	"runtime"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
 	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gmetric"
 	"github.com/gogf/gf/v2/os/gtimer"
 	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/grand"
 )
 
 // JobFunc is the timing called job function in cron.
@@ -26,15 +32,18 @@ type JobFunc = gtimer.JobFunc
 
 // Entry is timing task entry.
 type Entry struct {
-	cron         *Cron         // Cron object belonged to.
-	timerEntry   *gtimer.Entry // Associated timer Entry.
-	schedule     *cronSchedule // Timed schedule object.
-	jobName      string        // Callback function name(address info).
-	times        *gtype.Int    // Running times limit.
-	infinite     *gtype.Bool   // No times limit.
-	Name         string        // Entry name.
-	RegisterTime time.Time     // Registered time.
-	Job          JobFunc       `json:"-"` // Callback function.
+	cron           *Cron         // Cron object belonged to.
+	timerEntry     *gtimer.Entry // Associated timer Entry.
+	schedule       *cronSchedule // Timed schedule object.
+	jobName        string        // Callback function name(address info).
+	times          *gtype.Int    // Running times limit.
+	infinite       *gtype.Bool   // No times limit.
+	jitter         *gtype.Int64  // Maximum random delay in nanoseconds before each run.
+	misfirePolicy  *gtype.Int    // Misfire policy for missed ticks.
+	misfireCatchUp *gtype.Int    // Max missed ticks to run for MisfireCatchUp.
+	Name           string        // Entry name.
+	RegisterTime   time.Time     // Registered time.
+	Job            JobFunc       `json:"-"` // Callback function.
 }
 
 type doAddEntryInput struct {
@@ -64,13 +73,16 @@ func (c *Cron) doAddEntry(in doAddEntryInput) (*Entry, error) {
 	}
 	// No limit for `times`, for timer checking scheduling every second.
 	entry := &Entry{
-		cron:         c,
-		schedule:     schedule,
-		jobName:      runtime.FuncForPC(reflect.ValueOf(in.Job).Pointer()).Name(),
-		times:        gtype.NewInt(in.Times),
-		infinite:     gtype.NewBool(in.Infinite),
-		RegisterTime: time.Now(),
-		Job:          in.Job,
+		cron:           c,
+		schedule:       schedule,
+		jobName:        runtime.FuncForPC(reflect.ValueOf(in.Job).Pointer()).Name(),
+		times:          gtype.NewInt(in.Times),
+		infinite:       gtype.NewBool(in.Infinite),
+		jitter:         gtype.NewInt64(),
+		misfirePolicy:  gtype.NewInt(int(MisfireSkip)),
+		misfireCatchUp: gtype.NewInt(1),
+		RegisterTime:   time.Now(),
+		Job:            in.Job,
 	}
 	if in.Name != "" {
 		entry.Name = in.Name
@@ -110,6 +122,36 @@ func (e *Entry) SetTimes(times int) {
 	e.infinite.Set(false)
 }
 
+// Pattern returns the cron pattern of the entry.
+func (e *Entry) Pattern() string {
+	return e.schedule.pattern
+}
+
+// SetLocation sets the location in which the pattern of entry is evaluated,
+// which overwrites the timezone prefix of pattern.
+func (e *Entry) SetLocation(loc *time.Location) {
+	e.schedule.setLocation(loc)
+}
+
+// SetJitter sets the maximum random delay before each run of the entry,
+// which spreads the load of jobs scheduled at the same time.
+func (e *Entry) SetJitter(jitter time.Duration) {
+	e.jitter.Set(int64(jitter))
+}
+
+// NextRun returns the upcoming `n` fire times of the entry, which does not include the jitter.
+func (e *Entry) NextRun(n int) []time.Time {
+	var (
+		times       = make([]time.Time, 0, n)
+		currentTime = time.Now()
+	)
+	for i := 0; i < n; i++ {
+		currentTime = e.schedule.Next(currentTime)
+		times = append(times, currentTime)
+	}
+	return times
+}
+
 // Status returns the status of entry.
 func (e *Entry) Status() int {
 	return e.timerEntry.Status()
@@ -122,6 +164,8 @@ func (e *Entry) SetStatus(status int) int {
 
 // Start starts running the entry.
 func (e *Entry) Start() {
+	// The ticks during stopping are not treated as misfire.
+	e.schedule.lastCheckTimestamp.Set(time.Now().Unix())
 	e.timerEntry.Start()
 }
 
@@ -130,17 +174,26 @@ func (e *Entry) Stop() {
 	e.timerEntry.Stop()
 }
 
-// Close stops and removes the entry from cron.
+// Close stops and removes the entry from cron, along with its run records in history store.
 func (e *Entry) Close() {
 	e.cron.entries.Remove(e.Name)
 	e.timerEntry.Close()
+	e.removeHistory(context.Background())
 }
 
 // checkAndRun is the core timing task check logic.
 // This function is called every second.
 func (e *Entry) checkAndRun(ctx context.Context) {
-	currentTime := time.Now()
-	if !e.schedule.checkMeetAndUpdateLastSeconds(ctx, currentTime) {
+	var (
+		currentTime = time.Now()
+		missedTicks []time.Time
+	)
+	// It must be retrieved before checking, as checking updates the last check time.
+	if MisfirePolicy(e.misfirePolicy.Val()) != MisfireSkip {
+		missedTicks = e.schedule.getMissedTicks(currentTime)
+	}
+	currentMeet := e.schedule.checkMeetAndUpdateLastSeconds(ctx, currentTime)
+	if !currentMeet && len(missedTicks) == 0 {
 		return
 	}
 	switch e.cron.status.Val() {
@@ -163,32 +216,87 @@ func (e *Entry) checkAndRun(ctx context.Context) {
 		e.cron.runningLock.Unlock()
 		defer func() {
 			e.cron.jobWaiter.Done()
-			if exception := recover(); exception != nil {
-				// Exception caught, it logs the error content to logger in default behavior.
-				e.logErrorf(ctx,
-					`cron job "%s(%s)" end with error: %+v`,
-					e.jobName, e.schedule.pattern, exception,
-				)
-			} else {
-				e.logDebugf(ctx, `cron job "%s" ends`, e.getJobNameWithPattern())
-			}
 			if e.timerEntry.Status() == StatusClosed {
 				e.Close()
 			}
 		}()
 
-		// Running times check.
-		if !e.infinite.Val() {
-			times := e.times.Add(-1)
-			if times <= 0 {
-				if e.timerEntry.SetStatus(StatusClosed) == StatusClosed || times < 0 {
-					return
+		var ticks []time.Time
+		if len(missedTicks) > 0 {
+			ticks = e.handleMisfire(ctx, missedTicks, currentMeet)
+		}
+		if currentMeet {
+			ticks = append(ticks, currentTime)
+		}
+		for _, tick := range ticks {
+			if !e.cron.acquireClusterLock(ctx, e, tick) {
+				continue
+			}
+			// Running times check.
+			if !e.infinite.Val() {
+				times := e.times.Add(-1)
+				if times <= 0 {
+					if e.timerEntry.SetStatus(StatusClosed) == StatusClosed || times < 0 {
+						return
+					}
 				}
 			}
+			if jitter := e.jitter.Val(); jitter > 0 {
+				time.Sleep(grand.D(0, time.Duration(jitter)))
+			}
+			e.run(ctx, tick)
+		}
+	}
+}
+
+// run runs the job for the scheduled tick, with its history, metrics and tracing recorded.
+func (e *Entry) run(ctx context.Context, scheduled time.Time) {
+	var (
+		metricOption = metricManager.GetMetricOptionForEntry(e)
+		record       = &RunRecord{
+			Entry:     e.Name,
+			Scheduled: scheduled,
+			Start:     time.Now(),
+			Status:    RunStatusSuccess,
 		}
-		e.logDebugf(ctx, `cron job "%s" starts`, e.getJobNameWithPattern())
-		e.Job(ctx)
+	)
+	ctx, span := gtrace.NewSpan(ctx, tracingSpanNamePrefix+e.Name)
+	span.SetAttributes(gtrace.CommonLabels()...)
+	span.SetAttributes(
+		attribute.String(tracingAttrCronJobName, e.Name),
+		attribute.String(tracingAttrCronJobPattern, e.schedule.pattern),
+		attribute.String(tracingAttrCronJobScheduled, scheduled.Format(time.RFC3339)),
+	)
+	if gmetric.IsEnabled() {
+		metricManager.CronJobRunActive.Inc(ctx, metricOption)
 	}
+	defer func() {
+		record.Duration = time.Since(record.Start)
+		if exception := recover(); exception != nil {
+			// Exception caught, it logs the error content to logger in default behavior.
+			record.Status = RunStatusFailed
+			record.Error = fmt.Sprintf(`%+v`, exception)
+			span.SetStatus(codes.Error, record.Error)
+			e.logErrorf(ctx,
+				`cron job "%s(%s)" end with error: %+v`,
+				e.jobName, e.schedule.pattern, exception,
+			)
+		} else {
+			e.logDebugf(ctx, `cron job "%s" ends`, e.getJobNameWithPattern())
+		}
+		span.End()
+		if gmetric.IsEnabled() {
+			recordMetricOption := metricManager.GetMetricOptionForRecord(e, record)
+			metricManager.CronJobRunActive.Dec(ctx, metricOption)
+			metricManager.CronJobRunTotal.Inc(ctx, recordMetricOption)
+			metricManager.CronJobRunDuration.Record(
+				float64(record.Duration.Microseconds())/1000, recordMetricOption,
+			)
+		}
+		e.addHistory(ctx, record)
+	}()
+	e.logDebugf(ctx, `cron job "%s" starts`, e.getJobNameWithPattern())
+	e.Job(ctx)
 }
 
 func (e *Entry) getJobNameWithPattern() string {
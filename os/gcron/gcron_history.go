@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RunStatus is the status of a run of cron job.
+type RunStatus string
+
+const (
+	RunStatusSuccess RunStatus = "success" // The job ends normally.
+	RunStatusFailed  RunStatus = "failed"  // The job ends with panic.
+	RunStatusMissed  RunStatus = "missed"  // The tick is missed and skipped by misfire policy.
+)
+
+// RunRecord is the record of a run of cron job.
+type RunRecord struct {
+	Entry     string        `json:"entry"`           // Entry name.
+	Scheduled time.Time     `json:"scheduled"`       // Scheduled time of the tick.
+	Start     time.Time     `json:"start"`           // Start time of the run, it is zero if the tick is missed.
+	Duration  time.Duration `json:"duration"`        // Running duration.
+	Status    RunStatus     `json:"status"`          // Status of the run.
+	Error     string        `json:"error,omitempty"` // Error message if the job panics.
+}
+
+// HistoryStore is the storage for run records of cron jobs.
+type HistoryStore interface {
+	// Add adds a run record.
+	Add(ctx context.Context, record *RunRecord) error
+
+	// List returns at most `limit` latest run records of the entry named `name`, ordered from the latest.
+	List(ctx context.Context, name string, limit int) ([]*RunRecord, error)
+
+	// Remove removes all the run records of the entry named `name`, which is called when the entry is closed.
+	Remove(ctx context.Context, name string) error
+}
+
+const (
+	defaultHistorySize = 10
+)
+
+// HistoryStoreMemory is the HistoryStore implements in memory,
+// which keeps the latest records of each entry in a ring buffer.
+type HistoryStoreMemory struct {
+	mu      sync.RWMutex
+	size    int
+	buffers map[string]*historyRingBuffer
+}
+
+// historyRingBuffer is the ring buffer of records for an entry.
+type historyRingBuffer struct {
+	records []*RunRecord
+	next    int // Position for the next record.
+}
+
+var _ HistoryStore = (*HistoryStoreMemory)(nil)
+
+// NewHistoryStoreMemory creates and returns a memory HistoryStore,
+// the parameter `size` specifies the max records kept for each entry, which is 10 in default.
+func NewHistoryStoreMemory(size ...int) *HistoryStoreMemory {
+	s := &HistoryStoreMemory{
+		size:    defaultHistorySize,
+		buffers: make(map[string]*historyRingBuffer),
+	}
+	if len(size) > 0 && size[0] > 0 {
+		s.size = size[0]
+	}
+	return s
+}
+
+// Add adds a run record.
+func (s *HistoryStoreMemory) Add(ctx context.Context, record *RunRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buffer, ok := s.buffers[record.Entry]
+	if !ok {
+		buffer = &historyRingBuffer{
+			records: make([]*RunRecord, 0, s.size),
+		}
+		s.buffers[record.Entry] = buffer
+	}
+	if len(buffer.records) < s.size {
+		buffer.records = append(buffer.records, record)
+	} else {
+		buffer.records[buffer.next] = record
+	}
+	buffer.next = (buffer.next + 1) % s.size
+	return nil
+}
+
+// List returns at most `limit` latest run records of the entry named `name`, ordered from the latest.
+func (s *HistoryStoreMemory) List(ctx context.Context, name string, limit int) ([]*RunRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	buffer, ok := s.buffers[name]
+	if !ok {
+		return nil, nil
+	}
+	var (
+		count   = len(buffer.records)
+		records = make([]*RunRecord, 0, count)
+	)
+	if limit <= 0 || limit > count {
+		limit = count
+	}
+	for i := 1; i <= limit; i++ {
+		records = append(records, buffer.records[(buffer.next-i+count)%count])
+	}
+	return records, nil
+}
+
+// Remove removes all the run records of the entry named `name`.
+func (s *HistoryStoreMemory) Remove(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.buffers, name)
+	return nil
+}
+
+// SetHistoryStore sets the storage for run records of cron jobs, it disables the history if `store` is nil.
+// The cron keeps 10 latest records for each entry in memory in default.
+func (c *Cron) SetHistoryStore(store HistoryStore) {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+	c.history = store
+}
+
+// GetHistoryStore returns the storage for run records of cron jobs, it returns nil if the history is disabled.
+func (c *Cron) GetHistoryStore() HistoryStore {
+	c.historyMu.RLock()
+	defer c.historyMu.RUnlock()
+	return c.history
+}
+
+// History returns at most `limit` latest run records of the entry, ordered from the latest.
+func (e *Entry) History(ctx context.Context, limit int) ([]*RunRecord, error) {
+	store := e.cron.GetHistoryStore()
+	if store == nil {
+		return nil, nil
+	}
+	return store.List(ctx, e.Name, limit)
+}
+
+// LastRun returns the latest run record of the entry, it returns nil if the entry is never run.
+func (e *Entry) LastRun(ctx context.Context) *RunRecord {
+	records, err := e.History(ctx, 1)
+	if err != nil || len(records) == 0 {
+		return nil
+	}
+	return records[0]
+}
+
+// addHistory adds the run record to history store of cron.
+func (e *Entry) addHistory(ctx context.Context, record *RunRecord) {
+	store := e.cron.GetHistoryStore()
+	if store == nil {
+		return
+	}
+	if err := store.Add(ctx, record); err != nil {
+		e.logErrorf(ctx, `cron job "%s" adds history failed: %+v`, e.getJobNameWithPattern(), err)
+	}
+}
+
+// removeHistory removes the run records of the entry from history store of cron.
+func (e *Entry) removeHistory(ctx context.Context) {
+	store := e.cron.GetHistoryStore()
+	if store == nil {
+		return
+	}
+	if err := store.Remove(ctx, e.Name); err != nil {
+		e.logErrorf(ctx, `cron job "%s" removes history failed: %+v`, e.getJobNameWithPattern(), err)
+	}
+}
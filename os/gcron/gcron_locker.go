@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Locker is the distributed lock backend for cron cluster.
+// The implements using database and Redis are in package gcronlocker.
+type Locker interface {
+	// Lock tries to acquire the lock `key` for `owner`, which expires after `ttl`.
+	// It returns true if the lock is acquired, or it is already held by `owner`,
+	// in which case the expiration is extended with `ttl`.
+	Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+
+	// Unlock releases the lock `key` if it is held by `owner`.
+	Unlock(ctx context.Context, key, owner string) error
+}
+
+// LockerMemory is the Locker implements in memory, which works only for crons in the same process.
+// It is usually used for testing.
+type LockerMemory struct {
+	mu    sync.Mutex
+	locks map[string]lockerMemoryItem
+}
+
+type lockerMemoryItem struct {
+	owner  string
+	expire time.Time
+}
+
+var _ Locker = (*LockerMemory)(nil)
+
+// NewLockerMemory creates and returns a new memory Locker.
+func NewLockerMemory() *LockerMemory {
+	return &LockerMemory{
+		locks: make(map[string]lockerMemoryItem),
+	}
+}
+
+// Lock tries to acquire the lock `key` for `owner`, which expires after `ttl`.
+func (l *LockerMemory) Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for k, item := range l.locks {
+		if !item.expire.After(now) {
+			delete(l.locks, k)
+		}
+	}
+	if item, ok := l.locks[key]; ok && item.owner != owner {
+		return false, nil
+	}
+	l.locks[key] = lockerMemoryItem{
+		owner:  owner,
+		expire: now.Add(ttl),
+	}
+	return true, nil
+}
+
+// Unlock releases the lock `key` if it is held by `owner`.
+func (l *LockerMemory) Unlock(ctx context.Context, key, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if item, ok := l.locks[key]; ok && item.owner == owner {
+		delete(l.locks, key)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+type localMetricManager struct {
+	CronJobRunActive    gmetric.UpDownCounter
+	CronJobRunTotal     gmetric.Counter
+	CronJobRunDuration  gmetric.Histogram
+	CronJobMisfireTotal gmetric.Counter
+}
+
+const (
+	instrumentName              = "github.com/gogf/gf/v2/os/gcron.Cron"
+	metricAttrKeyCronJobName    = "cron.job.name"
+	metricAttrKeyCronJobStatus  = "cron.job.status"
+	tracingSpanNamePrefix       = "gcron."
+	tracingAttrCronJobName      = "cron.job.name"
+	tracingAttrCronJobPattern   = "cron.job.pattern"
+	tracingAttrCronJobScheduled = "cron.job.scheduled"
+)
+
+var (
+	// metricManager for cron job metrics.
+	metricManager = newMetricManager()
+)
+
+func newMetricManager() *localMetricManager {
+	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        instrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+	mm := &localMetricManager{
+		CronJobRunDuration: meter.MustHistogram(
+			"cron.job.run.duration",
+			gmetric.MetricOption{
+				Help:       "Measures the duration of cron job runs.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets: []float64{
+					1,
+					10,
+					100,
+					500,
+					1000,
+					5000,
+					10000,
+					30000,
+					60000,
+					300000,
+					600000,
+					1800000,
+					3600000,
+				},
+			},
+		),
+		CronJobRunTotal: meter.MustCounter(
+			"cron.job.run.total",
+			gmetric.MetricOption{
+				Help:       "Total run number of cron jobs.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		CronJobRunActive: meter.MustUpDownCounter(
+			"cron.job.run.active",
+			gmetric.MetricOption{
+				Help:       "Number of running cron jobs.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		CronJobMisfireTotal: meter.MustCounter(
+			"cron.job.misfire.total",
+			gmetric.MetricOption{
+				Help:       "Total missed tick number of cron jobs.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+	}
+	return mm
+}
+
+// GetMetricOptionForEntry returns the metric option for entry.
+func (m *localMetricManager) GetMetricOptionForEntry(entry *Entry) gmetric.Option {
+	return gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyCronJobName, entry.Name),
+		},
+	}
+}
+
+// GetMetricOptionForRecord returns the metric option for run record of entry.
+func (m *localMetricManager) GetMetricOptionForRecord(entry *Entry, record *RunRecord) gmetric.Option {
+	return gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyCronJobName, entry.Name),
+			gmetric.NewAttribute(metricAttrKeyCronJobStatus, string(record.Status)),
+		},
+	}
+}
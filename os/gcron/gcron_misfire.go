@@ -0,0 +1,106 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+// MisfirePolicy specifies what to do with the ticks missed, which happens if the process is paused
+// or blocked longer than the tolerant latency.
+type MisfirePolicy int
+
+const (
+	// MisfireSkip skips all the missed ticks without detecting them, which is the default policy.
+	MisfireSkip MisfirePolicy = iota
+
+	// MisfireFireNow runs the job once immediately for all the missed ticks,
+	// if the current tick does not run the job.
+	MisfireFireNow
+
+	// MisfireCatchUp runs the job immediately for the latest n missed ticks in order.
+	MisfireCatchUp
+)
+
+const (
+	// maxTolerantLatencySeconds is the latency that does not cause misfire, see getAndUpdateLastCheckTimestamp.
+	maxTolerantLatencySeconds = 3
+
+	// maxMisfireTicks is the max missed ticks that are detected for each misfire.
+	maxMisfireTicks = 100
+)
+
+// SetMisfirePolicy sets the misfire policy of the entry.
+// The optional parameter `catchUp` specifies the max missed ticks to run for MisfireCatchUp, which is 1 in default.
+func (e *Entry) SetMisfirePolicy(policy MisfirePolicy, catchUp ...int) {
+	e.misfirePolicy.Set(int(policy))
+	if len(catchUp) > 0 && catchUp[0] > 0 {
+		e.misfireCatchUp.Set(min(catchUp[0], maxMisfireTicks))
+	}
+}
+
+// getMissedTicks returns the latest maxMisfireTicks ticks missed between the last check and `currentTime` in order,
+// it returns nil if there's no latency beyond the tolerant latency.
+//
+// It walks the ticks in a window before `currentTime`, which is doubled until it contains maxMisfireTicks ticks
+// or reaches the last check, so that a long pause does not walk all the missed ticks.
+func (s *cronSchedule) getMissedTicks(currentTime time.Time) []time.Time {
+	lastCheckTimestamp := s.lastCheckTimestamp.Val()
+	if currentTime.Unix()-lastCheckTimestamp <= maxTolerantLatencySeconds {
+		return nil
+	}
+	for window := int64(maxMisfireTicks); ; window *= 2 {
+		var (
+			from  = max(currentTime.Unix()-window, lastCheckTimestamp)
+			ticks []time.Time
+		)
+		for tick := s.Next(time.Unix(from, 0)); tick.Unix() < currentTime.Unix(); tick = s.Next(tick) {
+			if len(ticks) == maxMisfireTicks {
+				ticks = ticks[1:]
+			}
+			ticks = append(ticks, tick)
+		}
+		if len(ticks) == maxMisfireTicks || from == lastCheckTimestamp {
+			return ticks
+		}
+	}
+}
+
+// handleMisfire handles the missed ticks according to the misfire policy of entry, and returns the ticks to run.
+// The parameter `currentMeet` specifies whether the current tick runs the job.
+func (e *Entry) handleMisfire(ctx context.Context, missedTicks []time.Time, currentMeet bool) []time.Time {
+	var runTicks []time.Time
+	switch MisfirePolicy(e.misfirePolicy.Val()) {
+	case MisfireFireNow:
+		if !currentMeet {
+			runTicks = missedTicks[len(missedTicks)-1:]
+		}
+
+	case MisfireCatchUp:
+		runTicks = missedTicks[len(missedTicks)-min(e.misfireCatchUp.Val(), len(missedTicks)):]
+	}
+	e.logDebugf(
+		ctx, `cron job "%s" misfires %d ticks, %d of them run`,
+		e.getJobNameWithPattern(), len(missedTicks), len(runTicks),
+	)
+	if gmetric.IsEnabled() {
+		metricManager.CronJobMisfireTotal.Add(
+			ctx, float64(len(missedTicks)), metricManager.GetMetricOptionForEntry(e),
+		)
+	}
+	for _, tick := range missedTicks[:len(missedTicks)-len(runTicks)] {
+		e.addHistory(ctx, &RunRecord{
+			Entry:     e.Name,
+			Scheduled: tick,
+			Status:    RunStatusMissed,
+		})
+	}
+	return runTicks
+}
@@ -30,6 +30,11 @@ type cronSchedule struct {
 	dayMap          map[int]struct{} // Job can run in these day numbers.
 	weekMap         map[int]struct{} // Job can run in these week numbers.
 	monthMap        map[int]struct{} // Job can run in these moth numbers.
+	dayModifiers    []dayModifier    // Quartz style day modifiers of day field, like: L, L-3, LW, 15W.
+	weekModifiers   []dayModifier    // Quartz style day modifiers of week field, like: 5L, 1#2.
+
+	// Location in which the pattern is evaluated, it is the local time in default.
+	location *gtype.Any
 
 	// This field stores the timestamp that meets schedule latest.
 	lastMeetTimestamp *gtype.Int64
@@ -51,7 +56,10 @@ const (
 
 const (
 	// regular expression for cron pattern, which contains 6 parts of time units.
-	regexForCron = `^([\-/\d\*,#]+)\s+([\-/\d\*,]+)\s+([\-/\d\*,]+)\s+([\-/\d\*\?,]+)\s+([\-/\d\*,A-Za-z]+)\s+([\-/\d\*\?,A-Za-z]+)$`
+	regexForCron = `^([\-/\d\*,#]+)\s+([\-/\d\*,]+)\s+([\-/\d\*,]+)\s+([\-/\d\*\?,LWlw]+)\s+([\-/\d\*,A-Za-z]+)\s+([\-/\d\*\?,A-Za-z#]+)$`
+
+	// regular expression for timezone prefix of cron pattern, like: CRON_TZ=Asia/Shanghai, TZ=UTC.
+	regexForTimezone = `^(?:CRON_TZ|TZ)=(\S+)\s+(.+)$`
 )
 
 var (
@@ -119,7 +127,20 @@ var (
 
 // newSchedule creates and returns a schedule object for given cron pattern.
 func newSchedule(pattern string) (*cronSchedule, error) {
-	var currentTimestamp = time.Now().Unix()
+	var (
+		rawPattern       = pattern
+		currentTimestamp = time.Now().Unix()
+		location         = gtype.NewAny()
+	)
+	// Check and remove the timezone prefix of given `pattern`.
+	if match, _ := gregex.MatchString(regexForTimezone, strings.TrimSpace(pattern)); len(match) == 3 {
+		loc, err := time.LoadLocation(match[1])
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid timezone in pattern: "%s"`, pattern)
+		}
+		location.Set(loc)
+		pattern = match[2]
+	}
 	// Check given `pattern` if the predefined patterns.
 	if match, _ := gregex.MatchString(`(@\w+)\s*(\w*)\s*`, pattern); len(match) > 0 {
 		key := strings.ToLower(match[1])
@@ -134,17 +155,18 @@ func newSchedule(pattern string) (*cronSchedule, error) {
 				createTimestamp:    currentTimestamp,
 				everySeconds:       int64(d.Seconds()),
 				pattern:            pattern,
+				location:           location,
 				lastMeetTimestamp:  gtype.NewInt64(currentTimestamp),
 				lastCheckTimestamp: gtype.NewInt64(currentTimestamp),
 			}, nil
 		} else {
-			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern: "%s"`, pattern)
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern: "%s"`, rawPattern)
 		}
 	}
 	// Handle given `pattern` as common 6 parts pattern.
 	match, _ := gregex.MatchString(regexForCron, pattern)
 	if len(match) != 7 {
-		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern: "%s"`, pattern)
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern: "%s"`, rawPattern)
 	}
 	var (
 		err error
@@ -152,6 +174,7 @@ func newSchedule(pattern string) (*cronSchedule, error) {
 			createTimestamp:    currentTimestamp,
 			everySeconds:       0,
 			pattern:            pattern,
+			location:           location,
 			lastMeetTimestamp:  gtype.NewInt64(currentTimestamp),
 			lastCheckTimestamp: gtype.NewInt64(currentTimestamp),
 		}
@@ -177,7 +200,7 @@ func newSchedule(pattern string) (*cronSchedule, error) {
 		return nil, err
 	}
 	// Day.
-	cs.dayMap, err = parsePatternItem(match[4], 1, 31, true, patternItemTypeDay)
+	cs.dayMap, cs.dayModifiers, err = parsePatternItemWithModifiers(match[4], 1, 31, patternItemTypeDay)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +210,7 @@ func newSchedule(pattern string) (*cronSchedule, error) {
 		return nil, err
 	}
 	// Week.
-	cs.weekMap, err = parsePatternItem(match[6], 0, 6, true, patternItemTypeWeek)
+	cs.weekMap, cs.weekModifiers, err = parsePatternItemWithModifiers(match[6], 0, 6, patternItemTypeWeek)
 	if err != nil {
 		return nil, err
 	}
@@ -286,3 +309,16 @@ func parseWeekAndMonthNameToInt(value string, itemType patternItemType) (int, er
 	}
 	return 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern value: "%s"`, value)
 }
+
+// getLocation returns the location in which the schedule is evaluated, it returns nil for the local time.
+func (s *cronSchedule) getLocation() *time.Location {
+	if loc, ok := s.location.Val().(*time.Location); ok {
+		return loc
+	}
+	return nil
+}
+
+// setLocation sets the location in which the schedule is evaluated.
+func (s *cronSchedule) setLocation(loc *time.Location) {
+	s.location.Set(loc)
+}
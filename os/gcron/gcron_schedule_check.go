@@ -21,6 +21,11 @@ func (s *cronSchedule) checkMeetAndUpdateLastSeconds(ctx context.Context, curren
 		lastCheckTime      = gtime.NewFromTimeStamp(lastCheckTimestamp)
 		lastMeetTime       = gtime.NewFromTimeStamp(s.lastMeetTimestamp.Val())
 	)
+	// The schedule is checked in its own location.
+	if loc := s.getLocation(); loc != nil {
+		lastMeetTime.Time = lastMeetTime.In(loc)
+		currentTime = currentTime.In(loc)
+	}
 	defer func() {
 		if ok {
 			s.lastMeetTimestamp.Set(currentTime.Unix())
@@ -88,7 +93,7 @@ func (s *cronSchedule) checkMeetHour(currentTime time.Time) (ok bool) {
 }
 
 func (s *cronSchedule) checkMeetDay(currentTime time.Time) (ok bool) {
-	return s.keyMatch(s.dayMap, currentTime.Day())
+	return s.keyMatch(s.dayMap, currentTime.Day()) || s.modifierMatch(s.dayModifiers, currentTime)
 }
 
 func (s *cronSchedule) checkMeetMonth(currentTime time.Time) (ok bool) {
@@ -96,7 +101,7 @@ func (s *cronSchedule) checkMeetMonth(currentTime time.Time) (ok bool) {
 }
 
 func (s *cronSchedule) checkMeetWeek(currentTime time.Time) (ok bool) {
-	return s.keyMatch(s.weekMap, int(currentTime.Weekday()))
+	return s.keyMatch(s.weekMap, int(currentTime.Weekday())) || s.modifierMatch(s.weekModifiers, currentTime)
 }
 
 func (s *cronSchedule) keyMatch(m map[int]struct{}, key int) bool {
@@ -104,6 +109,15 @@ func (s *cronSchedule) keyMatch(m map[int]struct{}, key int) bool {
 	return ok
 }
 
+func (s *cronSchedule) modifierMatch(modifiers []dayModifier, t time.Time) bool {
+	for _, modifier := range modifiers {
+		if modifier(t) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *cronSchedule) checkItemMapMeet(lastMeetTime, currentTime time.Time) (ok bool) {
 	// second.
 	if s.ignoreSeconds {
@@ -124,7 +138,7 @@ func (s *cronSchedule) checkItemMapMeet(lastMeetTime, currentTime time.Time) (ok
 		return false
 	}
 	// day.
-	if !s.keyMatch(s.dayMap, currentTime.Day()) {
+	if !s.checkMeetDay(currentTime) {
 		return false
 	}
 	// month.
@@ -132,7 +146,7 @@ func (s *cronSchedule) checkItemMapMeet(lastMeetTime, currentTime time.Time) (ok
 		return false
 	}
 	// week.
-	if !s.keyMatch(s.weekMap, int(currentTime.Weekday())) {
+	if !s.checkMeetWeek(currentTime) {
 		return false
 	}
 	return true
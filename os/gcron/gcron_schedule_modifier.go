@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gregex"
+)
+
+// dayModifier checks whether the date of given time meets a Quartz style day modifier.
+type dayModifier func(t time.Time) bool
+
+const (
+	regexForDayLastOffset     = `^L-(\d+)$`    // Example: L-3, the third to last day of the month.
+	regexForDayNearestWeekday = `^(\d+)W$`     // Example: 15W, the nearest weekday to the 15th of the month.
+	regexForWeekLastOfMonth   = `^(\w+)L$`     // Example: 5L, FRIL, the last Friday of the month.
+	regexForWeekNthOfMonth    = `^(\w+)#(\d)$` // Example: 1#2, MON#2, the second Monday of the month.
+	dayModifierLastDay        = "L"            // The last day of the month.
+	dayModifierLastWeekday    = "LW"           // The last weekday of the month.
+	maxWeekNumberInMonth      = 5
+	maxDayLastOffsetInMonth   = 30
+)
+
+// parsePatternItemWithModifiers parses the day or week item which might contain Quartz style day modifiers,
+// the modifiers are returned separately from the item map.
+func parsePatternItemWithModifiers(
+	item string, min int, max int, itemType patternItemType,
+) (itemMap map[int]struct{}, modifiers []dayModifier, err error) {
+	var (
+		modifier dayModifier
+		elements = make([]string, 0)
+	)
+	for _, itemElem := range strings.Split(item, ",") {
+		switch itemType {
+		case patternItemTypeDay:
+			modifier, err = parseDayModifier(itemElem)
+		case patternItemTypeWeek:
+			modifier, err = parseWeekModifier(itemElem)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if modifier != nil {
+			modifiers = append(modifiers, modifier)
+		} else {
+			elements = append(elements, itemElem)
+		}
+	}
+	if len(elements) == 0 {
+		return make(map[int]struct{}), modifiers, nil
+	}
+	itemMap, err = parsePatternItem(strings.Join(elements, ","), min, max, true, itemType)
+	return itemMap, modifiers, err
+}
+
+// parseDayModifier parses the modifier of day field, it returns nil if `value` is not a modifier.
+func parseDayModifier(value string) (dayModifier, error) {
+	value = strings.ToUpper(value)
+	if !strings.ContainsAny(value, dayModifierLastWeekday) {
+		return nil, nil
+	}
+	switch value {
+	case dayModifierLastDay:
+		return func(t time.Time) bool {
+			return t.Day() == lastDayOfMonth(t)
+		}, nil
+
+	case dayModifierLastWeekday:
+		return func(t time.Time) bool {
+			return t.Day() == nearestWeekdayOfMonth(t, lastDayOfMonth(t))
+		}, nil
+	}
+	if match, _ := gregex.MatchString(regexForDayLastOffset, value); len(match) == 2 {
+		offset, _ := strconv.Atoi(match[1])
+		if offset <= maxDayLastOffsetInMonth {
+			return func(t time.Time) bool {
+				return t.Day() == lastDayOfMonth(t)-offset
+			}, nil
+		}
+	}
+	if match, _ := gregex.MatchString(regexForDayNearestWeekday, value); len(match) == 2 {
+		day, _ := strconv.Atoi(match[1])
+		if day >= 1 && day <= 31 {
+			return func(t time.Time) bool {
+				return t.Day() == nearestWeekdayOfMonth(t, day)
+			}, nil
+		}
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern item: "%s"`, value)
+}
+
+// parseWeekModifier parses the modifier of week field, it returns nil if `value` is not a modifier.
+func parseWeekModifier(value string) (dayModifier, error) {
+	if match, _ := gregex.MatchString(regexForWeekNthOfMonth, value); len(match) == 3 {
+		weekday, err := parseWeekAndMonthNameToInt(match[1], patternItemTypeWeek)
+		if err != nil || weekday > 6 {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern item: "%s"`, value)
+		}
+		nth, _ := strconv.Atoi(match[2])
+		if nth < 1 || nth > maxWeekNumberInMonth {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern item: "%s"`, value)
+		}
+		return func(t time.Time) bool {
+			return int(t.Weekday()) == weekday && (t.Day()-1)/7+1 == nth
+		}, nil
+	}
+	if strings.Contains(value, "#") {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern item: "%s"`, value)
+	}
+	if match, _ := gregex.MatchString(regexForWeekLastOfMonth, strings.ToUpper(value)); len(match) == 2 {
+		weekday, err := parseWeekAndMonthNameToInt(match[1], patternItemTypeWeek)
+		if err != nil || weekday > 6 {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern item: "%s"`, value)
+		}
+		return func(t time.Time) bool {
+			return int(t.Weekday()) == weekday && t.Day()+7 > lastDayOfMonth(t)
+		}, nil
+	}
+	return nil, nil
+}
+
+// lastDayOfMonth returns the last day number of the month of `t`.
+func lastDayOfMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekdayOfMonth returns the day number of the nearest weekday to `day` in the month of `t`,
+// which never goes across the month.
+func nearestWeekdayOfMonth(t time.Time, day int) int {
+	lastDay := lastDayOfMonth(t)
+	if day > lastDay {
+		day = lastDay
+	}
+	switch time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+
+	case time.Sunday:
+		if day == lastDay {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
@@ -12,13 +12,22 @@ import (
 
 // Next returns the next time this schedule is activated, greater than the given
 // time.  If no time can be found to satisfy the schedule, return the zero time.
+//
+// The calculation is done in the location of schedule, and it is DST correct, which
+// skips the nonexistent wall clock time and never goes back in time.
 func (s *cronSchedule) Next(lastMeetTime time.Time) time.Time {
+	if loc := s.getLocation(); loc != nil {
+		lastMeetTime = lastMeetTime.In(loc)
+	}
 	if s.everySeconds != 0 {
 		var (
 			diff  = lastMeetTime.Unix() - s.createTimestamp
 			count = diff/s.everySeconds + 1
 		)
-		return lastMeetTime.Add(time.Duration(count*s.everySeconds) * time.Second)
+		if count < 1 {
+			count = 1
+		}
+		return time.Unix(s.createTimestamp+count*s.everySeconds, 0).In(lastMeetTime.Location())
 	}
 
 	var currentTime = lastMeetTime
@@ -55,15 +64,16 @@ WRAP:
 		}
 	}
 	for !s.checkMeetHour(currentTime) {
-		currentTime = currentTime.Add(time.Hour)
-		currentTime = currentTime.Truncate(time.Hour)
+		// Adding absolute duration from the start of the hour, which is DST correct.
+		currentTime = currentTime.Add(
+			time.Hour - time.Duration(currentTime.Minute())*time.Minute - time.Duration(currentTime.Second())*time.Second,
+		)
 		if currentTime.Hour() == 0 {
 			goto WRAP
 		}
 	}
 	for !s.checkMeetMinute(currentTime) {
-		currentTime = currentTime.Add(1 * time.Minute)
-		currentTime = currentTime.Truncate(time.Minute)
+		currentTime = currentTime.Add(1*time.Minute - time.Duration(currentTime.Second())*time.Second)
 		if currentTime.Minute() == 0 {
 			goto WRAP
 		}
@@ -0,0 +1,127 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gcron"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type failedLocker struct{}
+
+func (failedLocker) Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	return false, errors.New("locker unavailable")
+}
+
+func (failedLocker) Unlock(ctx context.Context, key, owner string) error {
+	return nil
+}
+
+func TestCron_Cluster_Tick(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			locker = gcron.NewLockerMemory()
+			ticks  = garray.NewIntArray(true)
+			crons  = make([]*gcron.Cron, 3)
+		)
+		for i := range crons {
+			crons[i] = gcron.New()
+			t.AssertNil(crons[i].SetCluster(gcron.ClusterOption{Locker: locker}))
+			_, err := crons[i].Add(ctx, "* * * * * *", func(ctx context.Context) {
+				ticks.Append(int(time.Now().Unix()))
+			}, "tick")
+			t.AssertNil(err)
+		}
+		time.Sleep(2500 * time.Millisecond)
+		for _, cron := range crons {
+			cron.Close()
+		}
+		t.AssertGE(ticks.Len(), 2)
+		t.Assert(ticks.Len(), ticks.Unique().Len())
+	})
+}
+
+func TestCron_Cluster_Leader(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			locker = gcron.NewLockerMemory()
+			count1 = gtype.NewInt()
+			count2 = gtype.NewInt()
+			cron1  = gcron.New()
+			cron2  = gcron.New()
+			option = gcron.ClusterOption{
+				Locker: locker,
+				Mode:   gcron.ClusterModeLeader,
+			}
+		)
+		defer cron2.Close()
+		option.Owner = "cron1"
+		t.AssertNil(cron1.SetCluster(option))
+		t.Assert(cron1.IsLeader(ctx), true)
+		option.Owner = "cron2"
+		t.AssertNil(cron2.SetCluster(option))
+		t.Assert(cron2.IsLeader(ctx), false)
+
+		_, err := cron1.Add(ctx, "* * * * * *", func(ctx context.Context) {
+			count1.Add(1)
+		}, "job")
+		t.AssertNil(err)
+		_, err = cron2.Add(ctx, "* * * * * *", func(ctx context.Context) {
+			count2.Add(1)
+		}, "job")
+		t.AssertNil(err)
+		time.Sleep(1500 * time.Millisecond)
+		t.AssertGE(count1.Val(), 1)
+		t.Assert(count2.Val(), 0)
+
+		// The leader lease is released on closing, and the other instance takes over.
+		cron1.Close()
+		time.Sleep(1500 * time.Millisecond)
+		t.AssertGE(count2.Val(), 1)
+		t.Assert(cron2.IsLeader(ctx), true)
+	})
+}
+
+func TestCron_Cluster_Misfire(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(gcron.New().SetCluster(gcron.ClusterOption{}), nil)
+
+		var (
+			skipped = gtype.NewInt()
+			run     = gtype.NewInt()
+			cron1   = gcron.New()
+			cron2   = gcron.New()
+		)
+		defer cron1.Close()
+		defer cron2.Close()
+		t.AssertNil(cron1.SetCluster(gcron.ClusterOption{
+			Locker: failedLocker{},
+		}))
+		t.AssertNil(cron2.SetCluster(gcron.ClusterOption{
+			Locker:  failedLocker{},
+			Misfire: gcron.ClusterMisfireRun,
+		}))
+		_, err := cron1.Add(ctx, "* * * * * *", func(ctx context.Context) {
+			skipped.Add(1)
+		})
+		t.AssertNil(err)
+		_, err = cron2.Add(ctx, "* * * * * *", func(ctx context.Context) {
+			run.Add(1)
+		})
+		t.AssertNil(err)
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(skipped.Val(), 0)
+		t.AssertGE(run.Val(), 1)
+	})
+}
@@ -67,3 +67,48 @@ func TestCron_Entry_Operations(t *testing.T) {
 		t.Assert(cron.Size(), 0)
 	})
 }
+
+func TestCron_Entry_NextRun(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cron := gcron.New()
+		defer cron.Close()
+		entry, err := cron.Add(ctx, "CRON_TZ=UTC 0 30 * * * *", func(ctx context.Context) {})
+		t.AssertNil(err)
+		times := entry.NextRun(3)
+		t.Assert(len(times), 3)
+		t.AssertGT(times[0].Unix(), time.Now().Unix())
+		for i, v := range times {
+			t.Assert(v.Location().String(), "UTC")
+			t.Assert(v.Minute(), 30)
+			t.Assert(v.Second(), 0)
+			if i > 0 {
+				t.Assert(v.Sub(times[i-1]), time.Hour)
+			}
+		}
+
+		loc, err := time.LoadLocation("Asia/Kolkata")
+		t.AssertNil(err)
+		entry.SetLocation(loc)
+		times = entry.NextRun(2)
+		t.Assert(times[0].Location().String(), "Asia/Kolkata")
+		t.Assert(times[0].Minute(), 30)
+		t.Assert(times[1].Sub(times[0]), time.Hour)
+	})
+}
+
+func TestCron_Entry_Jitter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron  = gcron.New()
+			array = garray.New(true)
+		)
+		defer cron.Close()
+		entry, err := cron.Add(ctx, "* * * * * *", func(ctx context.Context) {
+			array.Append(time.Now().Nanosecond())
+		})
+		t.AssertNil(err)
+		entry.SetJitter(300 * time.Millisecond)
+		time.Sleep(2500 * time.Millisecond)
+		t.AssertGE(array.Len(), 1)
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestHistoryStoreMemory(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = context.TODO()
+			store = NewHistoryStoreMemory(3)
+		)
+		for i := 1; i <= 5; i++ {
+			t.AssertNil(store.Add(ctx, &RunRecord{Entry: "job", Error: fmt.Sprint(i)}))
+		}
+		records, err := store.List(ctx, "job", 0)
+		t.AssertNil(err)
+		t.Assert(len(records), 3)
+		t.Assert(records[0].Error, "5")
+		t.Assert(records[2].Error, "3")
+
+		records, err = store.List(ctx, "job", 2)
+		t.AssertNil(err)
+		t.Assert(len(records), 2)
+		t.Assert(records[1].Error, "4")
+
+		records, err = store.List(ctx, "none", 2)
+		t.AssertNil(err)
+		t.Assert(len(records), 0)
+
+		t.AssertNil(store.Remove(ctx, "job"))
+		records, err = store.List(ctx, "job", 0)
+		t.AssertNil(err)
+		t.Assert(len(records), 0)
+		t.Assert(len(store.buffers), 0)
+	})
+}
+
+func TestEntry_History(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.TODO()
+			cron = New()
+		)
+		defer cron.Close()
+		entry, err := cron.AddTimes(ctx, "* * * * * *", 3, func(ctx context.Context) {
+			if entry := cron.Search("history"); entry != nil && entry.LastRun(ctx) != nil {
+				panic("second run")
+			}
+		}, "history")
+		t.AssertNil(err)
+		var records []*RunRecord
+		for i := 0; i < 300 && len(records) < 2; i++ {
+			time.Sleep(10 * time.Millisecond)
+			records, err = entry.History(ctx, 10)
+			t.AssertNil(err)
+		}
+		t.Assert(len(records), 2)
+		t.Assert(records[0].Status, RunStatusFailed)
+		t.Assert(records[0].Error, "second run")
+		t.Assert(records[1].Status, RunStatusSuccess)
+		t.AssertGE(records[1].Start.Unix(), records[1].Scheduled.Unix())
+
+		// The records are removed along with the entry.
+		entry.Close()
+		t.Assert(entry.LastRun(ctx), nil)
+
+		cron.SetHistoryStore(nil)
+		t.Assert(entry.LastRun(ctx), nil)
+	})
+}
+
+func TestEntry_Misfire(t *testing.T) {
+	// waitForSecondStart waits until the beginning of next second, to avoid the second changing in testing.
+	waitForSecondStart := func() time.Time {
+		time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second + 50*time.Millisecond)))
+		return time.Now()
+	}
+	// newMisfireEntry creates an entry whose timer is stopped, and the last 10 seconds are missed.
+	newMisfireEntry := func(cron *Cron, pattern string, array *garray.Array) *Entry {
+		entry, err := cron.Add(context.TODO(), pattern, func(ctx context.Context) {
+			array.Append(ctx)
+		})
+		if err != nil {
+			panic(err)
+		}
+		entry.timerEntry.Stop()
+		entry.schedule.lastCheckTimestamp.Set(time.Now().Unix() - 10)
+		return entry
+	}
+	var ctx = context.TODO()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cron    = New()
+			array   = garray.New(true)
+			now     = waitForSecondStart()
+			pattern = fmt.Sprintf("%d,%d * * * * *", (now.Second()+55)%60, (now.Second()+57)%60)
+		)
+		defer cron.Close()
+		// Skip, the missed ticks are not detected.
+		entry := newMisfireEntry(cron, pattern, array)
+		entry.checkAndRun(ctx)
+		t.Assert(array.Len(), 0)
+		records, _ := entry.History(ctx, 10)
+		t.Assert(len(records), 0)
+
+		// Fire now.
+		entry = newMisfireEntry(cron, pattern, array)
+		entry.SetMisfirePolicy(MisfireFireNow)
+		entry.checkAndRun(ctx)
+		t.Assert(array.Len(), 1)
+		records, _ = entry.History(ctx, 10)
+		t.Assert(len(records), 2)
+		t.Assert(records[0].Status, RunStatusSuccess)
+		t.Assert(records[0].Scheduled.Unix(), now.Unix()-3)
+		t.Assert(records[1].Status, RunStatusMissed)
+		t.Assert(records[1].Scheduled.Unix(), now.Unix()-5)
+
+		// Catch up.
+		array.Clear()
+		entry = newMisfireEntry(cron, pattern, array)
+		entry.SetMisfirePolicy(MisfireCatchUp, 5)
+		entry.checkAndRun(ctx)
+		t.Assert(array.Len(), 2)
+		records, _ = entry.History(ctx, 10)
+		t.Assert(len(records), 2)
+		t.Assert(records[0].Status, RunStatusSuccess)
+		t.Assert(records[1].Status, RunStatusSuccess)
+		t.Assert(records[1].Scheduled.Unix(), now.Unix()-5)
+	})
+}
+
+func TestSchedule_GetMissedTicks(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		schedule, err := newSchedule("* * * * * *")
+		t.AssertNil(err)
+		var (
+			now   = time.Now().Truncate(time.Second)
+			start = time.Now()
+		)
+		// Only the latest ticks are walked for a long pause.
+		schedule.lastCheckTimestamp.Set(now.AddDate(-10, 0, 0).Unix())
+		ticks := schedule.getMissedTicks(now)
+		t.Assert(len(ticks), maxMisfireTicks)
+		t.Assert(ticks[0].Unix(), now.Unix()-maxMisfireTicks)
+		t.Assert(ticks[len(ticks)-1].Unix(), now.Unix()-1)
+		t.AssertLT(time.Since(start), time.Second)
+
+		// The sparse ticks are found by expanding the window.
+		schedule, err = newSchedule("0 0 0 1 1 *")
+		t.AssertNil(err)
+		schedule.lastCheckTimestamp.Set(now.AddDate(-3, 0, 0).Unix())
+		ticks = schedule.getMissedTicks(now)
+		t.Assert(len(ticks), 3)
+		t.Assert(ticks[len(ticks)-1].Month(), time.January)
+		t.Assert(ticks[len(ticks)-1].Year(), now.Year())
+
+		// No tick is missed within the tolerant latency.
+		schedule.lastCheckTimestamp.Set(now.Unix() - maxTolerantLatencySeconds)
+		t.Assert(len(schedule.getMissedTicks(now)), 0)
+	})
+}
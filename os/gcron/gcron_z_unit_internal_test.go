@@ -0,0 +1,51 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ClusterOption_TickKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			option = &ClusterOption{Name: "gcron"}
+			base   = time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+			newKey = func(pattern string, tick time.Time) string {
+				schedule, err := newSchedule(pattern)
+				t.AssertNil(err)
+				return option.tickKey(&Entry{Name: "job", schedule: schedule}, tick)
+			}
+		)
+		// The "#" pattern runs at any second of the minute.
+		t.Assert(
+			newKey("# * * * * *", base.Add(3*time.Second)),
+			newKey("# * * * * *", base.Add(59*time.Second+500*time.Millisecond)),
+		)
+		t.AssertNE(
+			newKey("# * * * * *", base.Add(59*time.Second)),
+			newKey("# * * * * *", base.Add(time.Minute)),
+		)
+		t.Assert(newKey("# * * * * *", base.Add(3*time.Second)), "gcron:job:1704105000")
+
+		// The precise pattern runs at the scheduled second.
+		t.Assert(newKey("*/5 * * * * *", base.Add(5*time.Second+300*time.Millisecond)), "gcron:job:1704105005")
+		t.AssertNE(
+			newKey("*/5 * * * * *", base.Add(5*time.Second)),
+			newKey("*/5 * * * * *", base.Add(10*time.Second)),
+		)
+
+		// The interval pattern runs in the interval from epoch.
+		t.Assert(
+			newKey("@every 10s", base.Add(1*time.Second)),
+			newKey("@every 10s", base.Add(9*time.Second)),
+		)
+	})
+}
@@ -85,6 +85,24 @@ func TestNext(t *testing.T) {
 		// Ignore seconds.
 		{"Mon Jul 9 23:35 2012", "# * * * * *", "Mon Jul 9 23:36 2012"},
 		{"Mon Jul 9 23:35 2012", "# */2 * * * *", "Mon Jul 9 23:36 2012"},
+
+		// Day modifiers.
+		{"Mon Jul 9 23:35 2012", "0 0 0 L * ?", "Tue Jul 31 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 L-2 * ?", "Sun Jul 29 00:00 2012"},
+		{"Mon Sep 3 00:00 2012", "0 0 0 LW * ?", "Fri Sep 28 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 15W * ?", "Mon Jul 16 00:00 2012"},
+		{"Mon Aug 6 00:00 2012", "0 0 0 1W * ?", "Mon Sep 3 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 ? * 5L", "Fri Jul 27 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 ? * MON#2", "Mon Aug 13 00:00 2012"},
+		{"Mon Jul 9 23:35 2012", "0 0 0 ? * 0#1,FRIL", "Fri Jul 27 00:00 2012"},
+
+		// Timezone.
+		{"TZ=UTC Mon Jul 9 23:35 2012", "CRON_TZ=Asia/Shanghai 0 0 9 * * *", "TZ=UTC Tue Jul 10 01:00 2012"},
+		{"TZ=UTC Mon Jul 9 23:35 2012", "TZ=Asia/Shanghai @daily", "TZ=UTC Tue Jul 10 16:00 2012"},
+
+		// DST, the nonexistent time is skipped and the repeated time is not doubled.
+		{"TZ=America/New_York Sat Mar 10 03:00 2012", "CRON_TZ=America/New_York 0 30 2 * * *", "TZ=America/New_York Mon Mar 12 02:30 2012"},
+		{"TZ=America/New_York Sat Nov 3 23:35 2012", "CRON_TZ=America/New_York 0 0 3 * * *", "TZ=America/New_York Sun Nov 4 03:00 2012"},
 	}
 
 	for _, c := range runs {
@@ -105,6 +123,23 @@ func TestNext(t *testing.T) {
 	}
 }
 
+func TestScheduleModifier_Invalid(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, spec := range []string{
+			"0 0 0 L-x * ?",
+			"0 0 0 32W * ?",
+			"0 0 0 WL * ?",
+			"0 0 0 ? * 1#6",
+			"0 0 0 ? * XYZ#1",
+			"0 0 0 ? * 8L",
+			"TZ=Invalid/Zone * * * * * *",
+		} {
+			_, err := newSchedule(spec)
+			t.AssertNE(err, nil)
+		}
+	})
+}
+
 func getTime(value string) time.Time {
 	if value == "" {
 		return time.Time{}
@@ -118,7 +153,7 @@ func getTime(value string) time.Time {
 			panic("could not parse location:" + err.Error())
 		}
 		location = loc
-		value = parts[1]
+		value = strings.Join(parts[1:], " ")
 	}
 
 	var layouts = []string{
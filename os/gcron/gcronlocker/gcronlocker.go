@@ -0,0 +1,18 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gcronlocker provides the distributed lock backends of cron cluster using database and Redis,
+// which are kept out of package gcron so that it does not depend on the database packages.
+package gcronlocker
+
+import (
+	"github.com/gogf/gf/v2/os/gcron"
+)
+
+var (
+	_ gcron.Locker = (*DB)(nil)
+	_ gcron.Locker = (*Redis)(nil)
+)
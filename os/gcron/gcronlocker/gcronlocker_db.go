@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcronlocker
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// DB is the gcron.Locker implements using database table, which should be created in advance, eg in MySQL:
+//
+//	CREATE TABLE `gcron_lock` (
+//	    `name`      varchar(255) NOT NULL COMMENT 'Lock key',
+//	    `owner`     varchar(64)  NOT NULL COMMENT 'Lock owner',
+//	    `expire_at` bigint       NOT NULL COMMENT 'Expiration timestamp in milliseconds',
+//	    PRIMARY KEY (`name`)
+//	);
+type DB struct {
+	db    gdb.DB
+	table string
+}
+
+const (
+	defaultDBTable = "gcron_lock"
+)
+
+// NewDB creates and returns a new database Locker.
+// The optional parameter `table` specifies the lock table name, which is "gcron_lock" in default.
+func NewDB(db gdb.DB, table ...string) *DB {
+	l := &DB{
+		db:    db,
+		table: defaultDBTable,
+	}
+	if len(table) > 0 && table[0] != "" {
+		l.table = table[0]
+	}
+	return l
+}
+
+// Lock tries to acquire the lock `key` for `owner`, which expires after `ttl`.
+func (l *DB) Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	var (
+		now      = time.Now().UnixMilli()
+		expireAt = now + ttl.Milliseconds()
+	)
+	// Remove the expired lock.
+	_, err := l.db.Model(l.table).Ctx(ctx).Where("name", key).WhereLT("expire_at", now).Delete()
+	if err != nil {
+		return false, err
+	}
+	// Extend the lock held by the owner.
+	result, err := l.db.Model(l.table).Ctx(ctx).
+		Data(map[string]any{"expire_at": expireAt}).
+		Where("name", key).
+		Where("owner", owner).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return true, nil
+	}
+	// Acquire the lock, it fails if the lock is held by another owner.
+	result, err = l.db.Model(l.table).Ctx(ctx).InsertIgnore(map[string]any{
+		"name":      key,
+		"owner":     owner,
+		"expire_at": expireAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Unlock releases the lock `key` if it is held by `owner`.
+func (l *DB) Unlock(ctx context.Context, key, owner string) error {
+	_, err := l.db.Model(l.table).Ctx(ctx).Where("name", key).Where("owner", owner).Delete()
+	return err
+}
@@ -0,0 +1,64 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcronlocker
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+)
+
+// Redis is the gcron.Locker implements using Redis server.
+type Redis struct {
+	redis *gredis.Redis
+}
+
+const (
+	// redisLockScript acquires the lock if it does not exist, or extends it if it is held by the owner.
+	redisLockScript = `
+local v = redis.call('GET', KEYS[1])
+if v == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+if not v then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+return 0
+`
+	// redisUnlockScript deletes the lock only if it is held by the owner.
+	redisUnlockScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+)
+
+// NewRedis creates and returns a new Redis Locker.
+func NewRedis(redis *gredis.Redis) *Redis {
+	return &Redis{
+		redis: redis,
+	}
+}
+
+// Lock tries to acquire the lock `key` for `owner`, which expires after `ttl`.
+func (l *Redis) Lock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	v, err := l.redis.Eval(ctx, redisLockScript, 1, []string{key}, []any{owner, ttl.Milliseconds()})
+	if err != nil {
+		return false, err
+	}
+	return v.Int() == 1, nil
+}
+
+// Unlock releases the lock `key` if it is held by `owner`.
+func (l *Redis) Unlock(ctx context.Context, key, owner string) error {
+	_, err := l.redis.Eval(ctx, redisUnlockScript, 1, []string{key}, []any{owner})
+	return err
+}
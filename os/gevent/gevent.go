@@ -0,0 +1,68 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gevent implements an application-level event bus with pluggable transports,
+// which publishes events to topics and delivers them to the subscribers of matching topic patterns.
+//
+// Topics are names separated by '.', eg "order.created". The topic pattern of subscription supports wildcards:
+// '*' matches exactly one segment, eg "order.*" matches "order.created" but not "order.item.created";
+// '>' at the end matches one or more segments, eg "order.>" matches both of them.
+package gevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Event is an event published to the bus.
+type Event struct {
+	ID       string            `json:"id"`                 // Unique id of the event.
+	Topic    string            `json:"topic"`              // Topic of the event.
+	Payload  string            `json:"payload"`            // Payload of the event in JSON.
+	Time     time.Time         `json:"time"`               // Time at which the event is published.
+	Metadata map[string]string `json:"metadata,omitempty"` // Metadata of the event, eg the tracing context.
+}
+
+// HandlerFunc is the handler function for events.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Middleware wraps the handler function with extra logic, eg tracing and recovery.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// DeliverFunc is the function of bus that transports call for the received events,
+// the event is delivered successfully if it returns nil.
+type DeliverFunc func(ctx context.Context, event *Event) error
+
+// Transport transports the published events to the buses, which might be in other processes.
+type Transport interface {
+	// Publish sends the event to the buses.
+	Publish(ctx context.Context, event *Event) error
+
+	// Start starts receiving events, which are passed to `deliver`.
+	Start(ctx context.Context, deliver DeliverFunc) error
+
+	// Close stops receiving events.
+	Close(ctx context.Context) error
+}
+
+// iTransportLogger is the interface for transports logging errors in receiving,
+// the bus sets its logger to the transport before starting it.
+type iTransportLogger interface {
+	setLogger(logger glog.ILogger)
+}
+
+// Scan decodes the payload of event to `pointer` using gconv.
+func (e *Event) Scan(pointer any) error {
+	var value any
+	if err := json.UnmarshalUseNumber([]byte(e.Payload), &value); err != nil {
+		return err
+	}
+	return gconv.Scan(value, pointer)
+}
@@ -0,0 +1,248 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/grpool"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Bus is the event bus, which publishes events through its transport and delivers
+// the received events to the subscribers.
+type Bus struct {
+	option        Option
+	mu            sync.RWMutex
+	subscriptions []*Subscription // Subscriptions in order of subscribing.
+	middlewares   []Middleware    // Middlewares for all handlers, in order of wrapping from outside.
+	started       *gtype.Bool     // Whether the transport is started.
+}
+
+// Option is the option for event bus.
+type Option struct {
+	Transport Transport    // Transport of events, it is an in-process transport in default.
+	Pool      *grpool.Pool // Goroutine pool for asynchronous delivery, it uses the default pool of grpool if it is nil.
+	Logger    glog.ILogger // Logger for handler errors of asynchronous delivery and receiving errors of transport, it uses the default logger if it is nil.
+}
+
+// Subscription is the subscription of handler to topic pattern.
+type Subscription struct {
+	bus     *Bus
+	pattern string
+	handler HandlerFunc
+	async   bool
+}
+
+// SubscribeOption is the option for subscribing.
+type SubscribeOption struct {
+	// Async specifies the handler is called asynchronously in goroutine pool,
+	// the handler error does not fail the delivery but is logged.
+	// The handler is called synchronously in default, and its error fails the delivery,
+	// which is returned to publisher for in-process transport, or causes redelivery for at-least-once transport.
+	Async bool
+}
+
+// New creates and returns a new event bus.
+// The bus does not receive events from transport until it is started.
+func New(option ...Option) *Bus {
+	var opt Option
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Transport == nil {
+		opt.Transport = NewTransportLocal()
+	}
+	return &Bus{
+		option:  opt,
+		started: gtype.NewBool(),
+	}
+}
+
+// Use adds middlewares for all handlers of the bus.
+func (b *Bus) Use(middlewares ...Middleware) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.middlewares = append(b.middlewares, middlewares...)
+}
+
+// Subscribe subscribes `handler` to the topics matching `pattern`, which might contain wildcards.
+func (b *Bus) Subscribe(pattern string, handler HandlerFunc, option ...SubscribeOption) *Subscription {
+	var opt SubscribeOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	s := &Subscription{
+		bus:     b,
+		pattern: pattern,
+		handler: handler,
+		async:   opt.Async,
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, s)
+	return s
+}
+
+// SubscribeT subscribes `handler` to the topics matching `pattern` of bus `b`,
+// the payload of event is decoded to type `T` using gconv.
+func SubscribeT[T any](
+	b *Bus, pattern string, handler func(ctx context.Context, payload T) error, option ...SubscribeOption,
+) *Subscription {
+	return b.Subscribe(pattern, func(ctx context.Context, event *Event) error {
+		var payload T
+		if err := event.Scan(&payload); err != nil {
+			return err
+		}
+		return handler(ctx, payload)
+	}, option...)
+}
+
+// Pattern returns the topic pattern of subscription.
+func (s *Subscription) Pattern() string {
+	return s.pattern
+}
+
+// Unsubscribe removes the subscription from bus.
+func (s *Subscription) Unsubscribe() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	for i, v := range s.bus.subscriptions {
+		if v == s {
+			s.bus.subscriptions = append(s.bus.subscriptions[:i:i], s.bus.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish publishes an event of `topic` with `payload`, which is encoded in JSON.
+// The tracing context of `ctx` is propagated with the event.
+func (b *Bus) Publish(ctx context.Context, topic string, payload any) error {
+	if topic == "" {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `event topic should not be empty`)
+	}
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return gerror.Wrapf(err, `encode payload of event "%s" failed`, topic)
+	}
+	event := &Event{
+		ID:       guid.S(),
+		Topic:    topic,
+		Payload:  string(content),
+		Time:     time.Now(),
+		Metadata: make(map[string]string),
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(event.Metadata))
+	return b.option.Transport.Publish(ctx, event)
+}
+
+// Start starts receiving events from transport and delivering them to the subscribers.
+func (b *Bus) Start(ctx context.Context) error {
+	if !b.started.Cas(false, true) {
+		return nil
+	}
+	if t, ok := b.option.Transport.(iTransportLogger); ok {
+		t.setLogger(b.logger())
+	}
+	if err := b.option.Transport.Start(ctx, b.deliver); err != nil {
+		b.started.Set(false)
+		return err
+	}
+	return nil
+}
+
+// Close stops receiving events from transport.
+func (b *Bus) Close(ctx context.Context) error {
+	if !b.started.Cas(true, false) {
+		return nil
+	}
+	return b.option.Transport.Close(ctx)
+}
+
+// deliver calls the handlers of subscriptions matching the topic of `event`.
+// It returns the errors of synchronous handlers.
+func (b *Bus) deliver(ctx context.Context, event *Event) error {
+	if len(event.Metadata) > 0 {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(event.Metadata))
+	}
+	b.mu.RLock()
+	var (
+		subscriptions []*Subscription
+		middlewares   = b.middlewares
+	)
+	for _, s := range b.subscriptions {
+		if MatchTopic(s.pattern, event.Topic) {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscriptions {
+		handler := s.handler
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			handler = middlewares[i](handler)
+		}
+		if !s.async {
+			if err := handler(ctx, event); err != nil {
+				errs = append(errs, gerror.Wrapf(
+					err, `handle event "%s" of topic "%s" by subscription "%s" failed`,
+					event.ID, event.Topic, s.pattern,
+				))
+			}
+			continue
+		}
+		var (
+			pattern = s.pattern
+			asyncFn = func(ctx context.Context) {
+				if err := handler(ctx, event); err != nil {
+					b.logErrorf(
+						ctx, `handle event "%s" of topic "%s" by subscription "%s" failed: %+v`,
+						event.ID, event.Topic, pattern, err,
+					)
+				}
+			}
+			err error
+		)
+		asyncCtx := context.WithoutCancel(ctx)
+		if b.option.Pool != nil {
+			err = b.option.Pool.Add(asyncCtx, asyncFn)
+		} else {
+			err = grpool.Add(asyncCtx, asyncFn)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// logger returns the logger of bus, which is the default logger if it is not set.
+func (b *Bus) logger() glog.ILogger {
+	if b.option.Logger == nil {
+		return glog.DefaultLogger()
+	}
+	return b.option.Logger
+}
+
+func (b *Bus) logErrorf(ctx context.Context, format string, v ...any) {
+	b.logger().Errorf(ctx, format, v...)
+}
@@ -0,0 +1,53 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/net/gtrace"
+)
+
+const (
+	tracingSpanNameHandle = "gevent.handle"
+	tracingAttrEventID    = "event.id"
+	tracingAttrEventTopic = "event.topic"
+)
+
+// MiddlewareRecover is the middleware that recovers the panic of handler as error of code gcode.CodeInternalPanic.
+func MiddlewareRecover(next HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, event *Event) (err error) {
+		defer func() {
+			if exception := recover(); exception != nil {
+				err = utils.PanicToError(exception)
+			}
+		}()
+		return next(ctx, event)
+	}
+}
+
+// MiddlewareTracing is the middleware that creates a tracing span for handling event,
+// which is the child of the span publishing the event.
+func MiddlewareTracing(next HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, event *Event) error {
+		ctx, span := gtrace.NewSpan(ctx, tracingSpanNameHandle)
+		defer span.End()
+		span.SetAttributes(
+			attribute.String(tracingAttrEventID, event.ID),
+			attribute.String(tracingAttrEventTopic, event.Topic),
+		)
+		err := next(ctx, event)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent
+
+import (
+	"strings"
+)
+
+const (
+	topicSeparator      = "."
+	topicWildcardSingle = "*" // Matches exactly one segment.
+	topicWildcardTail   = ">" // Matches one or more segments at the end.
+)
+
+// MatchTopic checks whether `topic` matches the topic `pattern`, which might contain wildcards.
+func MatchTopic(pattern, topic string) bool {
+	if pattern == topic {
+		return true
+	}
+	var (
+		patternSegments = strings.Split(pattern, topicSeparator)
+		topicSegments   = strings.Split(topic, topicSeparator)
+	)
+	for i, segment := range patternSegments {
+		if segment == topicWildcardTail && i == len(patternSegments)-1 {
+			return len(topicSegments) > i
+		}
+		if i >= len(topicSegments) {
+			return false
+		}
+		if segment != topicWildcardSingle && segment != topicSegments[i] {
+			return false
+		}
+	}
+	return len(patternSegments) == len(topicSegments)
+}
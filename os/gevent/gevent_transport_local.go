@@ -0,0 +1,53 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent
+
+import (
+	"context"
+	"sync"
+)
+
+// TransportLocal is the in-process Transport, which delivers the event to the bus in publishing.
+// The errors of synchronous handlers are returned to the publisher.
+type TransportLocal struct {
+	mu      sync.RWMutex
+	deliver DeliverFunc // DeliverFunc of the started bus.
+}
+
+var _ Transport = (*TransportLocal)(nil)
+
+// NewTransportLocal creates and returns an in-process Transport.
+func NewTransportLocal() *TransportLocal {
+	return &TransportLocal{}
+}
+
+// Publish delivers the event to the bus, it does nothing if the bus is not started.
+func (t *TransportLocal) Publish(ctx context.Context, event *Event) error {
+	t.mu.RLock()
+	deliver := t.deliver
+	t.mu.RUnlock()
+	if deliver == nil {
+		return nil
+	}
+	return deliver(ctx, event)
+}
+
+// Start starts receiving events, which are passed to `deliver`.
+func (t *TransportLocal) Start(ctx context.Context, deliver DeliverFunc) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deliver = deliver
+	return nil
+}
+
+// Close stops receiving events.
+func (t *TransportLocal) Close(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deliver = nil
+	return nil
+}
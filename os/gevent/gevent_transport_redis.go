@@ -0,0 +1,145 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/glog"
+)
+
+// TransportRedis is the Transport using Redis PubSub, which publishes the event to channel "<prefix><topic>".
+// It delivers events at most once: the events published while the bus is not connected are lost,
+// and the errors of handlers are only logged.
+type TransportRedis struct {
+	redis  *gredis.Redis
+	prefix string
+	mu     sync.Mutex
+	conn   gredis.Conn // Connection subscribing the channels.
+	cancel context.CancelFunc
+	logger glog.ILogger // Logger for receiving errors, which is the logger of bus.
+}
+
+var (
+	_ Transport        = (*TransportRedis)(nil)
+	_ iTransportLogger = (*TransportRedis)(nil)
+)
+
+const (
+	defaultTransportRedisPrefix = "gevent:"
+
+	// transportRedisRetryInterval is the interval before re-subscribing after connection failure.
+	transportRedisRetryInterval = time.Second
+)
+
+// NewTransportRedis creates and returns a Redis PubSub Transport.
+// The optional parameter `prefix` specifies the channel prefix, which is "gevent:" in default.
+func NewTransportRedis(redis *gredis.Redis, prefix ...string) *TransportRedis {
+	t := &TransportRedis{
+		redis:  redis,
+		prefix: defaultTransportRedisPrefix,
+		logger: glog.DefaultLogger(),
+	}
+	if len(prefix) > 0 && prefix[0] != "" {
+		t.prefix = prefix[0]
+	}
+	return t
+}
+
+// Publish sends the event to the channel of its topic.
+func (t *TransportRedis) Publish(ctx context.Context, event *Event) error {
+	content, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = t.redis.Publish(ctx, t.prefix+event.Topic, string(content))
+	return err
+}
+
+// Start subscribes the channels of all topics, and receives events in goroutine.
+func (t *TransportRedis) Start(ctx context.Context, deliver DeliverFunc) error {
+	conn, _, err := t.redis.PSubscribe(ctx, t.prefix+"*")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	t.mu.Lock()
+	t.conn, t.cancel = conn, cancel
+	t.mu.Unlock()
+	go t.receive(ctx, conn, deliver)
+	return nil
+}
+
+// Close stops receiving events and closes the subscribing connection.
+func (t *TransportRedis) Close(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel == nil {
+		return nil
+	}
+	t.cancel()
+	t.cancel = nil
+	return t.conn.Close(ctx)
+}
+
+// receive receives the messages from `conn` until `ctx` is done, it re-subscribes after connection failure.
+func (t *TransportRedis) receive(ctx context.Context, conn gredis.Conn, deliver DeliverFunc) {
+	for ctx.Err() == nil {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			t.logger.Errorf(ctx, `receive event from redis failed: %+v`, err)
+			time.Sleep(transportRedisRetryInterval)
+			if conn, err = t.resubscribe(ctx); err != nil {
+				t.logger.Errorf(ctx, `subscribe events from redis failed: %+v`, err)
+			}
+			continue
+		}
+		if !strings.HasPrefix(msg.Channel, t.prefix) {
+			continue
+		}
+		var event *Event
+		if err = json.UnmarshalUseNumber([]byte(msg.Payload), &event); err != nil {
+			t.logger.Errorf(ctx, `decode event from redis channel "%s" failed: %+v`, msg.Channel, err)
+			continue
+		}
+		if err = deliver(ctx, event); err != nil {
+			t.logger.Errorf(ctx, `deliver event "%s" failed: %+v`, event.ID, err)
+		}
+	}
+}
+
+// resubscribe replaces the subscribing connection with a new one.
+func (t *TransportRedis) resubscribe(ctx context.Context) (gredis.Conn, error) {
+	conn, _, err := t.redis.PSubscribe(ctx, t.prefix+"*")
+	if err != nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return t.conn, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ctx.Err() != nil {
+		_ = conn.Close(ctx)
+		return t.conn, ctx.Err()
+	}
+	_ = t.conn.Close(ctx)
+	t.conn = conn
+	return conn, nil
+}
+
+// setLogger sets the logger for receiving errors.
+func (t *TransportRedis) setLogger(logger glog.ILogger) {
+	t.logger = logger
+}
@@ -0,0 +1,373 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// TransportRedisStream is the Transport using Redis Streams, which delivers events at least once.
+//
+// All events are appended to a stream, and read by the buses through a consumer group. The event is
+// acknowledged only if all synchronous handlers succeed, or else it is delivered again after ClaimIdle.
+// The buses of the same consumer group share the events, use different groups to deliver each event
+// to the buses of all groups, eg a group for each service.
+//
+// The event failing MaxDeliveries deliveries is moved to the dead-letter stream and acknowledged,
+// so that it does not block the group forever.
+type TransportRedisStream struct {
+	redis  *gredis.Redis
+	option RedisStreamOption
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{} // Closed when the receiving goroutine exits.
+	logger glog.ILogger  // Logger for receiving errors, which is the logger of bus.
+}
+
+// RedisStreamOption is the option for Redis Streams Transport.
+type RedisStreamOption struct {
+	Stream    string        // Stream key, it is "gevent:stream" in default.
+	Group     string        // Consumer group name, it is "gevent" in default.
+	Consumer  string        // Consumer name in group, it is a unique id in default.
+	MaxLen    int64         // Approximate max length of stream, it is 100000 in default.
+	Batch     int64         // Max events read at once, it is 10 in default.
+	Block     time.Duration // Max duration of blocking reading, it is 1 second in default.
+	ClaimIdle time.Duration // Idle duration after which the unacknowledged event is delivered again, it is 30 seconds in default.
+
+	// MaxDeliveries is the max deliveries of an event, it is 10 in default.
+	// The event is moved to the dead-letter stream if it is not acknowledged after MaxDeliveries deliveries.
+	MaxDeliveries int64
+
+	// DeadLetterStream is the stream key of dead-letter events, it is "<Stream>:dead" in default.
+	// The dead-letter entry keeps the event in field "event", with the original entry id in field "id"
+	// and its deliveries in field "deliveries".
+	DeadLetterStream string
+}
+
+// streamMessage is the message read from stream.
+type streamMessage struct {
+	ID     string
+	Fields map[string]string
+}
+
+var (
+	_ Transport        = (*TransportRedisStream)(nil)
+	_ iTransportLogger = (*TransportRedisStream)(nil)
+)
+
+const (
+	defaultRedisStreamKey           = "gevent:stream"
+	defaultRedisStreamGroup         = "gevent"
+	defaultRedisStreamMaxLen        = 100000
+	defaultRedisStreamBatch         = 10
+	defaultRedisStreamBlock         = time.Second
+	defaultRedisStreamClaimIdle     = 30 * time.Second
+	defaultRedisStreamMaxDeliveries = 10
+	defaultRedisStreamDeadSuffix    = ":dead"
+	redisStreamFieldEvent           = "event"
+	redisStreamFieldID              = "id"
+	redisStreamFieldDeliveries      = "deliveries"
+)
+
+// NewTransportRedisStream creates and returns a Redis Streams Transport.
+func NewTransportRedisStream(redis *gredis.Redis, option ...RedisStreamOption) *TransportRedisStream {
+	var opt RedisStreamOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Stream == "" {
+		opt.Stream = defaultRedisStreamKey
+	}
+	if opt.Group == "" {
+		opt.Group = defaultRedisStreamGroup
+	}
+	if opt.Consumer == "" {
+		opt.Consumer = guid.S()
+	}
+	if opt.MaxLen <= 0 {
+		opt.MaxLen = defaultRedisStreamMaxLen
+	}
+	if opt.Batch <= 0 {
+		opt.Batch = defaultRedisStreamBatch
+	}
+	if opt.Block <= 0 {
+		opt.Block = defaultRedisStreamBlock
+	}
+	if opt.ClaimIdle <= 0 {
+		opt.ClaimIdle = defaultRedisStreamClaimIdle
+	}
+	if opt.MaxDeliveries <= 0 {
+		opt.MaxDeliveries = defaultRedisStreamMaxDeliveries
+	}
+	if opt.DeadLetterStream == "" {
+		opt.DeadLetterStream = opt.Stream + defaultRedisStreamDeadSuffix
+	}
+	return &TransportRedisStream{
+		redis:  redis,
+		option: opt,
+		logger: glog.DefaultLogger(),
+	}
+}
+
+// Publish appends the event to the stream.
+func (t *TransportRedisStream) Publish(ctx context.Context, event *Event) error {
+	content, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = t.redis.Do(
+		ctx, "XADD", t.option.Stream, "MAXLEN", "~", t.option.MaxLen, "*",
+		redisStreamFieldEvent, string(content),
+	)
+	return err
+}
+
+// Start creates the consumer group if it does not exist, and receives events in goroutine.
+func (t *TransportRedisStream) Start(ctx context.Context, deliver DeliverFunc) error {
+	_, err := t.redis.Do(ctx, "XGROUP", "CREATE", t.option.Stream, t.option.Group, "$", "MKSTREAM")
+	if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	t.mu.Lock()
+	t.cancel, t.done = cancel, done
+	t.mu.Unlock()
+	go func() {
+		defer close(done)
+		t.receive(ctx, deliver)
+	}()
+	return nil
+}
+
+// Close stops receiving events, it waits for the receiving goroutine exits until `ctx` is done.
+func (t *TransportRedisStream) Close(ctx context.Context) error {
+	t.mu.Lock()
+	cancel, done := t.cancel, t.done
+	t.cancel, t.done = nil, nil
+	t.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// receive claims the idle events and reads the new events until `ctx` is done.
+func (t *TransportRedisStream) receive(ctx context.Context, deliver DeliverFunc) {
+	var lastClaim time.Time
+	for ctx.Err() == nil {
+		if time.Since(lastClaim) >= t.option.ClaimIdle/2 {
+			lastClaim = time.Now()
+			messages, err := t.claim(ctx)
+			if err != nil && ctx.Err() == nil {
+				t.logger.Errorf(ctx, `claim events from redis stream failed: %+v`, err)
+			}
+			deliveries, err := t.deliveries(ctx, messages)
+			if err != nil && ctx.Err() == nil {
+				t.logger.Errorf(ctx, `query deliveries of events from redis stream failed: %+v`, err)
+			}
+			t.handle(ctx, messages, deliveries, deliver)
+		}
+		messages, err := t.read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			t.logger.Errorf(ctx, `read events from redis stream failed: %+v`, err)
+			time.Sleep(t.option.Block)
+			continue
+		}
+		// The new events are delivered the first time.
+		t.handle(ctx, messages, nil, deliver)
+	}
+}
+
+// read reads the new events of group.
+func (t *TransportRedisStream) read(ctx context.Context) ([]streamMessage, error) {
+	v, err := t.redis.Do(
+		ctx, "XREADGROUP", "GROUP", t.option.Group, t.option.Consumer,
+		"COUNT", t.option.Batch, "BLOCK", t.option.Block.Milliseconds(),
+		"STREAMS", t.option.Stream, ">",
+	)
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	// The reply is a list of [stream, messages] in RESP2, or a map of stream to messages in RESP3.
+	var messages []streamMessage
+	switch value := v.Val().(type) {
+	case map[any]any:
+		for _, item := range value {
+			messages = append(messages, parseStreamMessages(item)...)
+		}
+	case map[string]any:
+		for _, item := range value {
+			messages = append(messages, parseStreamMessages(item)...)
+		}
+	default:
+		for _, item := range gconv.Interfaces(value) {
+			if pair := gconv.Interfaces(item); len(pair) == 2 {
+				messages = append(messages, parseStreamMessages(pair[1])...)
+			}
+		}
+	}
+	return messages, nil
+}
+
+// claim takes the events of group that are not acknowledged in ClaimIdle.
+func (t *TransportRedisStream) claim(ctx context.Context) ([]streamMessage, error) {
+	v, err := t.redis.Do(
+		ctx, "XAUTOCLAIM", t.option.Stream, t.option.Group, t.option.Consumer,
+		t.option.ClaimIdle.Milliseconds(), "0-0", "COUNT", t.option.Batch,
+	)
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	// The reply is [next cursor, messages, deleted ids].
+	if reply := v.Interfaces(); len(reply) >= 2 {
+		return parseStreamMessages(reply[1]), nil
+	}
+	return nil, nil
+}
+
+// deliveries queries the delivery counts of claimed `messages` from the pending entries of group,
+// which include the current delivery.
+func (t *TransportRedisStream) deliveries(ctx context.Context, messages []streamMessage) (map[string]int64, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	// The claimed messages are in order of id.
+	v, err := t.redis.Do(
+		ctx, "XPENDING", t.option.Stream, t.option.Group,
+		messages[0].ID, messages[len(messages)-1].ID, len(messages), t.option.Consumer,
+	)
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	return parseStreamPending(v.Val()), nil
+}
+
+// handle delivers the events of `messages`, and acknowledges the delivered ones.
+// The parameter `deliveries` is the delivery counts of messages, the message absent is delivered the first time.
+func (t *TransportRedisStream) handle(
+	ctx context.Context, messages []streamMessage, deliveries map[string]int64, deliver DeliverFunc,
+) {
+	for _, message := range messages {
+		content, ok := message.Fields[redisStreamFieldEvent]
+		if !ok {
+			// Deleted or invalid message, which should not be delivered again.
+			t.ack(ctx, message.ID)
+			continue
+		}
+		if count := deliveries[message.ID]; count > t.option.MaxDeliveries {
+			t.deadLetter(ctx, message.ID, content, count-1)
+			continue
+		}
+		var event *Event
+		if err := json.UnmarshalUseNumber([]byte(content), &event); err != nil {
+			t.logger.Errorf(ctx, `decode event "%s" from redis stream failed: %+v`, message.ID, err)
+			t.ack(ctx, message.ID)
+			continue
+		}
+		if err := deliver(ctx, event); err != nil {
+			t.logger.Errorf(ctx, `deliver event "%s" failed, it will be delivered again: %+v`, event.ID, err)
+			continue
+		}
+		t.ack(ctx, message.ID)
+	}
+}
+
+// deadLetter moves the event of message `id` to the dead-letter stream, and acknowledges it in group.
+// The parameter `deliveries` is the failed deliveries of the event.
+func (t *TransportRedisStream) deadLetter(ctx context.Context, id, content string, deliveries int64) {
+	_, err := t.redis.Do(
+		ctx, "XADD", t.option.DeadLetterStream, "MAXLEN", "~", t.option.MaxLen, "*",
+		redisStreamFieldEvent, content,
+		redisStreamFieldID, id,
+		redisStreamFieldDeliveries, deliveries,
+	)
+	if err != nil {
+		// It is moved in the next claim.
+		t.logger.Errorf(ctx, `move event "%s" to dead-letter stream failed: %+v`, id, err)
+		return
+	}
+	t.logger.Warningf(
+		ctx, `event "%s" of redis stream is moved to dead-letter stream "%s" after %d deliveries`,
+		id, t.option.DeadLetterStream, deliveries,
+	)
+	t.ack(ctx, id)
+}
+
+// ack acknowledges the message of `id` in group.
+func (t *TransportRedisStream) ack(ctx context.Context, id string) {
+	if _, err := t.redis.Do(ctx, "XACK", t.option.Stream, t.option.Group, id); err != nil {
+		t.logger.Errorf(ctx, `acknowledge event "%s" of redis stream failed: %+v`, id, err)
+	}
+}
+
+// setLogger sets the logger for receiving errors.
+func (t *TransportRedisStream) setLogger(logger glog.ILogger) {
+	t.logger = logger
+}
+
+// parseStreamPending parses the extended XPENDING reply, which is a list of [id, consumer, idle, deliveries],
+// and returns the deliveries of the entries.
+func parseStreamPending(value any) map[string]int64 {
+	var deliveries = make(map[string]int64)
+	for _, item := range gconv.Interfaces(value) {
+		if entry := gconv.Interfaces(item); len(entry) == 4 {
+			deliveries[gconv.String(entry[0])] = gconv.Int64(entry[3])
+		}
+	}
+	return deliveries
+}
+
+// parseStreamMessages parses the messages reply of stream, which is a list of [id, [field, value, ...]].
+func parseStreamMessages(value any) []streamMessage {
+	var messages []streamMessage
+	for _, item := range gconv.Interfaces(value) {
+		pair := gconv.Interfaces(item)
+		if len(pair) != 2 {
+			continue
+		}
+		message := streamMessage{
+			ID:     gconv.String(pair[0]),
+			Fields: make(map[string]string),
+		}
+		switch fields := pair[1].(type) {
+		case map[any]any:
+			for k, v := range fields {
+				message.Fields[gconv.String(k)] = gconv.String(v)
+			}
+		case map[string]any:
+			for k, v := range fields {
+				message.Fields[k] = gconv.String(v)
+			}
+		default:
+			values := gconv.Interfaces(fields)
+			for i := 0; i+1 < len(values); i += 2 {
+				message.Fields[gconv.String(values[i])] = gconv.String(values[i+1])
+			}
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_TransportRedisStream_Option(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		transport := NewTransportRedisStream(nil, RedisStreamOption{Stream: "orders"})
+		t.Assert(transport.option.MaxDeliveries, defaultRedisStreamMaxDeliveries)
+		t.Assert(transport.option.DeadLetterStream, "orders:dead")
+		t.Assert(transport.logger, glog.DefaultLogger())
+	})
+}
+
+func Test_ParseStreamPending(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		deliveries := parseStreamPending([]any{
+			[]any{"1-0", "consumer", int64(31000), int64(3)},
+			[]any{"2-0", "consumer", int64(30500), int64(11)},
+			[]any{"invalid"},
+		})
+		t.Assert(len(deliveries), 2)
+		t.Assert(deliveries["1-0"], 3)
+		t.Assert(deliveries["2-0"], 11)
+		t.Assert(len(parseStreamPending(nil)), 0)
+	})
+}
+
+// testLoggerTransport is the local transport recording the logger set by bus.
+type testLoggerTransport struct {
+	*TransportLocal
+	logger glog.ILogger
+}
+
+func (t *testLoggerTransport) setLogger(logger glog.ILogger) {
+	t.logger = logger
+}
+
+func Test_Bus_TransportLogger(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			logger    = glog.New()
+			transport = &testLoggerTransport{TransportLocal: NewTransportLocal()}
+			bus       = New(Option{Transport: transport, Logger: logger})
+		)
+		t.AssertNil(bus.Start(context.Background()))
+		defer bus.Close(context.Background())
+		t.Assert(transport.logger, logger)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			transport = &testLoggerTransport{TransportLocal: NewTransportLocal()}
+			bus       = New(Option{Transport: transport})
+		)
+		t.AssertNil(bus.Start(context.Background()))
+		defer bus.Close(context.Background())
+		t.Assert(transport.logger, glog.DefaultLogger())
+	})
+}
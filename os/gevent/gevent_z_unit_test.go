@@ -0,0 +1,169 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gevent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gevent"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	ctx = gctx.New()
+)
+
+type orderCreated struct {
+	OrderId int
+	Amount  float64
+}
+
+func Test_MatchTopic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gevent.MatchTopic("order.created", "order.created"), true)
+		t.Assert(gevent.MatchTopic("order.created", "order.paid"), false)
+		t.Assert(gevent.MatchTopic("order.*", "order.created"), true)
+		t.Assert(gevent.MatchTopic("order.*", "order.item.created"), false)
+		t.Assert(gevent.MatchTopic("order.*", "order"), false)
+		t.Assert(gevent.MatchTopic("*.created", "user.created"), true)
+		t.Assert(gevent.MatchTopic("order.>", "order.created"), true)
+		t.Assert(gevent.MatchTopic("order.>", "order.item.created"), true)
+		t.Assert(gevent.MatchTopic("order.>", "order"), false)
+		t.Assert(gevent.MatchTopic(">", "order.created"), true)
+		t.Assert(gevent.MatchTopic("order.>.created", "order.item.created"), false)
+	})
+}
+
+func Test_Bus_Sync(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			bus   = gevent.New()
+			array = garray.NewStrArray(true)
+		)
+		t.AssertNil(bus.Start(ctx))
+		defer bus.Close(ctx)
+
+		bus.Subscribe("order.*", func(ctx context.Context, event *gevent.Event) error {
+			array.Append("wildcard:" + event.Topic)
+			return nil
+		})
+		gevent.SubscribeT(bus, "order.created", func(ctx context.Context, payload orderCreated) error {
+			t.Assert(payload.OrderId, 1)
+			t.Assert(payload.Amount, 9.9)
+			array.Append("typed")
+			return nil
+		})
+		t.AssertNil(bus.Publish(ctx, "order.created", orderCreated{OrderId: 1, Amount: 9.9}))
+		t.Assert(array.Slice(), []string{"wildcard:order.created", "typed"})
+
+		t.AssertNil(bus.Publish(ctx, "user.created", nil))
+		t.Assert(array.Len(), 2)
+
+		err := bus.Publish(ctx, "", nil)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+	})
+}
+
+func Test_Bus_Error_Unsubscribe(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		bus := gevent.New()
+		t.AssertNil(bus.Start(ctx))
+		defer bus.Close(ctx)
+
+		subscription := bus.Subscribe("order.>", func(ctx context.Context, event *gevent.Event) error {
+			return errors.New("failed")
+		})
+		t.Assert(subscription.Pattern(), "order.>")
+		err := bus.Publish(ctx, "order.created", nil)
+		t.AssertNE(err, nil)
+
+		subscription.Unsubscribe()
+		t.AssertNil(bus.Publish(ctx, "order.created", nil))
+	})
+}
+
+func Test_Bus_Async(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			bus   = gevent.New()
+			array = garray.NewStrArray(true)
+		)
+		t.AssertNil(bus.Start(ctx))
+		defer bus.Close(ctx)
+
+		bus.Subscribe("order.created", func(ctx context.Context, event *gevent.Event) error {
+			time.Sleep(100 * time.Millisecond)
+			array.Append("async")
+			return errors.New("ignored")
+		}, gevent.SubscribeOption{Async: true})
+		t.AssertNil(bus.Publish(ctx, "order.created", nil))
+		t.Assert(array.Len(), 0)
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(array.Slice(), []string{"async"})
+	})
+}
+
+func Test_Bus_Middleware(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			bus   = gevent.New()
+			array = garray.NewStrArray(true)
+		)
+		t.AssertNil(bus.Start(ctx))
+		defer bus.Close(ctx)
+
+		bus.Use(
+			func(next gevent.HandlerFunc) gevent.HandlerFunc {
+				return func(ctx context.Context, event *gevent.Event) error {
+					array.Append("before")
+					err := next(ctx, event)
+					array.Append("after")
+					return err
+				}
+			},
+			gevent.MiddlewareTracing,
+			gevent.MiddlewareRecover,
+		)
+		bus.Subscribe("order.created", func(ctx context.Context, event *gevent.Event) error {
+			array.Append("handler")
+			panic("boom")
+		})
+		err := bus.Publish(ctx, "order.created", nil)
+		t.Assert(gerror.Code(err), gcode.CodeInternalPanic)
+		t.Assert(array.Slice(), []string{"before", "handler", "after"})
+	})
+}
+
+func Test_Bus_NotStarted(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			bus   = gevent.New()
+			array = garray.NewStrArray(true)
+		)
+		bus.Subscribe(">", func(ctx context.Context, event *gevent.Event) error {
+			array.Append(event.Topic)
+			return nil
+		})
+		t.AssertNil(bus.Publish(ctx, "order.created", nil))
+		t.Assert(array.Len(), 0)
+
+		t.AssertNil(bus.Start(ctx))
+		t.AssertNil(bus.Publish(ctx, "order.created", nil))
+		t.Assert(array.Len(), 1)
+
+		t.AssertNil(bus.Close(ctx))
+		t.AssertNil(bus.Publish(ctx, "order.created", nil))
+		t.Assert(array.Len(), 1)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gjob implements a persistent background job queue, which supports delayed jobs, unique jobs,
+// priority, retries with exponential backoff and dead-letter, with pluggable storage backends.
+package gjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Job is a background job in queue.
+type Job struct {
+	ID          string    `json:"id"`                  // Unique id of the job.
+	Type        string    `json:"type"`                // Job type name, which is used to find the handler.
+	Payload     string    `json:"payload"`             // Payload of the job in JSON.
+	Priority    int       `json:"priority"`            // Jobs of higher priority run first if they are all due.
+	UniqueKey   string    `json:"uniqueKey,omitempty"` // Only one pending or running job for the same unique key.
+	Attempts    int       `json:"attempts"`            // Attempts that have been made.
+	MaxAttempts int       `json:"maxAttempts"`         // Max attempts, the job is moved to dead-letter after that.
+	RunAt       time.Time `json:"runAt"`               // Time at which the job becomes due.
+	EnqueuedAt  time.Time `json:"enqueuedAt"`          // Time at which the job is enqueued.
+	LastError   string    `json:"lastError,omitempty"` // Error of the last failed attempt.
+}
+
+// Scan decodes the payload of job to `pointer` using gconv.
+func (j *Job) Scan(pointer any) error {
+	var value any
+	if err := json.UnmarshalUseNumber([]byte(j.Payload), &value); err != nil {
+		return err
+	}
+	return gconv.Scan(value, pointer)
+}
+
+// String implements the fmt.Stringer interface for Job.
+func (j *Job) String() string {
+	return fmt.Sprintf(`%s(%s)`, j.Type, j.ID)
+}
+
+// Backend is the storage backend of job queue.
+//
+// A job popped from backend is leased for a duration, it should be acknowledged, retried or moved to
+// dead-letter before the lease expires, or else it is treated as pending and might be popped again.
+type Backend interface {
+	// Push stores a new job.
+	// It returns false if there's already a pending or running job with the same unique key.
+	Push(ctx context.Context, job *Job) (bool, error)
+
+	// Pop takes a due job and leases it until `leaseUntil`, the due job of the highest priority is taken first.
+	// It increases the Attempts of job and stores it along with the lease, so that the attempt is counted
+	// even if the worker crashes while running the job. It returns nil if there's no due job.
+	Pop(ctx context.Context, now time.Time, leaseUntil time.Time) (*Job, error)
+
+	// Ack removes the finished job.
+	Ack(ctx context.Context, job *Job) error
+
+	// Retry releases the failed job, which becomes due again at its RunAt.
+	Retry(ctx context.Context, job *Job) error
+
+	// Dead moves the job to dead-letter.
+	Dead(ctx context.Context, job *Job) error
+
+	// DeadJobs returns at most `limit` latest jobs in dead-letter.
+	DeadJobs(ctx context.Context, limit int) ([]*Job, error)
+}
+
+// HandlerFunc is the handler function for jobs of a type.
+// The job is retried if the handler returns error or panics.
+type HandlerFunc func(ctx context.Context, job *Job) error
+
+// EnqueueOption is the option for enqueuing job.
+type EnqueueOption struct {
+	Delay       time.Duration // Delay from now before the job becomes due.
+	RunAt       time.Time     // Time at which the job becomes due, which takes precedence over Delay.
+	Priority    int           // Jobs of higher priority run first if they are all due.
+	MaxAttempts int           // Max attempts of the job, it uses the MaxAttempts of queue if it is not positive.
+	UniqueKey   string        // The job is not enqueued if there's pending or running job with the same unique key.
+}
@@ -0,0 +1,224 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// BackendDB is the Backend implements using database table, which should be created in advance, eg in MySQL:
+//
+//	CREATE TABLE `gjob` (
+//	    `id`           varchar(64)  NOT NULL COMMENT 'Job id',
+//	    `type`         varchar(255) NOT NULL COMMENT 'Job type',
+//	    `payload`      longtext     NOT NULL COMMENT 'Job payload in JSON',
+//	    `priority`     int          NOT NULL DEFAULT 0 COMMENT 'Job priority',
+//	    `unique_key`   varchar(255) DEFAULT NULL COMMENT 'Unique key, it is NULL for non-unique job',
+//	    `attempts`     int          NOT NULL DEFAULT 0 COMMENT 'Attempts that have been made',
+//	    `max_attempts` int          NOT NULL DEFAULT 0 COMMENT 'Max attempts',
+//	    `status`       varchar(16)  NOT NULL COMMENT 'Status: pending, running or dead',
+//	    `run_at`       bigint       NOT NULL COMMENT 'Due timestamp in milliseconds',
+//	    `lease_until`  bigint       NOT NULL DEFAULT 0 COMMENT 'Lease timestamp in milliseconds',
+//	    `last_error`   text         COMMENT 'Error of the last failed attempt',
+//	    `enqueued_at`  bigint       NOT NULL COMMENT 'Enqueued timestamp in milliseconds',
+//	    PRIMARY KEY (`id`),
+//	    UNIQUE KEY `unique_key` (`unique_key`),
+//	    KEY `status_run_at` (`status`, `run_at`)
+//	);
+type BackendDB struct {
+	db    gdb.DB
+	table string
+}
+
+// backendDBJob is the job record in table.
+type backendDBJob struct {
+	Id          string
+	Type        string
+	Payload     string
+	Priority    int
+	UniqueKey   *string
+	Attempts    int
+	MaxAttempts int
+	Status      string
+	RunAt       int64
+	LeaseUntil  int64
+	LastError   string
+	EnqueuedAt  int64
+}
+
+var _ Backend = (*BackendDB)(nil)
+
+const (
+	defaultBackendDBTable = "gjob"
+
+	backendDBStatusPending = "pending"
+	backendDBStatusRunning = "running"
+	backendDBStatusDead    = "dead"
+
+	// backendDBPopRetries is the max tries for popping job, as the job might be taken by other workers.
+	backendDBPopRetries = 3
+)
+
+// NewBackendDB creates and returns a database backend.
+// The optional parameter `table` specifies the job table name, which is "gjob" in default.
+func NewBackendDB(db gdb.DB, table ...string) *BackendDB {
+	b := &BackendDB{
+		db:    db,
+		table: defaultBackendDBTable,
+	}
+	if len(table) > 0 && table[0] != "" {
+		b.table = table[0]
+	}
+	return b
+}
+
+// Push stores a new job.
+func (b *BackendDB) Push(ctx context.Context, job *Job) (bool, error) {
+	data := map[string]any{
+		"id":           job.ID,
+		"type":         job.Type,
+		"payload":      job.Payload,
+		"priority":     job.Priority,
+		"unique_key":   nil,
+		"attempts":     job.Attempts,
+		"max_attempts": job.MaxAttempts,
+		"status":       backendDBStatusPending,
+		"run_at":       job.RunAt.UnixMilli(),
+		"lease_until":  0,
+		"last_error":   job.LastError,
+		"enqueued_at":  job.EnqueuedAt.UnixMilli(),
+	}
+	if job.UniqueKey != "" {
+		data["unique_key"] = job.UniqueKey
+	}
+	result, err := b.db.Model(b.table).Ctx(ctx).InsertIgnore(data)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Pop takes a due job and leases it until `leaseUntil`.
+//
+// The job is leased and its attempts are increased by an update conditioned on its previous status and lease,
+// so that a job is taken by only one worker among concurrent pops.
+func (b *BackendDB) Pop(ctx context.Context, now time.Time, leaseUntil time.Time) (*Job, error) {
+	nowMilli := now.UnixMilli()
+	for i := 0; i < backendDBPopRetries; i++ {
+		var record *backendDBJob
+		err := b.db.Model(b.table).Ctx(ctx).
+			Where(
+				b.db.Model(b.table).Builder().
+					Where("status", backendDBStatusPending).WhereLTE("run_at", nowMilli).
+					WhereOr(
+						b.db.Model(b.table).Builder().
+							Where("status", backendDBStatusRunning).WhereLT("lease_until", nowMilli),
+					),
+			).
+			OrderDesc("priority").
+			OrderAsc("run_at").
+			Limit(1).
+			Scan(&record)
+		if err != nil || record == nil {
+			return nil, err
+		}
+		result, err := b.db.Model(b.table).Ctx(ctx).
+			Data(map[string]any{
+				"status":      backendDBStatusRunning,
+				"attempts":    record.Attempts + 1,
+				"lease_until": leaseUntil.UnixMilli(),
+			}).
+			Where("id", record.Id).
+			Where("status", record.Status).
+			Where("lease_until", record.LeaseUntil).
+			Update()
+		if err != nil {
+			return nil, err
+		}
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			record.Attempts++
+			return record.toJob(), nil
+		}
+	}
+	return nil, nil
+}
+
+// Ack removes the finished job.
+func (b *BackendDB) Ack(ctx context.Context, job *Job) error {
+	_, err := b.db.Model(b.table).Ctx(ctx).Where("id", job.ID).Delete()
+	return err
+}
+
+// Retry releases the failed job, which becomes due again at its RunAt.
+func (b *BackendDB) Retry(ctx context.Context, job *Job) error {
+	_, err := b.db.Model(b.table).Ctx(ctx).
+		Data(map[string]any{
+			"status":      backendDBStatusPending,
+			"attempts":    job.Attempts,
+			"run_at":      job.RunAt.UnixMilli(),
+			"lease_until": 0,
+			"last_error":  job.LastError,
+		}).
+		Where("id", job.ID).
+		Update()
+	return err
+}
+
+// Dead moves the job to dead-letter, which releases the unique key of job.
+func (b *BackendDB) Dead(ctx context.Context, job *Job) error {
+	_, err := b.db.Model(b.table).Ctx(ctx).
+		Data(map[string]any{
+			"status":      backendDBStatusDead,
+			"unique_key":  nil,
+			"attempts":    job.Attempts,
+			"lease_until": 0,
+			"last_error":  job.LastError,
+		}).
+		Where("id", job.ID).
+		Update()
+	return err
+}
+
+// DeadJobs returns at most `limit` latest jobs in dead-letter.
+func (b *BackendDB) DeadJobs(ctx context.Context, limit int) ([]*Job, error) {
+	var records []*backendDBJob
+	model := b.db.Model(b.table).Ctx(ctx).Where("status", backendDBStatusDead).OrderDesc("enqueued_at")
+	if limit > 0 {
+		model = model.Limit(limit)
+	}
+	if err := model.Scan(&records); err != nil {
+		return nil, err
+	}
+	jobs := make([]*Job, 0, len(records))
+	for _, record := range records {
+		jobs = append(jobs, record.toJob())
+	}
+	return jobs, nil
+}
+
+// toJob converts the record to Job.
+func (r *backendDBJob) toJob() *Job {
+	job := &Job{
+		ID:          r.Id,
+		Type:        r.Type,
+		Payload:     r.Payload,
+		Priority:    r.Priority,
+		Attempts:    r.Attempts,
+		MaxAttempts: r.MaxAttempts,
+		RunAt:       time.UnixMilli(r.RunAt),
+		EnqueuedAt:  time.UnixMilli(r.EnqueuedAt),
+		LastError:   r.LastError,
+	}
+	if r.UniqueKey != nil {
+		job.UniqueKey = *r.UniqueKey
+	}
+	return job
+}
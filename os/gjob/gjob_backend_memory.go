@@ -0,0 +1,134 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BackendMemory is the Backend implements in memory, which is for testing or non-persistent usage.
+type BackendMemory struct {
+	mu     sync.Mutex
+	jobs   map[string]*memoryJob // Pending and running jobs.
+	unique map[string]string     // Unique key to job id.
+	dead   []*Job                // Jobs in dead-letter.
+}
+
+// memoryJob is the job in memory with its lease.
+type memoryJob struct {
+	job        *Job
+	leaseUntil time.Time // It is zero if the job is pending.
+}
+
+var _ Backend = (*BackendMemory)(nil)
+
+// NewBackendMemory creates and returns a memory backend.
+func NewBackendMemory() *BackendMemory {
+	return &BackendMemory{
+		jobs:   make(map[string]*memoryJob),
+		unique: make(map[string]string),
+	}
+}
+
+// Push stores a new job.
+func (b *BackendMemory) Push(ctx context.Context, job *Job) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if job.UniqueKey != "" {
+		if _, ok := b.unique[job.UniqueKey]; ok {
+			return false, nil
+		}
+		b.unique[job.UniqueKey] = job.ID
+	}
+	copied := *job
+	b.jobs[job.ID] = &memoryJob{job: &copied}
+	return true, nil
+}
+
+// Pop takes a due job and leases it until `leaseUntil`.
+func (b *BackendMemory) Pop(ctx context.Context, now time.Time, leaseUntil time.Time) (*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var found *memoryJob
+	for _, item := range b.jobs {
+		// Running job whose lease is not expired.
+		if item.leaseUntil.After(now) {
+			continue
+		}
+		// Pending job that is not due, note that the job with expired lease is due.
+		if item.leaseUntil.IsZero() && item.job.RunAt.After(now) {
+			continue
+		}
+		if found == nil ||
+			item.job.Priority > found.job.Priority ||
+			(item.job.Priority == found.job.Priority && item.job.RunAt.Before(found.job.RunAt)) {
+			found = item
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	found.leaseUntil = leaseUntil
+	found.job.Attempts++
+	copied := *found.job
+	return &copied, nil
+}
+
+// Ack removes the finished job.
+func (b *BackendMemory) Ack(ctx context.Context, job *Job) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(job)
+	return nil
+}
+
+// Retry releases the failed job, which becomes due again at its RunAt.
+func (b *BackendMemory) Retry(ctx context.Context, job *Job) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.jobs[job.ID]; !ok {
+		return nil
+	}
+	copied := *job
+	b.jobs[job.ID] = &memoryJob{job: &copied}
+	return nil
+}
+
+// Dead moves the job to dead-letter.
+func (b *BackendMemory) Dead(ctx context.Context, job *Job) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(job)
+	copied := *job
+	b.dead = append(b.dead, &copied)
+	return nil
+}
+
+// DeadJobs returns at most `limit` latest jobs in dead-letter.
+func (b *BackendMemory) DeadJobs(ctx context.Context, limit int) ([]*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if limit <= 0 || limit > len(b.dead) {
+		limit = len(b.dead)
+	}
+	jobs := make([]*Job, 0, limit)
+	for i := len(b.dead) - 1; i >= len(b.dead)-limit; i-- {
+		copied := *b.dead[i]
+		jobs = append(jobs, &copied)
+	}
+	return jobs, nil
+}
+
+// remove removes the job and its unique key, it should be called with lock.
+func (b *BackendMemory) remove(job *Job) {
+	delete(b.jobs, job.ID)
+	if job.UniqueKey != "" && b.unique[job.UniqueKey] == job.ID {
+		delete(b.unique, job.UniqueKey)
+	}
+}
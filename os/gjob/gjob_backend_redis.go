@@ -0,0 +1,205 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// BackendRedis is the Backend implements using Redis server, the jobs are stored in keys with the prefix:
+//
+//	<prefix>:jobs     Hash of job id to job in JSON.
+//	<prefix>:pending  Sorted set of pending job ids, scored by due timestamp in milliseconds.
+//	<prefix>:running  Sorted set of running job ids, scored by lease timestamp in milliseconds.
+//	<prefix>:unique   Hash of unique key to job id.
+//	<prefix>:dead     List of jobs in dead-letter in JSON, from the latest.
+type BackendRedis struct {
+	redis *gredis.Redis
+	keys  []string // Keys of jobs, pending, running, unique and dead in order.
+}
+
+var _ Backend = (*BackendRedis)(nil)
+
+const (
+	defaultBackendRedisPrefix = "gjob"
+
+	// backendRedisPushScript stores the job if its unique key is not held.
+	backendRedisPushScript = `
+if ARGV[3] ~= '' and redis.call('HSETNX', KEYS[4], ARGV[3], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[4], ARGV[1])
+return 1
+`
+	// backendRedisPopScript releases the jobs whose lease expires, and then leases the due job of the highest
+	// priority among the earliest due jobs, and increases its attempts.
+	backendRedisPopScript = `
+local expired = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', ARGV[1])
+for _, id in ipairs(expired) do
+	redis.call('ZREM', KEYS[3], id)
+	redis.call('ZADD', KEYS[2], ARGV[1], id)
+end
+local ids = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1], 'LIMIT', 0, 100)
+local foundId, foundJob, foundPriority
+for _, id in ipairs(ids) do
+	local job = redis.call('HGET', KEYS[1], id)
+	if job then
+		local priority = cjson.decode(job)['priority'] or 0
+		if not foundId or priority > foundPriority then
+			foundId, foundJob, foundPriority = id, job, priority
+		end
+	else
+		redis.call('ZREM', KEYS[2], id)
+	end
+end
+if not foundId then
+	return false
+end
+local decoded = cjson.decode(foundJob)
+decoded['attempts'] = (decoded['attempts'] or 0) + 1
+foundJob = cjson.encode(decoded)
+redis.call('HSET', KEYS[1], foundId, foundJob)
+redis.call('ZREM', KEYS[2], foundId)
+redis.call('ZADD', KEYS[3], ARGV[2], foundId)
+return foundJob
+`
+	// backendRedisRemoveScript removes the job and its unique key,
+	// and pushes the job to dead-letter if ARGV[3] is given.
+	backendRedisRemoveScript = `
+redis.call('HDEL', KEYS[1], ARGV[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('ZREM', KEYS[3], ARGV[1])
+if ARGV[2] ~= '' and redis.call('HGET', KEYS[4], ARGV[2]) == ARGV[1] then
+	redis.call('HDEL', KEYS[4], ARGV[2])
+end
+if ARGV[3] ~= '' then
+	redis.call('LPUSH', KEYS[5], ARGV[3])
+end
+return 1
+`
+	// backendRedisRetryScript updates the job and releases it if it still exists.
+	backendRedisRetryScript = `
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('ZREM', KEYS[3], ARGV[1])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+return 1
+`
+)
+
+// NewBackendRedis creates and returns a Redis backend.
+// The optional parameter `prefix` specifies the key prefix, which is "gjob" in default.
+func NewBackendRedis(redis *gredis.Redis, prefix ...string) *BackendRedis {
+	p := defaultBackendRedisPrefix
+	if len(prefix) > 0 && prefix[0] != "" {
+		p = prefix[0]
+	}
+	return &BackendRedis{
+		redis: redis,
+		keys: []string{
+			p + ":jobs",
+			p + ":pending",
+			p + ":running",
+			p + ":unique",
+			p + ":dead",
+		},
+	}
+}
+
+// Push stores a new job.
+func (b *BackendRedis) Push(ctx context.Context, job *Job) (bool, error) {
+	content, err := json.Marshal(job)
+	if err != nil {
+		return false, err
+	}
+	v, err := b.redis.Eval(
+		ctx, backendRedisPushScript, int64(len(b.keys)), b.keys,
+		[]any{job.ID, string(content), job.UniqueKey, job.RunAt.UnixMilli()},
+	)
+	if err != nil {
+		return false, err
+	}
+	return v.Int() == 1, nil
+}
+
+// Pop takes a due job and leases it until `leaseUntil`.
+func (b *BackendRedis) Pop(ctx context.Context, now time.Time, leaseUntil time.Time) (*Job, error) {
+	v, err := b.redis.Eval(
+		ctx, backendRedisPopScript, int64(len(b.keys)), b.keys,
+		[]any{now.UnixMilli(), leaseUntil.UnixMilli()},
+	)
+	if err != nil || v.IsEmpty() {
+		return nil, err
+	}
+	var job *Job
+	if err = json.UnmarshalUseNumber(v.Bytes(), &job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Ack removes the finished job.
+func (b *BackendRedis) Ack(ctx context.Context, job *Job) error {
+	_, err := b.redis.Eval(
+		ctx, backendRedisRemoveScript, int64(len(b.keys)), b.keys,
+		[]any{job.ID, job.UniqueKey, ""},
+	)
+	return err
+}
+
+// Retry releases the failed job, which becomes due again at its RunAt.
+func (b *BackendRedis) Retry(ctx context.Context, job *Job) error {
+	content, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	_, err = b.redis.Eval(
+		ctx, backendRedisRetryScript, int64(len(b.keys)), b.keys,
+		[]any{job.ID, string(content), job.RunAt.UnixMilli()},
+	)
+	return err
+}
+
+// Dead moves the job to dead-letter.
+func (b *BackendRedis) Dead(ctx context.Context, job *Job) error {
+	content, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	_, err = b.redis.Eval(
+		ctx, backendRedisRemoveScript, int64(len(b.keys)), b.keys,
+		[]any{job.ID, job.UniqueKey, string(content)},
+	)
+	return err
+}
+
+// DeadJobs returns at most `limit` latest jobs in dead-letter.
+func (b *BackendRedis) DeadJobs(ctx context.Context, limit int) ([]*Job, error) {
+	v, err := b.redis.Do(ctx, "LRANGE", b.keys[4], 0, limit-1)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		items = v.Strings()
+		jobs  = make([]*Job, 0, len(items))
+	)
+	for _, item := range items {
+		var job *Job
+		if err = json.UnmarshalUseNumber([]byte(item), &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
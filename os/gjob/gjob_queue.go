@@ -0,0 +1,294 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/grpool"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Queue is the background job queue, which dispatches the due jobs in backend to their handlers.
+type Queue struct {
+	option     QueueOption
+	handlers   *gmap.StrAnyMap // Job type name to HandlerFunc.
+	pool       *grpool.Pool    // Worker pool running the jobs.
+	workers    chan struct{}   // Semaphore limiting the running jobs.
+	timer      *gtimer.Entry   // Timer entry polling the due jobs.
+	started    *gtype.Bool     // Whether the queue is started.
+	dispatchMu sync.Mutex      // Ensures only one dispatching at the same time.
+	jobWaiter  sync.WaitGroup  // Waits the running jobs on stopping.
+}
+
+// QueueOption is the option for job queue.
+type QueueOption struct {
+	Backend      Backend       // Storage backend, it is a memory backend in default.
+	Concurrency  int           // Max jobs running at the same time, it is 10 in default.
+	PollInterval time.Duration // Interval polling due jobs from backend, it is 1 second in default.
+	Lease        time.Duration // Max running duration of a job, after that the job might run again, it is 1 minute in default.
+	MaxAttempts  int           // Default max attempts of jobs, it is 3 in default.
+	BackoffBase  time.Duration // Base interval of exponential backoff for retries, it is 1 second in default.
+	BackoffMax   time.Duration // Max interval of exponential backoff for retries, it is 1 hour in default.
+	Logger       glog.ILogger  // Logger for job errors, it uses the default logger if it is nil.
+}
+
+const (
+	defaultConcurrency  = 10
+	defaultPollInterval = time.Second
+	defaultLease        = time.Minute
+	defaultMaxAttempts  = 3
+	defaultBackoffBase  = time.Second
+	defaultBackoffMax   = time.Hour
+)
+
+// New creates and returns a new job queue.
+// The queue does not run jobs until it is started.
+func New(option ...QueueOption) *Queue {
+	var opt QueueOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Backend == nil {
+		opt.Backend = NewBackendMemory()
+	}
+	if opt.Concurrency <= 0 {
+		opt.Concurrency = defaultConcurrency
+	}
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = defaultPollInterval
+	}
+	if opt.Lease <= 0 {
+		opt.Lease = defaultLease
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = defaultMaxAttempts
+	}
+	if opt.BackoffBase <= 0 {
+		opt.BackoffBase = defaultBackoffBase
+	}
+	if opt.BackoffMax <= 0 {
+		opt.BackoffMax = defaultBackoffMax
+	}
+	return &Queue{
+		option:   opt,
+		handlers: gmap.NewStrAnyMap(true),
+		pool:     grpool.New(opt.Concurrency),
+		workers:  make(chan struct{}, opt.Concurrency),
+		started:  gtype.NewBool(),
+	}
+}
+
+// Handle registers the handler for jobs of type `jobType`.
+func (q *Queue) Handle(jobType string, handler HandlerFunc) {
+	q.handlers.Set(jobType, handler)
+}
+
+// Register registers the handler for jobs of type `jobType` to queue `q`,
+// the payload of job is decoded to type `T` using gconv.
+func Register[T any](q *Queue, jobType string, handler func(ctx context.Context, payload T) error) {
+	q.Handle(jobType, func(ctx context.Context, job *Job) error {
+		var payload T
+		if err := job.Scan(&payload); err != nil {
+			return err
+		}
+		return handler(ctx, payload)
+	})
+}
+
+// Enqueue adds a job of type `jobType` with `payload`, which is encoded in JSON.
+// It returns error of code gcode.CodeInvalidOperation if the unique key of job is held by another job.
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload any, option ...EnqueueOption) (*Job, error) {
+	var opt EnqueueOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `encode payload of job "%s" failed`, jobType)
+	}
+	var (
+		now = time.Now()
+		job = &Job{
+			ID:          guid.S(),
+			Type:        jobType,
+			Payload:     string(content),
+			Priority:    opt.Priority,
+			UniqueKey:   opt.UniqueKey,
+			MaxAttempts: opt.MaxAttempts,
+			RunAt:       now.Add(opt.Delay),
+			EnqueuedAt:  now,
+		}
+	)
+	if !opt.RunAt.IsZero() {
+		job.RunAt = opt.RunAt
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = q.option.MaxAttempts
+	}
+	ok, err := q.option.Backend.Push(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidOperation, `duplicated job of unique key "%s"`, job.UniqueKey,
+		)
+	}
+	// Dispatches immediately if the job is due.
+	if q.started.Val() && !job.RunAt.After(now) {
+		go q.dispatch(context.WithoutCancel(ctx))
+	}
+	return job, nil
+}
+
+// DeadJobs returns at most `limit` latest jobs in dead-letter.
+func (q *Queue) DeadJobs(ctx context.Context, limit int) ([]*Job, error) {
+	return q.option.Backend.DeadJobs(ctx, limit)
+}
+
+// Start starts polling and running the due jobs.
+func (q *Queue) Start(ctx context.Context) {
+	if !q.started.Cas(false, true) {
+		return
+	}
+	// The jobs keep running after `ctx` is done, until the queue is stopped.
+	ctx = context.WithoutCancel(ctx)
+	q.timer = gtimer.AddSingleton(ctx, q.option.PollInterval, q.dispatch)
+	go q.dispatch(ctx)
+}
+
+// Stop stops polling the jobs, and waits for the running jobs done until `ctx` is done.
+func (q *Queue) Stop(ctx context.Context) error {
+	if !q.started.Cas(true, false) {
+		return nil
+	}
+	q.timer.Close()
+	// Waits the dispatching done, no more jobs are added to pool after that.
+	q.dispatchMu.Lock()
+	q.dispatchMu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		q.jobWaiter.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dispatch pops the due jobs from backend and runs them in worker pool until the workers are full.
+func (q *Queue) dispatch(ctx context.Context) {
+	if !q.dispatchMu.TryLock() {
+		return
+	}
+	defer q.dispatchMu.Unlock()
+	for q.started.Val() {
+		select {
+		case q.workers <- struct{}{}:
+		default:
+			// All workers are busy.
+			return
+		}
+		now := time.Now()
+		job, err := q.option.Backend.Pop(ctx, now, now.Add(q.option.Lease))
+		if err != nil || job == nil {
+			<-q.workers
+			if err != nil {
+				q.logErrorf(ctx, `pop job failed: %+v`, err)
+			}
+			return
+		}
+		q.jobWaiter.Add(1)
+		err = q.pool.Add(ctx, func(ctx context.Context) {
+			defer func() {
+				<-q.workers
+				q.jobWaiter.Done()
+			}()
+			q.process(ctx, job)
+		})
+		if err != nil {
+			<-q.workers
+			q.jobWaiter.Done()
+			q.logErrorf(ctx, `run job "%s" failed: %+v`, job.ID, err)
+			return
+		}
+	}
+}
+
+// process runs the job with its handler, and acknowledges, retries or moves it to dead-letter according to result.
+// Note that the Attempts of job is already increased in popping.
+func (q *Queue) process(ctx context.Context, job *Job) {
+	var (
+		backend = q.option.Backend
+		err     error
+	)
+	if job.Attempts > job.MaxAttempts {
+		// The lease of the last attempt expired without result, eg: the worker crashed while running the job.
+		job.Attempts = job.MaxAttempts
+		err = gerror.NewCodef(
+			gcode.CodeOperationFailed, `lease of job expired in the last attempt of %d attempts`, job.MaxAttempts,
+		)
+	} else {
+		err = q.runHandler(ctx, job)
+	}
+	if err == nil {
+		if err = backend.Ack(ctx, job); err != nil {
+			q.logErrorf(ctx, `ack job "%s" failed: %+v`, job.ID, err)
+		}
+		return
+	}
+	job.LastError = err.Error()
+	if job.Attempts >= job.MaxAttempts {
+		q.logErrorf(ctx, `job "%s" of type "%s" is dead after %d attempts: %+v`, job.ID, job.Type, job.Attempts, err)
+		if err = backend.Dead(ctx, job); err != nil {
+			q.logErrorf(ctx, `move job "%s" to dead-letter failed: %+v`, job.ID, err)
+		}
+		return
+	}
+	job.RunAt = time.Now().Add(utils.Backoff(q.option.BackoffBase, q.option.BackoffMax, job.Attempts))
+	if err = backend.Retry(ctx, job); err != nil {
+		q.logErrorf(ctx, `retry job "%s" failed: %+v`, job.ID, err)
+	}
+}
+
+// runHandler runs the handler of job in the lease, the panic of handler is returned as error.
+func (q *Queue) runHandler(ctx context.Context, job *Job) (err error) {
+	handler, ok := q.handlers.Get(job.Type).(HandlerFunc)
+	if !ok {
+		return gerror.NewCodef(gcode.CodeNotFound, `no handler for job type "%s"`, job.Type)
+	}
+	ctx, cancel := context.WithTimeout(ctx, q.option.Lease)
+	defer cancel()
+	defer func() {
+		if exception := recover(); exception != nil {
+			err = utils.PanicToError(exception)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+func (q *Queue) logErrorf(ctx context.Context, format string, v ...any) {
+	logger := q.option.Logger
+	if logger == nil {
+		logger = glog.DefaultLogger()
+	}
+	logger.Errorf(ctx, format, v...)
+}
@@ -0,0 +1,261 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gjob"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	ctx = gctx.New()
+)
+
+func newTestQueue(option ...gjob.QueueOption) *gjob.Queue {
+	opt := gjob.QueueOption{
+		PollInterval: 100 * time.Millisecond,
+		BackoffBase:  100 * time.Millisecond,
+	}
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	return gjob.New(opt)
+}
+
+func Test_Queue_Run(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			queue = newTestQueue()
+			array = garray.NewStrArray(true)
+		)
+		queue.Handle("echo", func(ctx context.Context, job *gjob.Job) error {
+			var s string
+			if err := job.Scan(&s); err != nil {
+				return err
+			}
+			array.Append(s)
+			return nil
+		})
+		queue.Start(ctx)
+		defer queue.Stop(ctx)
+
+		job, err := queue.Enqueue(ctx, "echo", "hello")
+		t.AssertNil(err)
+		t.AssertNE(job.ID, "")
+		t.Assert(job.MaxAttempts, 3)
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(array.Slice(), []string{"hello"})
+	})
+}
+
+func Test_Queue_Priority_Delay(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			queue = gjob.New(gjob.QueueOption{
+				Concurrency:  1,
+				PollInterval: 100 * time.Millisecond,
+			})
+			array = garray.NewIntArray(true)
+		)
+		gjob.Register(queue, "number", func(ctx context.Context, n int) error {
+			array.Append(n)
+			return nil
+		})
+		_, err := queue.Enqueue(ctx, "number", 1)
+		t.AssertNil(err)
+		_, err = queue.Enqueue(ctx, "number", 2, gjob.EnqueueOption{Priority: 10})
+		t.AssertNil(err)
+		_, err = queue.Enqueue(ctx, "number", 3, gjob.EnqueueOption{Priority: 20, Delay: 500 * time.Millisecond})
+		t.AssertNil(err)
+
+		queue.Start(ctx)
+		defer queue.Stop(ctx)
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(array.Slice(), []int{2, 1})
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(array.Slice(), []int{2, 1, 3})
+	})
+}
+
+func Test_Queue_Unique(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			queue = newTestQueue()
+			count = garray.NewIntArray(true)
+		)
+		queue.Handle("unique", func(ctx context.Context, job *gjob.Job) error {
+			count.Append(1)
+			return nil
+		})
+		_, err := queue.Enqueue(ctx, "unique", nil, gjob.EnqueueOption{UniqueKey: "k"})
+		t.AssertNil(err)
+		_, err = queue.Enqueue(ctx, "unique", nil, gjob.EnqueueOption{UniqueKey: "k"})
+		t.Assert(gerror.Code(err), gcode.CodeInvalidOperation)
+
+		queue.Start(ctx)
+		defer queue.Stop(ctx)
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(count.Len(), 1)
+
+		// The unique key is released after the job is done.
+		_, err = queue.Enqueue(ctx, "unique", nil, gjob.EnqueueOption{UniqueKey: "k"})
+		t.AssertNil(err)
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(count.Len(), 2)
+	})
+}
+
+func Test_Queue_Retry_Dead(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			queue    = newTestQueue()
+			attempts = garray.NewArray(true)
+		)
+		queue.Handle("fail", func(ctx context.Context, job *gjob.Job) error {
+			attempts.Append(time.Now())
+			if job.Attempts == 1 {
+				panic("boom")
+			}
+			return errors.New("failed")
+		})
+		queue.Start(ctx)
+		defer queue.Stop(ctx)
+
+		_, err := queue.Enqueue(ctx, "fail", map[string]any{"k": "v"})
+		t.AssertNil(err)
+		time.Sleep(1500 * time.Millisecond)
+		t.Assert(attempts.Len(), 3)
+		// Exponential backoff: 100ms and then 200ms.
+		var (
+			first, _  = attempts.Get(0)
+			second, _ = attempts.Get(1)
+			third, _  = attempts.Get(2)
+		)
+		t.AssertGE(second.(time.Time).Sub(first.(time.Time)), 100*time.Millisecond)
+		t.AssertGE(third.(time.Time).Sub(second.(time.Time)), 200*time.Millisecond)
+
+		jobs, err := queue.DeadJobs(ctx, 10)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 1)
+		t.Assert(jobs[0].Type, "fail")
+		t.Assert(jobs[0].Attempts, 3)
+		t.Assert(jobs[0].LastError, "failed")
+		t.Assert(jobs[0].Payload, `{"k":"v"}`)
+	})
+}
+
+func Test_Queue_NoHandler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		queue := newTestQueue()
+		queue.Start(ctx)
+		defer queue.Stop(ctx)
+
+		_, err := queue.Enqueue(ctx, "unknown", nil, gjob.EnqueueOption{MaxAttempts: 1})
+		t.AssertNil(err)
+		time.Sleep(300 * time.Millisecond)
+		jobs, err := queue.DeadJobs(ctx, 10)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 1)
+		t.Assert(jobs[0].Type, "unknown")
+	})
+}
+
+func Test_Queue_LeaseExpired(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			backend = gjob.NewBackendMemory()
+			queue   = gjob.New(gjob.QueueOption{
+				Backend:      backend,
+				PollInterval: 50 * time.Millisecond,
+			})
+			array = garray.NewIntArray(true)
+		)
+		queue.Handle("crash", func(ctx context.Context, job *gjob.Job) error {
+			array.Append(1)
+			return nil
+		})
+		// The job of which all attempts were popped, but the last attempt did not finish in its lease.
+		now := time.Now()
+		_, err := backend.Push(ctx, &gjob.Job{ID: "1", Type: "crash", RunAt: now, MaxAttempts: 2})
+		t.AssertNil(err)
+		for i := 0; i < 2; i++ {
+			job, err := backend.Pop(ctx, now.Add(time.Duration(i)*time.Millisecond), now)
+			t.AssertNil(err)
+			t.Assert(job.Attempts, i+1)
+		}
+
+		queue.Start(ctx)
+		defer queue.Stop(ctx)
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(array.Len(), 0)
+		jobs, err := queue.DeadJobs(ctx, 10)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 1)
+		t.Assert(jobs[0].Attempts, 2)
+		t.AssertNE(jobs[0].LastError, "")
+	})
+}
+
+func Test_Queue_Stop(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			queue = newTestQueue()
+			array = garray.NewIntArray(true)
+		)
+		queue.Handle("slow", func(ctx context.Context, job *gjob.Job) error {
+			time.Sleep(300 * time.Millisecond)
+			array.Append(1)
+			return nil
+		})
+		queue.Start(ctx)
+		_, err := queue.Enqueue(ctx, "slow", nil)
+		t.AssertNil(err)
+		time.Sleep(100 * time.Millisecond)
+		t.AssertNil(queue.Stop(ctx))
+		t.Assert(array.Len(), 1)
+	})
+}
+
+func Test_BackendMemory_Lease(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			backend = gjob.NewBackendMemory()
+			now     = time.Now()
+		)
+		ok, err := backend.Push(ctx, &gjob.Job{ID: "1", Type: "t", RunAt: now})
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		job, err := backend.Pop(ctx, now, now.Add(time.Second))
+		t.AssertNil(err)
+		t.Assert(job.ID, "1")
+		t.Assert(job.Attempts, 1)
+		// Leased job is not popped again until the lease expires.
+		job, err = backend.Pop(ctx, now, now.Add(time.Second))
+		t.AssertNil(err)
+		t.AssertNil(job)
+		job, err = backend.Pop(ctx, now.Add(2*time.Second), now.Add(3*time.Second))
+		t.AssertNil(err)
+		t.Assert(job.ID, "1")
+		// The attempt whose lease expires is counted.
+		t.Assert(job.Attempts, 2)
+
+		t.AssertNil(backend.Ack(ctx, job))
+		job, err = backend.Pop(ctx, now.Add(5*time.Second), now.Add(6*time.Second))
+		t.AssertNil(err)
+		t.AssertNil(job)
+	})
+}
@@ -38,14 +38,32 @@ type Pool struct {
 	paused       atomic.Bool                  // Whether the pool is paused from starting new work.
 	timer        *gtimer.Entry                // Timer entry for running the supervisor job.
 	limitChanged atomic.Bool                  // Marks whether the pool limit changed and supervisor should adjust workers.
+	name         string                       // Pool name, which is used as attribute of metrics.
+	slots        chan struct{}                // Semaphore for bounded job queue, it is nil if the queue is not bounded.
+	fullPolicy   FullPolicy                   // Policy for adding job when the bounded job queue is full.
+	closeChan    chan struct{}                // Closed when pool is closed, which wakes up the blocking job adding.
 }
 
 // PoolOption is used to pass options for creating a Pool.
 type PoolOption struct {
 	Limit        int              // Max goroutine count limit.
 	LimitChanger LimitChangerFunc // Function used to change max goroutine count limit. Let it nil to disable.
+	Name         string           // Pool name, which is used as attribute of metrics.
+	QueueSize    int              // Max queued job count, which is not limited in default.
+	FullPolicy   FullPolicy       // Policy for adding job when the job queue is full, it blocks in default.
 }
 
+// FullPolicy specifies how to add job when the bounded job queue is full.
+type FullPolicy int
+
+const (
+	// FullPolicyBlock blocks the job adding until there's room in queue, or the context is done.
+	FullPolicyBlock FullPolicy = iota
+
+	// FullPolicyReject rejects the job adding with error immediately.
+	FullPolicyReject
+)
+
 // localPoolItem is the job item storing in job list.
 type localPoolItem struct {
 	Ctx  context.Context // Context.
@@ -59,7 +77,9 @@ const (
 
 // Default goroutine pool.
 var (
-	defaultPool = New()
+	defaultPool = NewWithOption(PoolOption{
+		Name: "default",
+	})
 )
 
 // New creates and returns a new goroutine pool object.
@@ -93,6 +113,9 @@ func NewWithOption(option ...PoolOption) *Pool {
 			count:        gtype.NewInt(),
 			list:         glist.NewT[*localPoolItem](true),
 			closed:       gtype.NewBool(),
+			name:         o.Name,
+			fullPolicy:   o.FullPolicy,
+			closeChan:    make(chan struct{}),
 		}
 		timerDuration = grand.D(
 			minSupervisorTimerDuration,
@@ -104,6 +127,9 @@ func NewWithOption(option ...PoolOption) *Pool {
 	} else {
 		pool.limit.Store(-1)
 	}
+	if o.QueueSize > 0 {
+		pool.slots = make(chan struct{}, o.QueueSize)
+	}
 	if o.LimitChanger != nil {
 		pool.limitChanger = o.LimitChanger
 	}
@@ -126,6 +152,18 @@ func AddWithRecover(ctx context.Context, userFunc Func, recoverFunc RecoverFunc)
 	return defaultPool.AddWithRecover(ctx, userFunc, recoverFunc)
 }
 
+// Submit pushes a new job to the default goroutine pool, and returns the future for its result.
+// See Pool.Submit.
+func Submit(ctx context.Context, f SubmitFunc) (*Future, error) {
+	return defaultPool.Submit(ctx, f)
+}
+
+// NewGroup creates and returns a job group using the default goroutine pool.
+// See Pool.NewGroup.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	return defaultPool.NewGroup(ctx)
+}
+
 // Size returns current goroutine count of default goroutine pool.
 func Size() int {
 	return defaultPool.Size()
@@ -0,0 +1,72 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/internal/utils"
+)
+
+// SubmitFunc is the pool function which returns result.
+type SubmitFunc func(ctx context.Context) (any, error)
+
+// Future is the result of a job submitted to pool, which is available after the job is done.
+type Future struct {
+	done  chan struct{} // Closed when the job is done.
+	value any           // Result value of the job.
+	err   error         // Result error of the job.
+}
+
+// Submit pushes a new job to the pool, and returns the future for its result.
+// The job will be executed asynchronously.
+//
+// The job is skipped if `ctx` is done before the job starts, and the future returns the error of `ctx`.
+// The panic of job is recovered and returned by the future as error of code gcode.CodeInternalPanic.
+func (p *Pool) Submit(ctx context.Context, f SubmitFunc) (*Future, error) {
+	future := &Future{
+		done: make(chan struct{}),
+	}
+	err := p.Add(ctx, func(ctx context.Context) {
+		future.value, future.err = p.runSubmitFunc(ctx, f)
+		close(future.done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return future, nil
+}
+
+// Done returns a channel that is closed when the job is done.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait waits for the job done and returns its result.
+// It returns the error of `ctx` if `ctx` is done before the job.
+func (f *Future) Wait(ctx context.Context) (any, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runSubmitFunc executes `f` if `ctx` is not done, and recovers its panic as error.
+func (p *Pool) runSubmitFunc(ctx context.Context, f SubmitFunc) (value any, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if exception := recover(); exception != nil {
+			p.onPanic(ctx)
+			value, err = nil, utils.PanicToError(exception)
+		}
+	}()
+	return f(ctx)
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpool
+
+import (
+	"context"
+	"sync"
+)
+
+// Group is a group of jobs executed in pool, the first error of the jobs cancels the rest of them.
+type Group struct {
+	pool    *Pool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error // The first error of the jobs.
+}
+
+// NewGroup creates and returns a job group executed in the pool, and the context derived from `ctx`
+// for the jobs, which is canceled when any job of group returns error or Wait returns.
+func (p *Pool) NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{
+		pool:   p,
+		ctx:    ctx,
+		cancel: cancel,
+	}, ctx
+}
+
+// Go pushes a new job to the group.
+// The job is skipped if the group is canceled before the job starts,
+// and the panic of job is recovered as error of code gcode.CodeInternalPanic.
+// It returns error if the job cannot be added to pool, which is also treated as error of group.
+func (g *Group) Go(f func(ctx context.Context) error) error {
+	g.wg.Add(1)
+	err := g.pool.Add(g.ctx, func(ctx context.Context) {
+		defer g.wg.Done()
+		_, err := g.pool.runSubmitFunc(ctx, func(ctx context.Context) (any, error) {
+			return nil, f(ctx)
+		})
+		if err != nil {
+			g.setError(err)
+		}
+	})
+	if err != nil {
+		g.wg.Done()
+		g.setError(err)
+	}
+	return err
+}
+
+// Wait waits for all jobs of group done, and returns the first error of them.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
+
+// setError records the first error of group and cancels the rest jobs.
+func (g *Group) setError(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		g.cancel()
+	})
+}
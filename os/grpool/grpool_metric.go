@@ -0,0 +1,110 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpool
+
+import (
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+type localMetricManager struct {
+	PoolJobQueued        gmetric.UpDownCounter
+	PoolJobRunning       gmetric.UpDownCounter
+	PoolJobTotal         gmetric.Counter
+	PoolJobDuration      gmetric.Histogram
+	PoolJobRejectedTotal gmetric.Counter
+	PoolJobPanicTotal    gmetric.Counter
+}
+
+const (
+	instrumentName        = "github.com/gogf/gf/v2/os/grpool.Pool"
+	metricAttrKeyPoolName = "pool.name"
+)
+
+var (
+	// metricManager for goroutine pool metrics.
+	metricManager = newMetricManager()
+)
+
+func newMetricManager() *localMetricManager {
+	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        instrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+	mm := &localMetricManager{
+		PoolJobQueued: meter.MustUpDownCounter(
+			"pool.job.queued",
+			gmetric.MetricOption{
+				Help:       "Number of jobs waiting in the queue of goroutine pool.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		PoolJobRunning: meter.MustUpDownCounter(
+			"pool.job.running",
+			gmetric.MetricOption{
+				Help:       "Number of jobs running in goroutine pool.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		PoolJobTotal: meter.MustCounter(
+			"pool.job.total",
+			gmetric.MetricOption{
+				Help:       "Total number of jobs executed by goroutine pool.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		PoolJobDuration: meter.MustHistogram(
+			"pool.job.duration",
+			gmetric.MetricOption{
+				Help:       "Measures the duration of jobs executed by goroutine pool.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets: []float64{
+					1,
+					5,
+					10,
+					50,
+					100,
+					500,
+					1000,
+					5000,
+					10000,
+					60000,
+				},
+			},
+		),
+		PoolJobRejectedTotal: meter.MustCounter(
+			"pool.job.rejected.total",
+			gmetric.MetricOption{
+				Help:       "Total number of jobs rejected by goroutine pool as its queue is full.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		PoolJobPanicTotal: meter.MustCounter(
+			"pool.job.panic.total",
+			gmetric.MetricOption{
+				Help:       "Total number of recovered panics of jobs in goroutine pool.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+	}
+	return mm
+}
+
+// GetMetricOptionForPool returns the metric option for pool.
+func (m *localMetricManager) GetMetricOptionForPool(pool *Pool) gmetric.Option {
+	return gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyPoolName, pool.name),
+		},
+	}
+}
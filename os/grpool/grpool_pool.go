@@ -8,13 +8,21 @@ package grpool
 
 import (
 	"context"
+	"time"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gmetric"
 )
 
 // Add pushes a new job to the pool.
 // The job will be executed asynchronously.
+//
+// If the job queue of pool is bounded and full, it blocks until there's room in queue or `ctx` is done,
+// or it returns error immediately if the pool is created with FullPolicyReject.
+// Note that the job is still executed even if `ctx` is done before the job starts, use Submit if you
+// need the job skipped in that case.
 func (p *Pool) Add(ctx context.Context, f Func) error {
 	if p.closed.Val() {
 		return gerror.NewCode(
@@ -22,10 +30,16 @@ func (p *Pool) Add(ctx context.Context, f Func) error {
 			"goroutine defaultPool is already closed",
 		)
 	}
+	if err := p.acquireSlot(ctx); err != nil {
+		return err
+	}
 	p.list.PushFront(&localPoolItem{
 		Ctx:  ctx,
 		Func: f,
 	})
+	if gmetric.IsEnabled() {
+		metricManager.PoolJobQueued.Inc(ctx, metricManager.GetMetricOptionForPool(p))
+	}
 	// Check and fork new worker.
 	p.checkAndForkNewGoroutineWorker()
 	return nil
@@ -40,12 +54,9 @@ func (p *Pool) AddWithRecover(ctx context.Context, userFunc Func, recoverFunc Re
 	return p.Add(ctx, func(ctx context.Context) {
 		defer func() {
 			if exception := recover(); exception != nil {
+				p.onPanic(ctx)
 				if recoverFunc != nil {
-					if v, ok := exception.(error); ok && gerror.HasStack(v) {
-						recoverFunc(ctx, v)
-					} else {
-						recoverFunc(ctx, gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception))
-					}
+					recoverFunc(ctx, utils.PanicToError(exception))
 				}
 			}
 		}()
@@ -87,6 +98,9 @@ func (p *Pool) Jobs() int {
 // ClearJobs clears all queued jobs and returns how many were cleared.
 func (p *Pool) ClearJobs() (count int) {
 	items := p.list.PopBackAll()
+	for _, item := range items {
+		p.onJobPopped(item)
+	}
 	return len(items)
 }
 
@@ -129,6 +143,7 @@ func (p *Pool) IsClosed() bool {
 // Close closes the goroutine pool, which makes all goroutines exit.
 func (p *Pool) Close() {
 	if p.closed.Cas(false, true) {
+		close(p.closeChan)
 		if p.timer != nil {
 			p.timer.Close()
 		}
@@ -179,7 +194,8 @@ func (p *Pool) asynchronousWorker() {
 			p.list.PushBack(listItem)
 			return
 		}
-		listItem.Func(listItem.Ctx)
+		p.onJobPopped(listItem)
+		p.runJob(listItem)
 		// Check whether need reduce worker.
 		n = p.count.Val()
 		if limit := p.limit.Load(); limit > 0 && int64(n) > limit && p.count.Cas(n, n-1) {
@@ -188,3 +204,72 @@ func (p *Pool) asynchronousWorker() {
 		}
 	}
 }
+
+// runJob executes the job and updates the metrics.
+func (p *Pool) runJob(item *localPoolItem) {
+	if !gmetric.IsEnabled() {
+		item.Func(item.Ctx)
+		return
+	}
+	var (
+		startTime = time.Now()
+		option    = metricManager.GetMetricOptionForPool(p)
+	)
+	metricManager.PoolJobRunning.Inc(item.Ctx, option)
+	defer func() {
+		metricManager.PoolJobRunning.Dec(item.Ctx, option)
+		metricManager.PoolJobDuration.Record(float64(time.Since(startTime).Milliseconds()), option)
+		metricManager.PoolJobTotal.Inc(item.Ctx, option)
+	}()
+	item.Func(item.Ctx)
+}
+
+// acquireSlot acquires room in the bounded job queue for a new job.
+func (p *Pool) acquireSlot(ctx context.Context) error {
+	if p.slots == nil {
+		return nil
+	}
+	if p.fullPolicy == FullPolicyReject {
+		select {
+		case p.slots <- struct{}{}:
+			return nil
+		default:
+			if gmetric.IsEnabled() {
+				metricManager.PoolJobRejectedTotal.Inc(ctx, metricManager.GetMetricOptionForPool(p))
+			}
+			return gerror.NewCodef(
+				gcode.CodeInvalidOperation,
+				"job queue of goroutine pool is full, size: %d",
+				cap(p.slots),
+			)
+		}
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return gerror.Wrap(ctx.Err(), "wait for room in job queue of goroutine pool failed")
+	case <-p.closeChan:
+		return gerror.NewCode(
+			gcode.CodeInvalidOperation,
+			"goroutine defaultPool is already closed",
+		)
+	}
+}
+
+// onJobPopped releases the room of job in the bounded job queue and updates the metrics.
+func (p *Pool) onJobPopped(item *localPoolItem) {
+	if p.slots != nil {
+		<-p.slots
+	}
+	if gmetric.IsEnabled() {
+		metricManager.PoolJobQueued.Dec(item.Ctx, metricManager.GetMetricOptionForPool(p))
+	}
+}
+
+// onPanic updates the metrics for the recovered panic of job.
+func (p *Pool) onPanic(ctx context.Context) {
+	if gmetric.IsEnabled() {
+		metricManager.PoolJobPanicTotal.Inc(ctx, metricManager.GetMetricOptionForPool(p))
+	}
+}
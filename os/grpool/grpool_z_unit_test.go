@@ -8,12 +8,16 @@ package grpool_test
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/os/grpool"
 	"github.com/gogf/gf/v2/test/gtest"
 )
@@ -281,3 +285,155 @@ func Test_AddWithRecover(t *testing.T) {
 		t.Assert(array.Len(), 2)
 	})
 }
+
+func Test_QueueSize_Reject(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			release = make(chan struct{})
+			pool    = grpool.NewWithOption(grpool.PoolOption{
+				Limit:      1,
+				QueueSize:  2,
+				FullPolicy: grpool.FullPolicyReject,
+			})
+		)
+		defer pool.Close()
+		blockFunc := func(ctx context.Context) {
+			<-release
+		}
+		t.AssertNil(pool.Add(ctx, blockFunc))
+		t.Assert(waitUntil(time.Second, func() bool { return pool.Jobs() == 0 }), true)
+		t.AssertNil(pool.Add(ctx, blockFunc))
+		t.AssertNil(pool.Add(ctx, blockFunc))
+		err := pool.Add(ctx, blockFunc)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidOperation)
+		close(release)
+
+		// Room is released after jobs are popped from queue.
+		t.Assert(waitUntil(time.Second, func() bool { return pool.Jobs() == 0 }), true)
+		t.AssertNil(pool.Add(ctx, blockFunc))
+	})
+}
+
+func Test_QueueSize_Block(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			release = make(chan struct{})
+			pool    = grpool.NewWithOption(grpool.PoolOption{
+				Limit:     1,
+				QueueSize: 1,
+			})
+		)
+		defer pool.Close()
+		blockFunc := func(ctx context.Context) {
+			<-release
+		}
+		t.AssertNil(pool.Add(ctx, blockFunc))
+		t.Assert(waitUntil(time.Second, func() bool { return pool.Jobs() == 0 }), true)
+		t.AssertNil(pool.Add(ctx, blockFunc))
+
+		// It blocks until the context is done.
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		err := pool.Add(timeoutCtx, blockFunc)
+		t.Assert(errors.Is(err, context.DeadlineExceeded), true)
+
+		// It blocks until there's room in queue.
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			close(release)
+		}()
+		t.AssertNil(pool.Add(ctx, blockFunc))
+	})
+}
+
+func Test_Submit(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		future, err := grpool.Submit(ctx, func(ctx context.Context) (any, error) {
+			return 1, nil
+		})
+		t.AssertNil(err)
+		value, err := future.Wait(ctx)
+		t.AssertNil(err)
+		t.Assert(value, 1)
+
+		future, err = grpool.Submit(ctx, func(ctx context.Context) (any, error) {
+			panic("boom")
+		})
+		t.AssertNil(err)
+		<-future.Done()
+		_, err = future.Wait(ctx)
+		t.Assert(gerror.Code(err), gcode.CodeInternalPanic)
+		t.Assert(err.Error(), "boom")
+	})
+	// Job is skipped if the context is done before it starts.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			release = make(chan struct{})
+			pool    = grpool.New(1)
+			started = gtype.NewBool()
+		)
+		defer pool.Close()
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) { <-release }))
+		cancelCtx, cancel := context.WithCancel(ctx)
+		future, err := pool.Submit(cancelCtx, func(ctx context.Context) (any, error) {
+			started.Set(true)
+			return nil, nil
+		})
+		t.AssertNil(err)
+		cancel()
+		close(release)
+		_, err = future.Wait(ctx)
+		t.Assert(errors.Is(err, context.Canceled), true)
+		t.Assert(started.Val(), false)
+	})
+	// Wait returns if its context is done.
+	gtest.C(t, func(t *gtest.T) {
+		future, err := grpool.Submit(ctx, func(ctx context.Context) (any, error) {
+			time.Sleep(time.Second)
+			return nil, nil
+		})
+		t.AssertNil(err)
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err = future.Wait(timeoutCtx)
+		t.Assert(errors.Is(err, context.DeadlineExceeded), true)
+	})
+}
+
+func Test_Group(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			count    = gtype.NewInt()
+			group, _ = grpool.NewGroup(ctx)
+		)
+		for i := 0; i < 10; i++ {
+			t.AssertNil(group.Go(func(ctx context.Context) error {
+				count.Add(1)
+				return nil
+			}))
+		}
+		t.AssertNil(group.Wait())
+		t.Assert(count.Val(), 10)
+	})
+	// The first error cancels the rest.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			pool            = grpool.New(1)
+			count           = gtype.NewInt()
+			group, groupCtx = pool.NewGroup(ctx)
+		)
+		defer pool.Close()
+		t.AssertNil(group.Go(func(ctx context.Context) error {
+			return errors.New("first")
+		}))
+		for i := 0; i < 10; i++ {
+			t.AssertNil(group.Go(func(ctx context.Context) error {
+				count.Add(1)
+				return nil
+			}))
+		}
+		t.Assert(group.Wait(), "first")
+		t.AssertNE(groupCtx.Err(), nil)
+		t.Assert(count.Val(), 0)
+	})
+}
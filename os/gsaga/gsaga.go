@@ -0,0 +1,195 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gsaga implements the saga orchestration for operations across multiple services.
+//
+// A saga is a sequence of steps, each of which has an action and an optional compensating action
+// undoing it. The steps run in order, and if a step fails after its retries, the compensating actions
+// of the completed steps run in reverse order. The state of each running saga is persisted to
+// Backend after every step, so that the saga interrupted by a crash is resumed by Saga.Resume.
+//
+// As a step might run again after resuming, the actions and compensating actions should be idempotent,
+// eg using Instance.ID as the idempotency key of the request to other services. The context passed to
+// the actions carries the tracing span of the step, which is propagated by gclient and grpcx.
+package gsaga
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Saga is the definition of a saga, which runs and resumes its instances.
+type Saga struct {
+	name    string
+	steps   []Step
+	option  Option
+	running *gset.StrSet // Ids of instances being executed in process.
+}
+
+// Option is the option for saga.
+type Option struct {
+	Backend Backend      // Backend persisting the instances, it uses BackendMemory in default which does not survive crash.
+	Logger  glog.ILogger // Logger for errors of resumed instances, it uses the default logger if it is nil.
+}
+
+// Step is a step of saga.
+type Step struct {
+	Name          string        // Name of the step.
+	Action        StepFunc      // Action of the step.
+	Compensate    StepFunc      // Compensating action undoing the Action, optional.
+	Retry         int           // Retry times of the action and compensating action if they fail, optional.
+	RetryInterval time.Duration // Interval between retries, optional.
+	Timeout       time.Duration // Timeout of each attempt of the action and compensating action, optional.
+}
+
+// StepFunc is the action or compensating action of step.
+// The instance data can be read and written by actions, which is persisted after the step is done.
+type StepFunc func(ctx context.Context, instance *Instance) error
+
+// Instance is a running of saga.
+type Instance struct {
+	ID        string          `json:"id"`              // Unique id of the instance.
+	Saga      string          `json:"saga"`            // Name of the saga.
+	Status    Status          `json:"status"`          // Status of the instance.
+	Step      int             `json:"step"`            // Index of the step being executed or compensated.
+	Data      *gmap.StrAnyMap `json:"data"`            // Data shared by the steps.
+	Error     string          `json:"error,omitempty"` // Error of the failed step.
+	CreatedAt time.Time       `json:"createdAt"`       // Time at which the instance is created.
+	UpdatedAt time.Time       `json:"updatedAt"`       // Time at which the instance is updated.
+}
+
+// Status is the status of instance.
+type Status string
+
+// Backend persists the instances of sagas.
+type Backend interface {
+	// Save creates or updates the instance.
+	Save(ctx context.Context, instance *Instance) error
+
+	// Get returns the instance of `id`, it returns nil if the instance does not exist.
+	Get(ctx context.Context, id string) (*Instance, error)
+
+	// Unfinished returns the instances of saga `saga` which are running or compensating.
+	Unfinished(ctx context.Context, saga string) ([]*Instance, error)
+}
+
+const (
+	StatusRunning      Status = "running"      // The steps are being executed.
+	StatusCompleted    Status = "completed"    // All the steps are done.
+	StatusCompensating Status = "compensating" // A step failed and the completed steps are being compensated.
+	StatusCompensated  Status = "compensated"  // A step failed and the completed steps are compensated.
+	StatusFailed       Status = "failed"       // The compensation failed, which needs manual intervention.
+)
+
+// New creates and returns a saga named `name`.
+// The name is persisted with its instances, which should be unique and not changed.
+func New(name string, option ...Option) *Saga {
+	var opt Option
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Backend == nil {
+		opt.Backend = NewBackendMemory()
+	}
+	return &Saga{
+		name:    name,
+		option:  opt,
+		running: gset.NewStrSet(true),
+	}
+}
+
+// Name returns the name of saga.
+func (s *Saga) Name() string {
+	return s.name
+}
+
+// AddStep appends steps to the saga. It should be called before the saga runs.
+func (s *Saga) AddStep(steps ...Step) *Saga {
+	s.steps = append(s.steps, steps...)
+	return s
+}
+
+// Run creates an instance of the saga with `data` and executes its steps.
+//
+// It returns the instance, and the error of the failed step or compensation. If `ctx` is done during
+// the execution, it returns the error of context and the instance is left to be resumed.
+func (s *Saga) Run(ctx context.Context, data map[string]any) (*Instance, error) {
+	var (
+		now      = time.Now()
+		instance = &Instance{
+			ID:        guid.S(),
+			Saga:      s.name,
+			Status:    StatusRunning,
+			Data:      gmap.NewStrAnyMap(true),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	)
+	instance.Data.Sets(data)
+	s.running.Add(instance.ID)
+	defer s.running.Remove(instance.ID)
+	if err := s.option.Backend.Save(ctx, instance); err != nil {
+		return nil, err
+	}
+	return instance, s.execute(ctx, instance)
+}
+
+// Resume executes the unfinished instances of the saga, eg those interrupted by crash.
+// It should be called after the process starts, and the errors of instances are logged.
+//
+// Note that the instances should not be resumed by multiple processes at the same time.
+func (s *Saga) Resume(ctx context.Context) error {
+	instances, err := s.option.Backend.Unfinished(ctx, s.name)
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// It ignores the instance being executed in process.
+		if !s.running.AddIfNotExist(instance.ID) {
+			continue
+		}
+		if err = s.execute(ctx, instance); err != nil {
+			s.logErrorf(ctx, `resume saga "%s" instance "%s" failed: %+v`, s.name, instance.ID, err)
+		}
+		s.running.Remove(instance.ID)
+	}
+	return nil
+}
+
+// Instance returns the instance of `id`, it returns error if the instance does not exist.
+func (s *Saga) Instance(ctx context.Context, id string) (*Instance, error) {
+	instance, err := s.option.Backend.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil || instance.Saga != s.name {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, `saga "%s" instance "%s" not found`, s.name, id)
+	}
+	return instance, nil
+}
+
+// IsFinished checks and returns whether the instance is finished, which will not be executed anymore.
+func (instance *Instance) IsFinished() bool {
+	return instance.Status != StatusRunning && instance.Status != StatusCompensating
+}
+
+func (s *Saga) logErrorf(ctx context.Context, format string, v ...any) {
+	logger := s.option.Logger
+	if logger == nil {
+		logger = glog.DefaultLogger()
+	}
+	logger.Errorf(ctx, format, v...)
+}
@@ -0,0 +1,140 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// BackendDB is the Backend implements using database table, which should be created in advance, eg in MySQL:
+//
+//	CREATE TABLE `gsaga` (
+//	    `id`         varchar(64)  NOT NULL COMMENT 'Instance id',
+//	    `saga`       varchar(255) NOT NULL COMMENT 'Saga name',
+//	    `status`     varchar(16)  NOT NULL COMMENT 'Status: running, completed, compensating, compensated or failed',
+//	    `step`       int          NOT NULL COMMENT 'Index of the step being executed or compensated',
+//	    `data`       longtext     NOT NULL COMMENT 'Instance data in JSON',
+//	    `error`      text         COMMENT 'Error of the failed step',
+//	    `created_at` bigint       NOT NULL COMMENT 'Created timestamp in milliseconds',
+//	    `updated_at` bigint       NOT NULL COMMENT 'Updated timestamp in milliseconds',
+//	    PRIMARY KEY (`id`),
+//	    KEY `saga_status` (`saga`, `status`)
+//	);
+type BackendDB struct {
+	db    gdb.DB
+	table string
+}
+
+// backendDBInstance is the instance record in table.
+type backendDBInstance struct {
+	Id        string
+	Saga      string
+	Status    string
+	Step      int
+	Data      string
+	Error     string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+var _ Backend = (*BackendDB)(nil)
+
+const (
+	defaultBackendDBTable = "gsaga"
+)
+
+// NewBackendDB creates and returns a database backend.
+// The optional parameter `table` specifies the instance table name, which is "gsaga" in default.
+func NewBackendDB(db gdb.DB, table ...string) *BackendDB {
+	b := &BackendDB{
+		db:    db,
+		table: defaultBackendDBTable,
+	}
+	if len(table) > 0 && table[0] != "" {
+		b.table = table[0]
+	}
+	return b
+}
+
+// Save creates or updates the instance.
+func (b *BackendDB) Save(ctx context.Context, instance *Instance) error {
+	data, err := json.Marshal(instance.Data)
+	if err != nil {
+		return err
+	}
+	_, err = b.db.Model(b.table).Ctx(ctx).OnConflict("id").Save(map[string]any{
+		"id":         instance.ID,
+		"saga":       instance.Saga,
+		"status":     string(instance.Status),
+		"step":       instance.Step,
+		"data":       string(data),
+		"error":      instance.Error,
+		"created_at": instance.CreatedAt.UnixMilli(),
+		"updated_at": instance.UpdatedAt.UnixMilli(),
+	})
+	return err
+}
+
+// Get returns the instance of `id`, it returns nil if the instance does not exist.
+func (b *BackendDB) Get(ctx context.Context, id string) (*Instance, error) {
+	var record *backendDBInstance
+	if err := b.db.Model(b.table).Ctx(ctx).Where("id", id).Scan(&record); err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, nil
+	}
+	return record.toInstance()
+}
+
+// Unfinished returns the instances of saga `saga` which are running or compensating,
+// in order of creation.
+func (b *BackendDB) Unfinished(ctx context.Context, saga string) ([]*Instance, error) {
+	var records []*backendDBInstance
+	err := b.db.Model(b.table).Ctx(ctx).
+		Where("saga", saga).
+		WhereIn("status", []string{string(StatusRunning), string(StatusCompensating)}).
+		OrderAsc("created_at").
+		Scan(&records)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]*Instance, 0, len(records))
+	for _, record := range records {
+		instance, err := record.toInstance()
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// toInstance converts the record to Instance.
+func (r *backendDBInstance) toInstance() (*Instance, error) {
+	var data map[string]any
+	if r.Data != "" {
+		if err := json.UnmarshalUseNumber([]byte(r.Data), &data); err != nil {
+			return nil, err
+		}
+	}
+	return &Instance{
+		ID:        r.Id,
+		Saga:      r.Saga,
+		Status:    Status(r.Status),
+		Step:      r.Step,
+		Data:      gmap.NewStrAnyMapFrom(data, true),
+		Error:     r.Error,
+		CreatedAt: time.UnixMilli(r.CreatedAt),
+		UpdatedAt: time.UnixMilli(r.UpdatedAt),
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gogf/gf/v2/container/gmap"
+)
+
+// BackendMemory is the Backend implements in memory, which is for testing or non-persistent usage.
+type BackendMemory struct {
+	mu        sync.RWMutex
+	instances map[string]*Instance
+}
+
+var _ Backend = (*BackendMemory)(nil)
+
+// NewBackendMemory creates and returns a memory backend.
+func NewBackendMemory() *BackendMemory {
+	return &BackendMemory{
+		instances: make(map[string]*Instance),
+	}
+}
+
+// Save creates or updates the instance.
+func (b *BackendMemory) Save(ctx context.Context, instance *Instance) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.instances[instance.ID] = instance.clone()
+	return nil
+}
+
+// Get returns the instance of `id`, it returns nil if the instance does not exist.
+func (b *BackendMemory) Get(ctx context.Context, id string) (*Instance, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if instance, ok := b.instances[id]; ok {
+		return instance.clone(), nil
+	}
+	return nil, nil
+}
+
+// Unfinished returns the instances of saga `saga` which are running or compensating,
+// in order of creation.
+func (b *BackendMemory) Unfinished(ctx context.Context, saga string) ([]*Instance, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var instances []*Instance
+	for _, instance := range b.instances {
+		if instance.Saga == saga && !instance.IsFinished() {
+			instances = append(instances, instance.clone())
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].CreatedAt.Before(instances[j].CreatedAt)
+	})
+	return instances, nil
+}
+
+// clone returns a copy of the instance, whose data is copied too.
+func (instance *Instance) clone() *Instance {
+	copied := *instance
+	if instance.Data != nil {
+		copied.Data = instance.Data.Clone(true)
+	} else {
+		copied.Data = gmap.NewStrAnyMap(true)
+	}
+	return &copied
+}
@@ -0,0 +1,155 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/net/gtrace"
+)
+
+const (
+	tracingSpanNameRun        = "gsaga.run"
+	tracingSpanNameAction     = "gsaga.action"
+	tracingSpanNameCompensate = "gsaga.compensate"
+	tracingAttrSagaName       = "saga.name"
+	tracingAttrSagaInstance   = "saga.instance"
+	tracingAttrSagaStatus     = "saga.status"
+	tracingAttrStepName       = "saga.step"
+	tracingAttrStepAttempts   = "saga.step.attempts"
+)
+
+// execute executes the steps of instance from its current step, and compensates the completed steps
+// if any step fails. The instance is saved after each step.
+func (s *Saga) execute(ctx context.Context, instance *Instance) (err error) {
+	ctx, span := gtrace.NewSpan(ctx, tracingSpanNameRun)
+	defer func() {
+		span.SetAttributes(attribute.String(tracingAttrSagaStatus, string(instance.Status)))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(
+		attribute.String(tracingAttrSagaName, s.name),
+		attribute.String(tracingAttrSagaInstance, instance.ID),
+	)
+	var stepErr error
+	for instance.Status == StatusRunning {
+		if instance.Step >= len(s.steps) {
+			instance.Status = StatusCompleted
+			break
+		}
+		step := s.steps[instance.Step]
+		if stepErr = s.runStep(ctx, tracingSpanNameAction, step, step.Action, instance); stepErr != nil {
+			if ctx.Err() != nil {
+				// The instance is left running, which is resumed later.
+				return ctx.Err()
+			}
+			stepErr = gerror.Wrapf(stepErr, `saga "%s" step "%s" failed`, s.name, step.Name)
+			instance.Status = StatusCompensating
+			instance.Error = stepErr.Error()
+			// The failed step itself is not compensated.
+			instance.Step--
+		} else {
+			instance.Step++
+		}
+		if err = s.save(ctx, instance); err != nil {
+			return err
+		}
+	}
+	for instance.Status == StatusCompensating {
+		if instance.Step < 0 {
+			instance.Status = StatusCompensated
+			break
+		}
+		step := s.steps[instance.Step]
+		if step.Compensate != nil {
+			if err = s.runStep(ctx, tracingSpanNameCompensate, step, step.Compensate, instance); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				err = gerror.Wrapf(err, `saga "%s" step "%s" compensation failed`, s.name, step.Name)
+				instance.Status = StatusFailed
+				instance.Error = err.Error()
+				if saveErr := s.save(ctx, instance); saveErr != nil {
+					s.logErrorf(ctx, `save saga "%s" instance "%s" failed: %+v`, s.name, instance.ID, saveErr)
+				}
+				return err
+			}
+		}
+		instance.Step--
+		if err = s.save(ctx, instance); err != nil {
+			return err
+		}
+	}
+	if err = s.save(ctx, instance); err != nil {
+		return err
+	}
+	if instance.Status == StatusCompensated && stepErr == nil {
+		// The instance was resumed in compensating.
+		stepErr = gerror.NewCode(gcode.CodeOperationFailed, instance.Error)
+	}
+	return stepErr
+}
+
+// runStep runs `f` of `step` with retries and timeout, in a tracing span named `spanName`.
+func (s *Saga) runStep(ctx context.Context, spanName string, step Step, f StepFunc, instance *Instance) (err error) {
+	ctx, span := gtrace.NewSpan(ctx, spanName)
+	defer span.End()
+	span.SetAttributes(
+		attribute.String(tracingAttrSagaName, s.name),
+		attribute.String(tracingAttrSagaInstance, instance.ID),
+		attribute.String(tracingAttrStepName, step.Name),
+	)
+	attempts := 0
+	for {
+		attempts++
+		if err = s.callStepFunc(ctx, step, f, instance); err == nil || attempts > step.Retry || ctx.Err() != nil {
+			break
+		}
+		if step.RetryInterval > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(step.RetryInterval):
+			}
+		}
+	}
+	span.SetAttributes(attribute.Int(tracingAttrStepAttempts, attempts))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// callStepFunc calls `f` once with the timeout of step, the panic of `f` is returned as error.
+func (s *Saga) callStepFunc(ctx context.Context, step Step, f StepFunc, instance *Instance) (err error) {
+	if step.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, step.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		if exception := recover(); exception != nil {
+			err = utils.PanicToError(exception)
+		}
+	}()
+	return f(ctx, instance)
+}
+
+// save updates the instance to backend.
+func (s *Saga) save(ctx context.Context, instance *Instance) error {
+	instance.UpdatedAt = time.Now()
+	return s.option.Backend.Save(ctx, instance)
+}
@@ -0,0 +1,263 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gsaga"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+var (
+	ctx = gctx.New()
+)
+
+// newStep creates a step recording its actions into `array`, the action fails if `fail` is true.
+func newStep(name string, array *garray.StrArray, fail bool) gsaga.Step {
+	return gsaga.Step{
+		Name: name,
+		Action: func(ctx context.Context, instance *gsaga.Instance) error {
+			array.Append("do:" + name)
+			if fail {
+				return gerror.NewCode(gcode.CodeOperationFailed, "failed")
+			}
+			instance.Data.Set(name, true)
+			return nil
+		},
+		Compensate: func(ctx context.Context, instance *gsaga.Instance) error {
+			array.Append("undo:" + name)
+			return nil
+		},
+	}
+}
+
+func Test_Saga_Completed(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			array = garray.NewStrArray(true)
+			saga  = gsaga.New("order")
+		)
+		saga.AddStep(
+			newStep("order", array, false),
+			newStep("inventory", array, false),
+			newStep("payment", array, false),
+		)
+		instance, err := saga.Run(ctx, map[string]any{"orderId": 1})
+		t.AssertNil(err)
+		t.Assert(instance.Status, gsaga.StatusCompleted)
+		t.Assert(array.Slice(), []string{"do:order", "do:inventory", "do:payment"})
+
+		instance, err = saga.Instance(ctx, instance.ID)
+		t.AssertNil(err)
+		t.Assert(instance.Status, gsaga.StatusCompleted)
+		t.Assert(instance.Step, 3)
+		t.Assert(instance.Data.GetVar("orderId").Int(), 1)
+		t.Assert(instance.Data.GetVar("payment").Bool(), true)
+
+		_, err = saga.Instance(ctx, "none")
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+	})
+}
+
+func Test_Saga_Compensated(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			array = garray.NewStrArray(true)
+			saga  = gsaga.New("order")
+		)
+		saga.AddStep(
+			newStep("order", array, false),
+			newStep("inventory", array, false),
+			newStep("payment", array, true),
+		)
+		instance, err := saga.Run(ctx, nil)
+		t.Assert(gerror.Code(err), gcode.CodeOperationFailed)
+		t.Assert(instance.Status, gsaga.StatusCompensated)
+		t.Assert(array.Slice(), []string{
+			"do:order", "do:inventory", "do:payment", "undo:inventory", "undo:order",
+		})
+		instance, err = saga.Instance(ctx, instance.ID)
+		t.AssertNil(err)
+		t.Assert(instance.Status, gsaga.StatusCompensated)
+		t.AssertNE(instance.Error, "")
+	})
+}
+
+func Test_Saga_Failed(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			array = garray.NewStrArray(true)
+			saga  = gsaga.New("order")
+			step  = newStep("inventory", array, false)
+		)
+		step.Compensate = func(ctx context.Context, instance *gsaga.Instance) error {
+			panic("boom")
+		}
+		saga.AddStep(
+			newStep("order", array, false),
+			step,
+			newStep("payment", array, true),
+		)
+		instance, err := saga.Run(ctx, nil)
+		t.Assert(gerror.Code(err), gcode.CodeInternalPanic)
+		t.Assert(instance.Status, gsaga.StatusFailed)
+		t.Assert(instance.Step, 1)
+		t.Assert(array.Slice(), []string{"do:order", "do:inventory", "do:payment"})
+	})
+}
+
+func Test_Saga_Retry_Timeout(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			attempts = 0
+			saga     = gsaga.New("order")
+		)
+		saga.AddStep(gsaga.Step{
+			Name: "payment",
+			Action: func(ctx context.Context, instance *gsaga.Instance) error {
+				attempts++
+				if attempts < 3 {
+					// It times out at the first two attempts.
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return nil
+			},
+			Retry:         2,
+			RetryInterval: 10 * time.Millisecond,
+			Timeout:       50 * time.Millisecond,
+		})
+		instance, err := saga.Run(ctx, nil)
+		t.AssertNil(err)
+		t.Assert(instance.Status, gsaga.StatusCompleted)
+		t.Assert(attempts, 3)
+	})
+}
+
+func Test_Saga_Resume(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			array   = garray.NewStrArray(true)
+			backend = gsaga.NewBackendMemory()
+			saga    = gsaga.New("order", gsaga.Option{Backend: backend})
+			crash   = true
+		)
+		saga.AddStep(
+			newStep("order", array, false),
+			gsaga.Step{
+				Name: "inventory",
+				Action: func(ctx context.Context, instance *gsaga.Instance) error {
+					array.Append("do:inventory")
+					if crash {
+						// It simulates the crash of process, which leaves the instance running.
+						return ctx.Err()
+					}
+					return nil
+				},
+			},
+			newStep("payment", array, false),
+		)
+		crashCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		instance, err := saga.Run(crashCtx, nil)
+		t.Assert(err, context.Canceled)
+		t.Assert(instance.Status, gsaga.StatusRunning)
+
+		crash = false
+		t.AssertNil(saga.Resume(ctx))
+		instance, err = saga.Instance(ctx, instance.ID)
+		t.AssertNil(err)
+		t.Assert(instance.Status, gsaga.StatusCompleted)
+		t.Assert(instance.Data.GetVar("order").Bool(), true)
+		t.Assert(array.Slice(), []string{"do:order", "do:inventory", "do:inventory", "do:payment"})
+
+		// Resuming the instance interrupted in compensating.
+		array.Clear()
+		t.AssertNil(backend.Save(ctx, &gsaga.Instance{
+			ID:     guid.S(),
+			Saga:   "order",
+			Status: gsaga.StatusCompensating,
+			Step:   1,
+			Error:  "payment failed",
+		}))
+		t.AssertNil(saga.Resume(ctx))
+		t.Assert(array.Slice(), []string{"undo:order"})
+		instances, err := backend.Unfinished(ctx, "order")
+		t.AssertNil(err)
+		t.Assert(len(instances), 0)
+	})
+}
+
+func Test_Saga_Client(t *testing.T) {
+	s := ghttp.GetServer(guid.S())
+	s.BindHandler("/payment/{action}", func(r *ghttp.Request) {
+		if r.Get("amount").Int() > 100 {
+			r.Response.WriteStatus(http.StatusPaymentRequired, "insufficient balance")
+			return
+		}
+		r.Response.Write(r.Get("action").String(), ":", r.Get("id").String())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			array  = garray.NewStrArray(true)
+			client = gclient.New().Prefix(fmt.Sprintf("http://127.0.0.1:%d/payment", s.GetListenedPort()))
+			call   = func(action string) gsaga.StepFunc {
+				return func(ctx context.Context, instance *gsaga.Instance) error {
+					response, err := client.Post(ctx, "/"+action, map[string]any{
+						"id":     instance.ID,
+						"amount": instance.Data.GetVar("amount").Int(),
+					})
+					if err != nil {
+						return err
+					}
+					defer response.Close()
+					if response.StatusCode != http.StatusOK {
+						return gerror.NewCodef(gcode.CodeOperationFailed, "payment %s failed: %s", action, response.ReadAllString())
+					}
+					array.Append(response.ReadAllString())
+					return nil
+				}
+			}
+			saga = gsaga.New("payment").AddStep(
+				gsaga.Step{Name: "pay", Action: call("pay"), Compensate: call("refund")},
+				gsaga.Step{Name: "charge", Action: call("charge"), Timeout: time.Second},
+			)
+		)
+		instance, err := saga.Run(ctx, map[string]any{"amount": 10})
+		t.AssertNil(err)
+		t.Assert(array.Slice(), []string{"pay:" + instance.ID, "charge:" + instance.ID})
+
+		array.Clear()
+		saga.AddStep(gsaga.Step{
+			Name: "verify",
+			Action: func(ctx context.Context, instance *gsaga.Instance) error {
+				return gerror.NewCode(gcode.CodeOperationFailed, "verification failed")
+			},
+		})
+		instance, err = saga.Run(ctx, map[string]any{"amount": 10})
+		t.Assert(gerror.Code(err), gcode.CodeOperationFailed)
+		t.Assert(instance.Status, gsaga.StatusCompensated)
+		t.Assert(array.Slice(), []string{"pay:" + instance.ID, "charge:" + instance.ID, "refund:" + instance.ID})
+	})
+}
@@ -33,18 +33,36 @@ import (
 // Timer is the timer manager, which uses ticks to calculate the timing interval.
 type Timer struct {
 	mu      sync.RWMutex
-	queue   *priorityQueue // queue is a priority queue based on heap structure.
-	status  *gtype.Int     // status is the current timer status.
-	ticks   *gtype.Int64   // ticks is the proceeded interval number by the timer.
-	options TimerOptions   // timer options is used for timer configuration.
+	queue   timerQueue   // queue manages the timing jobs by their running ticks.
+	status  *gtype.Int   // status is the current timer status.
+	ticks   *gtype.Int64 // ticks is the proceeded interval number by the timer.
+	options TimerOptions // timer options is used for timer configuration.
 }
 
 // TimerOptions is the configuration object for Timer.
 type TimerOptions struct {
 	Interval time.Duration // (optional) Interval is the underlying rolling interval tick of the timer.
 	Quick    bool          // Quick is used for quick timer, which means the timer will not wait for the first interval to be elapsed.
+	Engine   Engine        // (optional) Engine is the underlying engine managing the timing jobs, which is EngineHeap in default.
 }
 
+// Engine is the underlying engine of Timer managing the timing jobs.
+type Engine string
+
+// timerQueue is the queue managing the timing jobs of Timer by their running ticks.
+type timerQueue interface {
+	// PushEntry pushes the job `entry` which runs at `ticks`.
+	PushEntry(entry *Entry, ticks int64)
+
+	// PopEntries pops and returns the jobs whose running ticks are not greater than `ticks`.
+	PopEntries(ticks int64) []*Entry
+}
+
+const (
+	EngineHeap  Engine = "heap"  // EngineHeap is the engine based on heap structure, which costs O(log n) for each pushing and popping.
+	EngineWheel Engine = "wheel" // EngineWheel is the engine based on hierarchical timing wheel, which costs O(1) for each pushing and popping.
+)
+
 // internalPanic is the custom panic for internal usage.
 type internalPanic string
 
@@ -82,3 +82,22 @@ func (q *priorityQueue) Pop() any {
 	}
 	return nil
 }
+
+// PushEntry pushes the job `entry` which runs at `ticks`, it implements the timerQueue interface.
+func (q *priorityQueue) PushEntry(entry *Entry, ticks int64) {
+	q.Push(entry, ticks)
+}
+
+// PopEntries pops and returns the jobs whose running ticks are not greater than `ticks`,
+// it implements the timerQueue interface.
+func (q *priorityQueue) PopEntries(ticks int64) []*Entry {
+	var entries []*Entry
+	for q.NextPriority() <= ticks {
+		value := q.Pop()
+		if value == nil {
+			break
+		}
+		entries = append(entries, value.(*Entry))
+	}
+	return entries
+}
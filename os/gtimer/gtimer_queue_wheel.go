@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtimer
+
+import (
+	"sync"
+)
+
+// timingWheel is a hierarchical timing wheel, which pushes and pops jobs in O(1) regardless of the
+// amount of jobs.
+//
+// Each level of the wheel has 64 slots, the slot of level 0 spans 1 tick and the slot of level n spans
+// 64^n ticks. A job is placed at the level according to its remaining ticks, and it is moved down to
+// the lower level when the wheel proceeds to the beginning of its slot, until it is due in level 0.
+type timingWheel struct {
+	mu      sync.Mutex
+	ticks   int64                                // ticks is the ticks that the wheel has proceeded to.
+	count   int                                  // count is the amount of jobs in the wheel.
+	counts  [wheelLevels]int                     // counts is the amount of jobs in each level.
+	slots   [wheelLevels][wheelSlots][]wheelItem // slots stores the jobs of all levels.
+	pending []wheelItem                          // pending stores the jobs which are already due when placed.
+}
+
+// wheelItem is the job in wheel with its running ticks.
+type wheelItem struct {
+	entry *Entry
+	ticks int64
+}
+
+const (
+	wheelLevels   = 6                                  // Levels of the wheel, which spans 64^6 ticks in total.
+	wheelSlotBits = 6                                  // Bits of slot index in each level.
+	wheelSlots    = 1 << wheelSlotBits                 // Slots of each level.
+	wheelSlotMask = wheelSlots - 1                     // Mask of slot index in each level.
+	wheelSpan     = 1 << (wheelSlotBits * wheelLevels) // Ticks spanned by the wheel.
+)
+
+// newTimingWheel creates and returns a timing wheel.
+func newTimingWheel() *timingWheel {
+	return &timingWheel{}
+}
+
+// PushEntry pushes the job `entry` which runs at `ticks`, it implements the timerQueue interface.
+func (w *timingWheel) PushEntry(entry *Entry, ticks int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.count++
+	w.place(wheelItem{entry: entry, ticks: ticks})
+}
+
+// PopEntries proceeds the wheel to `ticks`, and pops and returns the jobs whose running ticks are not
+// greater than `ticks`, it implements the timerQueue interface.
+func (w *timingWheel) PopEntries(ticks int64) []*Entry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var entries []*Entry
+	entries = w.collect(entries, &w.pending)
+	for w.ticks < ticks {
+		if w.count == 0 {
+			// Nothing to cascade, it jumps to the ticks directly.
+			w.ticks = ticks
+			break
+		}
+		// It skips the ticks in which no job is due, as the lower levels are empty,
+		// the jobs of higher levels are not moved down until the beginning of their slots.
+		level := 0
+		for level < wheelLevels-1 && w.counts[level] == 0 {
+			level++
+		}
+		if level > 0 {
+			var (
+				span = int64(1) << (wheelSlotBits * level)
+				skip = (w.ticks/span+1)*span - 1
+			)
+			if skip >= ticks {
+				w.ticks = ticks
+				break
+			}
+			w.ticks = max(w.ticks, skip)
+		}
+		w.ticks++
+		// It moves the jobs of the slots beginning at current ticks down to lower levels,
+		// from the top level to the bottom level.
+		for level := wheelLevels - 1; level > 0; level-- {
+			if w.ticks&(1<<(wheelSlotBits*level)-1) != 0 {
+				continue
+			}
+			var (
+				index = (w.ticks >> (wheelSlotBits * level)) & wheelSlotMask
+				items = w.slots[level][index]
+			)
+			w.counts[level] -= len(items)
+			for _, item := range items {
+				w.place(item)
+			}
+			clear(items)
+			w.slots[level][index] = items[:0]
+		}
+		w.counts[0] -= len(w.slots[0][w.ticks&wheelSlotMask])
+		entries = w.collect(entries, &w.slots[0][w.ticks&wheelSlotMask])
+		entries = w.collect(entries, &w.pending)
+	}
+	return entries
+}
+
+// place places the item in the slot according to its remaining ticks.
+func (w *timingWheel) place(item wheelItem) {
+	var (
+		ticks = item.ticks
+		delta = ticks - w.ticks
+	)
+	if delta <= 0 {
+		w.pending = append(w.pending, item)
+		return
+	}
+	if delta >= wheelSpan {
+		// It is beyond the wheel, which is placed at the farthest slot
+		// and placed again when it is moved down.
+		ticks = w.ticks + wheelSpan - 1
+		delta = wheelSpan - 1
+	}
+	level := 0
+	for level < wheelLevels-1 && delta >= 1<<(wheelSlotBits*(level+1)) {
+		level++
+	}
+	index := (ticks >> (wheelSlotBits * level)) & wheelSlotMask
+	w.slots[level][index] = append(w.slots[level][index], item)
+	w.counts[level]++
+}
+
+// collect appends the jobs of `items` to `entries` and returns it, the `items` is emptied for reuse.
+func (w *timingWheel) collect(entries []*Entry, items *[]wheelItem) []*Entry {
+	for _, item := range *items {
+		entries = append(entries, item.entry)
+	}
+	w.count -= len(*items)
+	clear(*items)
+	*items = (*items)[:0]
+	return entries
+}
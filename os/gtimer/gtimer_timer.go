@@ -16,7 +16,6 @@ import (
 // New creates and returns a Timer.
 func New(options ...TimerOptions) *Timer {
 	t := &Timer{
-		queue:  newPriorityQueue(),
 		status: gtype.NewInt(StatusRunning),
 		ticks:  gtype.NewInt64(),
 	}
@@ -28,6 +27,12 @@ func New(options ...TimerOptions) *Timer {
 	} else {
 		t.options = DefaultOptions()
 	}
+	switch t.options.Engine {
+	case EngineWheel:
+		t.queue = newTimingWheel()
+	default:
+		t.queue = newPriorityQueue()
+	}
 	go t.loop()
 	return t
 }
@@ -203,6 +208,6 @@ func (t *Timer) createEntry(in createEntryInput) *Entry {
 			infinite:    gtype.NewBool(infinite),
 		}
 	)
-	t.queue.Push(entry, nextTicks)
+	t.queue.PushEntry(entry, nextTicks)
 	return entry
 }
@@ -20,9 +20,8 @@ func (t *Timer) loop() {
 		switch t.status.Val() {
 		case StatusRunning:
 			// Timer proceeding.
-			if currentTimerTicks = t.ticks.Add(1); currentTimerTicks >= t.queue.NextPriority() {
-				t.proceed(currentTimerTicks)
-			}
+			currentTimerTicks = t.ticks.Add(1)
+			t.proceed(currentTimerTicks)
 
 		case StatusStopped:
 			// Do nothing.
@@ -36,25 +35,20 @@ func (t *Timer) loop() {
 
 // proceed function proceeds the timer job checking and running logic.
 func (t *Timer) proceed(currentTimerTicks int64) {
-	var value any
-	for {
-		value = t.queue.Pop()
-		if value == nil {
-			break
-		}
-		entry := value.(*Entry)
+	for _, entry := range t.queue.PopEntries(currentTimerTicks) {
 		// It checks if it meets the ticks' requirement.
 		if jobNextTicks := entry.nextTicks.Val(); currentTimerTicks < jobNextTicks {
-			// It pushes the job back if current ticks does not meet its running ticks requirement.
-			t.queue.Push(entry, entry.nextTicks.Val())
-			break
+			// It pushes the job back if current ticks does not meet its running ticks requirement,
+			// which is reset after it was pushed.
+			t.queue.PushEntry(entry, jobNextTicks)
+			continue
 		}
 		// It checks the job running requirements and then does asynchronous running.
 		entry.doCheckAndRunByTicks(currentTimerTicks)
 		// Status check: push back or ignore it.
 		if entry.Status() != StatusClosed {
 			// It pushes the job back to queue for next running.
-			t.queue.Push(entry, entry.nextTicks.Val())
+			t.queue.PushEntry(entry, entry.nextTicks.Val())
 		}
 	}
 }
@@ -27,7 +27,7 @@ func Benchmark_Add(b *testing.B) {
 
 func Benchmark_PriorityQueue_Pop(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		timer.queue.Pop()
+		timer.queue.(*priorityQueue).Pop()
 	}
 }
 
@@ -37,3 +37,51 @@ func Benchmark_StartStop(b *testing.B) {
 		timer.Stop()
 	}
 }
+
+func Benchmark_Engine_Heap_PushPop(b *testing.B) {
+	benchmarkTimerQueue(b, newPriorityQueue())
+}
+
+func Benchmark_Engine_Wheel_PushPop(b *testing.B) {
+	benchmarkTimerQueue(b, newTimingWheel())
+}
+
+func Benchmark_Engine_Heap_Add(b *testing.B) {
+	benchmarkTimerAdd(b, EngineHeap)
+}
+
+func Benchmark_Engine_Wheel_Add(b *testing.B) {
+	benchmarkTimerAdd(b, EngineWheel)
+}
+
+// benchmarkTimerQueue pushes huge amount of short-lived jobs to the queue,
+// which proceeds one tick for every 1000 pushed jobs.
+func benchmarkTimerQueue(b *testing.B, queue timerQueue) {
+	var (
+		ticks int64
+		entry = &Entry{}
+	)
+	for i := 0; i < 100000; i++ {
+		queue.PushEntry(entry, int64(i%3000)+1)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		queue.PushEntry(entry, ticks+int64(i%3000)+1)
+		if i%1000 == 0 {
+			ticks++
+			queue.PopEntries(ticks)
+		}
+	}
+}
+
+func benchmarkTimerAdd(b *testing.B, engine Engine) {
+	timer := New(TimerOptions{
+		Interval: time.Hour,
+		Engine:   engine,
+	})
+	defer timer.Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		timer.Add(ctx, time.Hour, func(ctx context.Context) {})
+	}
+}
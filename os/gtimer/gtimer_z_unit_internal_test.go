@@ -83,3 +83,72 @@ func TestTimer_PriorityQueue_FirstOneInArrayIsTheLeast(t *testing.T) {
 		}
 	})
 }
+
+func TestTimer_TimingWheel(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wheel = newTimingWheel()
+			ticks = []int64{0, 1, 2, 63, 64, 65, 4095, 4096, 4097, 262143, 262144, 300000}
+		)
+		for i := len(ticks) - 1; i >= 0; i-- {
+			wheel.PushEntry(&Entry{ticks: ticks[i]}, ticks[i])
+		}
+		// The job beyond the span of wheel.
+		wheel.PushEntry(&Entry{ticks: wheelSpan + 10}, wheelSpan+10)
+		for i := int64(0); i <= 300000; i++ {
+			for _, entry := range wheel.PopEntries(i) {
+				t.Assert(entry.ticks, i)
+				t.Assert(ticks[0], i)
+				ticks = ticks[1:]
+			}
+		}
+		t.Assert(len(ticks), 0)
+		t.Assert(wheel.count, 1)
+
+		entries := wheel.PopEntries(wheelSpan + 9)
+		t.Assert(len(entries), 0)
+		entries = wheel.PopEntries(wheelSpan + 10)
+		t.Assert(len(entries), 1)
+		t.Assert(entries[0].ticks, wheelSpan+10)
+		t.Assert(wheel.count, 0)
+	})
+}
+
+func TestTimer_TimingWheel_Random(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			size  = 100000
+			wheel = newTimingWheel()
+			array = garray.NewIntArrayRange(1, size, 1)
+		)
+		array.Shuffle()
+		array.Iterator(func(k int, v int) bool {
+			wheel.PushEntry(&Entry{ticks: int64(v)}, int64(v))
+			return true
+		})
+		for i := 1; i <= size; i++ {
+			entries := wheel.PopEntries(int64(i))
+			t.Assert(len(entries), 1)
+			t.Assert(entries[0].ticks, i)
+		}
+	})
+}
+
+func TestTimer_Proceed_Wheel(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.New(true)
+		timer := New(TimerOptions{
+			Interval: time.Hour,
+			Engine:   EngineWheel,
+		})
+		timer.Add(ctx, 10000*time.Hour, func(ctx context.Context) {
+			array.Append(1)
+		})
+		timer.proceed(10001)
+		time.Sleep(10 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+		timer.proceed(20001)
+		time.Sleep(10 * time.Millisecond)
+		t.Assert(array.Len(), 2)
+	})
+}
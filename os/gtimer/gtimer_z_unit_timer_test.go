@@ -304,3 +304,55 @@ func TestTimer_Exit(t *testing.T) {
 		t.Assert(array.Len(), 1)
 	})
 }
+
+func TestTimer_EngineWheel(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		timer := gtimer.New(gtimer.TimerOptions{
+			Interval: 10 * time.Millisecond,
+			Engine:   gtimer.EngineWheel,
+		})
+		defer timer.Close()
+		array := garray.New(true)
+		timer.Add(ctx, 200*time.Millisecond, func(ctx context.Context) {
+			array.Append(1)
+		})
+		timer.AddTimes(ctx, 100*time.Millisecond, 2, func(ctx context.Context) {
+			array.Append(2)
+		})
+		job := timer.Add(ctx, 50*time.Millisecond, func(ctx context.Context) {
+			array.Append(3)
+		})
+		job.Stop()
+		time.Sleep(450 * time.Millisecond)
+		t.Assert(array.Len(), 4)
+		t.Assert(array.Search(3), -1)
+
+		job.Start()
+		time.Sleep(80 * time.Millisecond)
+		job.Close()
+		t.AssertGE(array.Len(), 5)
+	})
+	// Singleton and reset.
+	gtest.C(t, func(t *gtest.T) {
+		timer := gtimer.New(gtimer.TimerOptions{
+			Interval: 10 * time.Millisecond,
+			Engine:   gtimer.EngineWheel,
+		})
+		defer timer.Close()
+		array := garray.New(true)
+		timer.AddSingleton(ctx, 50*time.Millisecond, func(ctx context.Context) {
+			array.Append(1)
+			time.Sleep(10 * time.Second)
+		})
+		job := timer.AddOnce(ctx, 300*time.Millisecond, func(ctx context.Context) {
+			array.Append(2)
+		})
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+		job.Reset()
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(array.Len(), 1)
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(array.Slice(), []any{1, 2})
+	})
+}
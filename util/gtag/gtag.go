@@ -50,6 +50,12 @@ const (
 	Status               = "status"          // Response status code, usually for OpenAPI in response struct.
 	ResponseExample      = "responseExample" // Response example resource path, usually for OpenAPI in response struct.
 	ResponseExampleShort = "resEg"           // Short name of ResponseExample.
+	Cache                = "cache"           // Response cache TTL for HTTP route, usually in g.Meta of request struct.
+	CacheVary            = "cacheVary"       // Request header names that vary the cached response, usually in g.Meta of request struct.
+	ETag                 = "etag"            // ETag mode for HTTP route response: strong, weak or off.
+	MaxBody              = "maxBody"         // Max request body size for HTTP route, eg: 50MB, usually in g.Meta of request struct.
+	Timeout              = "timeout"         // Handler timeout for HTTP route, eg: 3s, usually in g.Meta of request struct.
+	MaxConcurrent        = "maxConcurrent"   // Max concurrent requests for HTTP route, usually in g.Meta of request struct.
 )
 
 // StructTagPriority defines the default priority tags for Map*/Struct* functions.